@@ -5,12 +5,25 @@ import (
 	"VoizyServer/internal/database/firebase"
 	analyticsHandlers "VoizyServer/internal/handlers/analytics"
 	authHandlers "VoizyServer/internal/handlers/auth"
+	friendListHandlers "VoizyServer/internal/handlers/friendlists"
+	groupHandlers "VoizyServer/internal/handlers/groups"
+	hashtagHandlers "VoizyServer/internal/handlers/hashtags"
+	messageHandlers "VoizyServer/internal/handlers/messages"
+	notificationHandlers "VoizyServer/internal/handlers/notifications"
 	postHandlers "VoizyServer/internal/handlers/posts"
+	uploadHandlers "VoizyServer/internal/handlers/uploads"
 	userHandlers "VoizyServer/internal/handlers/users"
+	"VoizyServer/internal/imaging"
 	"VoizyServer/internal/middleware"
+	"VoizyServer/internal/notifications"
+	"VoizyServer/internal/realtime"
+	"VoizyServer/internal/storage"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"time"
 )
 
 func main() {
@@ -26,6 +39,62 @@ func main() {
 	//}
 	//defer database.RDB.Close()
 
+	// Realtime delivery uses an in-memory hub unless REALTIME_BROADCASTER=redis,
+	// which is needed once more than one API node is running.
+	var broadcaster realtime.Broadcaster = realtime.NewMemoryBroadcaster()
+	if os.Getenv("REALTIME_BROADCASTER") == "redis" {
+		if err := database.InitRedis(); err != nil {
+			log.Fatalf("Failed to init Redis: %v", err)
+		}
+		defer database.RDB.Close()
+		broadcaster = realtime.NewRedisBroadcaster(database.RDB, "voizy:realtime")
+	}
+	if err := realtime.InitHub(broadcaster); err != nil {
+		log.Fatalf("Failed to init realtime hub: %v", err)
+	}
+
+	// Notifications are pushed to NOTIFICATION_WEBHOOK_URL when it is set and only
+	// logged otherwise.
+	if webhookURL := os.Getenv("NOTIFICATION_WEBHOOK_URL"); webhookURL != "" {
+		notifications.InitDelivery(notifications.NewWebhookDeliverer(webhookURL, os.Getenv("NOTIFICATION_WEBHOOK_SECRET")))
+	} else {
+		notifications.InitDelivery(notifications.LogDeliverer{})
+	}
+
+	// Uploads go to the S3 bucket unless STORAGE_BACKEND=local, which keeps them
+	// under STORAGE_LOCAL_DIR and serves them from this server at STORAGE_PUBLIC_URL.
+	if os.Getenv("STORAGE_BACKEND") == "local" {
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "storage"
+		}
+		localStore, err := storage.NewLocalStore(dir, os.Getenv("STORAGE_PUBLIC_URL"), []byte(os.Getenv("STORAGE_SIGNING_SECRET")))
+		if err != nil {
+			log.Fatalf("Failed to init local storage: %v", err)
+		}
+		storage.Init(localStore)
+		http.Handle(storage.LocalRoutePrefix, localStore)
+	} else {
+		bucket := os.Getenv("S3_BUCKET")
+		if bucket == "" {
+			bucket = "voizy-app"
+		}
+		region := os.Getenv("AWS_REGION")
+		if region == "" {
+			region = "us-west-2"
+		}
+		s3Store, err := storage.NewS3Store(context.Background(), bucket, region)
+		if err != nil {
+			log.Fatalf("Failed to init S3 storage: %v", err)
+		}
+		storage.Init(s3Store)
+	}
+
+	go postHandlers.StartPollCloser(time.Minute)
+	go postHandlers.StartPostPurger(time.Hour)
+	go postHandlers.StartPostScheduler(time.Minute)
+	go imaging.StartWorker(time.Minute)
+
 	/// USERS ///
 	// Create and Login
 	http.HandleFunc("/users/create", userHandlers.CreateUserHandler)
@@ -53,6 +122,7 @@ func main() {
 	http.HandleFunc("/users/preferences/get", middleware.ValidateAPIKeyMiddleware(userHandlers.GetUserPreferences))
 	// Friendships
 	http.HandleFunc("/users/friends/create", middleware.CombinedAuthMiddleware(userHandlers.CreateFriendRequestHandler))
+	http.HandleFunc("/users/friends/update", middleware.CombinedAuthMiddleware(userHandlers.UpdateFriendshipHandler))
 	http.HandleFunc("/users/friends/list", middleware.ValidateAPIKeyMiddleware(userHandlers.ListFriendshipsHandler))
 	http.HandleFunc("/users/friends/list/common", middleware.ValidateAPIKeyMiddleware(userHandlers.ListFriendsInCommonHandler))
 	http.HandleFunc("/users/friends/get/total", middleware.ValidateAPIKeyMiddleware(userHandlers.GetTotalFriendsHandler))
@@ -60,6 +130,14 @@ func main() {
 	// People
 	http.HandleFunc("/users/friends/people/list", middleware.ValidateAPIKeyMiddleware(userHandlers.ListPeopleYouMayKnow))
 	http.HandleFunc("/users/people/search", middleware.ValidateAPIKeyMiddleware(userHandlers.SearchPeople))
+	// Friend Lists
+	http.HandleFunc("/users/friends/lists/create", middleware.CombinedAuthMiddleware(friendListHandlers.CreateFriendListHandler))
+	http.HandleFunc("/users/friends/lists/list", middleware.CombinedAuthMiddleware(friendListHandlers.ListFriendListsHandler))
+	http.HandleFunc("/users/friends/lists/update", middleware.CombinedAuthMiddleware(friendListHandlers.UpdateFriendListHandler))
+	http.HandleFunc("/users/friends/lists/delete", middleware.CombinedAuthMiddleware(friendListHandlers.DeleteFriendListHandler))
+	http.HandleFunc("/users/friends/lists/members/put", middleware.CombinedAuthMiddleware(friendListHandlers.PutFriendListMembersHandler))
+	http.HandleFunc("/users/friends/lists/members/delete", middleware.CombinedAuthMiddleware(friendListHandlers.DeleteFriendListMemberHandler))
+	http.HandleFunc("/users/friends/lists/members/list", middleware.CombinedAuthMiddleware(friendListHandlers.ListFriendListMembersHandler))
 
 	/// POSTS ///
 	// Posts
@@ -67,17 +145,54 @@ func main() {
 	// http.HandleFunc("/posts/get/presigned", middleware.CombinedAuthMiddleware(postHandlers.GetPresignedPutUrlHandler))
 	http.HandleFunc("/posts/batch/get/presigned", middleware.CombinedAuthMiddleware(postHandlers.GetBatchPresignedPutUrlHandler))
 	http.HandleFunc("/posts/update", middleware.CombinedAuthMiddleware(postHandlers.UpdatePostHandler))
+	http.HandleFunc("/posts/audience/update", middleware.CombinedAuthMiddleware(postHandlers.UpdatePostAudienceHandler))
 	http.HandleFunc("/posts/list", middleware.ValidateAPIKeyMiddleware(postHandlers.ListPostsHandler))
 	http.HandleFunc("/posts/get/total", middleware.ValidateAPIKeyMiddleware(postHandlers.GetTotalPostsHandler))
 	http.HandleFunc("/posts/get/details", middleware.ValidateAPIKeyMiddleware(postHandlers.GetPostDetailsHandler))
 	http.HandleFunc("/posts/get/media", middleware.ValidateAPIKeyMiddleware(postHandlers.GetPostMediaHandler))
 	http.HandleFunc("/posts/put/media", middleware.CombinedAuthMiddleware(postHandlers.PutPostMediaHandler))
 	http.HandleFunc("/posts/reactions/put", middleware.CombinedAuthMiddleware(postHandlers.PutPostReactionHandler))
+	http.HandleFunc("/posts/reactions/list", middleware.ValidateAPIKeyMiddleware(postHandlers.ListPostReactionsHandler))
+	http.HandleFunc("/posts/shares/list", middleware.ValidateAPIKeyMiddleware(postHandlers.ListPostSharesHandler))
+	http.HandleFunc("/posts/shares/get/total", middleware.ValidateAPIKeyMiddleware(postHandlers.GetTotalSharesHandler))
+	http.HandleFunc("/posts/mentions/list", middleware.CombinedAuthMiddleware(postHandlers.ListMentionedPostsHandler))
+	// Revisions
+	http.HandleFunc("/posts/revisions/list", middleware.ValidateAPIKeyMiddleware(postHandlers.ListPostRevisionsHandler))
+	http.HandleFunc("/posts/revisions/revert", middleware.CombinedAuthMiddleware(postHandlers.RevertPostHandler))
+	// Pins
+	http.HandleFunc("/posts/pins/put", middleware.CombinedAuthMiddleware(postHandlers.PutPinnedPostHandler))
+	http.HandleFunc("/posts/pins/delete", middleware.CombinedAuthMiddleware(postHandlers.DeletePinnedPostHandler))
+	http.HandleFunc("/posts/pins/reorder", middleware.CombinedAuthMiddleware(postHandlers.ReorderPinnedPostsHandler))
+	// Saved
+	http.HandleFunc("/posts/saved/put", middleware.CombinedAuthMiddleware(postHandlers.PutSavedPostHandler))
+	http.HandleFunc("/posts/saved/delete", middleware.CombinedAuthMiddleware(postHandlers.DeleteSavedPostHandler))
+	http.HandleFunc("/posts/saved/list", middleware.CombinedAuthMiddleware(postHandlers.ListSavedPostsHandler))
+	http.HandleFunc("/posts/saved/collections/create", middleware.CombinedAuthMiddleware(postHandlers.CreateSavedCollectionHandler))
+	http.HandleFunc("/posts/saved/collections/list", middleware.CombinedAuthMiddleware(postHandlers.ListSavedCollectionsHandler))
+	http.HandleFunc("/posts/saved/collections/update", middleware.CombinedAuthMiddleware(postHandlers.UpdateSavedCollectionHandler))
+	http.HandleFunc("/posts/saved/collections/reorder", middleware.CombinedAuthMiddleware(postHandlers.ReorderSavedCollectionsHandler))
+	http.HandleFunc("/posts/saved/collections/delete", middleware.CombinedAuthMiddleware(postHandlers.DeleteSavedCollectionHandler))
+	// Trash
+	http.HandleFunc("/posts/delete", middleware.CombinedAuthMiddleware(postHandlers.DeletePostHandler))
+	http.HandleFunc("/posts/trash/list", middleware.CombinedAuthMiddleware(postHandlers.ListTrashedPostsHandler))
+	http.HandleFunc("/posts/trash/restore", middleware.CombinedAuthMiddleware(postHandlers.RestorePostHandler))
+	// Drafts
+	http.HandleFunc("/posts/drafts/save", middleware.CombinedAuthMiddleware(postHandlers.SaveDraftHandler))
+	http.HandleFunc("/posts/drafts/list", middleware.CombinedAuthMiddleware(postHandlers.ListDraftsHandler))
+	http.HandleFunc("/posts/drafts/update", middleware.CombinedAuthMiddleware(postHandlers.UpdateDraftHandler))
+	http.HandleFunc("/posts/drafts/publish", middleware.CombinedAuthMiddleware(postHandlers.PublishDraftHandler))
 	// Comments
 	http.HandleFunc("/posts/comments/get/total", middleware.ValidateAPIKeyMiddleware(postHandlers.GetTotalCommentsHandler))
 	http.HandleFunc("/posts/comments/put", middleware.CombinedAuthMiddleware(postHandlers.PutCommentHandler))
+	http.HandleFunc("/posts/comments/update", middleware.CombinedAuthMiddleware(postHandlers.UpdateCommentHandler))
+	http.HandleFunc("/posts/comments/delete", middleware.CombinedAuthMiddleware(postHandlers.DeleteCommentHandler))
 	http.HandleFunc("/posts/comments/list", middleware.ValidateAPIKeyMiddleware(postHandlers.ListPostCommentsHandler))
 	http.HandleFunc("/posts/comments/reactions/put", middleware.CombinedAuthMiddleware(postHandlers.PutCommentReactionHandler))
+	http.HandleFunc("/posts/comments/reactions/list", middleware.ValidateAPIKeyMiddleware(postHandlers.ListCommentReactionsHandler))
+	// Polls
+	http.HandleFunc("/posts/polls/votes/put", middleware.CombinedAuthMiddleware(postHandlers.PutPollVoteHandler))
+	http.HandleFunc("/posts/polls/votes/delete", middleware.CombinedAuthMiddleware(postHandlers.DeletePollVoteHandler))
+	http.HandleFunc("/posts/polls/results/get", middleware.ValidateAPIKeyMiddleware(postHandlers.GetPollResultsHandler))
 	// Feeds
 	http.HandleFunc("/posts/feed/list", middleware.ValidateAPIKeyMiddleware(postHandlers.ListFeedHandler))
 	http.HandleFunc("/posts/feed/recommended/get", middleware.ValidateAPIKeyMiddleware(postHandlers.GetRecommendedFeed))
@@ -88,6 +203,52 @@ func main() {
 	http.HandleFunc("/posts/impressions/put", middleware.ValidateAPIKeyMiddleware(postHandlers.PutPostImpressionHandler))
 	http.HandleFunc("/posts/views/put", middleware.ValidateAPIKeyMiddleware(postHandlers.PutPostViewHandler))
 
+	/// UPLOADS ///
+	http.HandleFunc("/uploads/confirm", middleware.CombinedAuthMiddleware(uploadHandlers.ConfirmUploadsHandler))
+	http.HandleFunc("/uploads/multipart/initiate", middleware.CombinedAuthMiddleware(uploadHandlers.InitiateMultipartUploadHandler))
+	http.HandleFunc("/uploads/multipart/parts/presign", middleware.CombinedAuthMiddleware(uploadHandlers.PresignMultipartPartsHandler))
+	http.HandleFunc("/uploads/multipart/complete", middleware.CombinedAuthMiddleware(uploadHandlers.CompleteMultipartUploadHandler))
+	http.HandleFunc("/uploads/multipart/abort", middleware.CombinedAuthMiddleware(uploadHandlers.AbortMultipartUploadHandler))
+
+	/// HASHTAGS ///
+	http.HandleFunc("/hashtags/trending/list", middleware.ValidateAPIKeyMiddleware(hashtagHandlers.ListTrendingHashtagsHandler))
+	http.HandleFunc("/hashtags/autocomplete/list", middleware.ValidateAPIKeyMiddleware(hashtagHandlers.AutocompleteHashtagsHandler))
+	http.HandleFunc("/hashtags/feed/get", middleware.ValidateAPIKeyMiddleware(hashtagHandlers.GetHashtagFeedHandler))
+
+	/// MESSAGES ///
+	// Conversations
+	http.HandleFunc("/messages/conversations/create", middleware.CombinedAuthMiddleware(messageHandlers.CreateConversationHandler))
+	http.HandleFunc("/messages/conversations/list", middleware.CombinedAuthMiddleware(messageHandlers.ListConversationsHandler))
+	http.HandleFunc("/messages/conversations/members/add", middleware.CombinedAuthMiddleware(messageHandlers.AddConversationMembersHandler))
+	http.HandleFunc("/messages/conversations/members/remove", middleware.CombinedAuthMiddleware(messageHandlers.RemoveConversationMemberHandler))
+	// Messages
+	http.HandleFunc("/messages/send", middleware.CombinedAuthMiddleware(messageHandlers.SendMessageHandler))
+	http.HandleFunc("/messages/voice/presign", middleware.CombinedAuthMiddleware(messageHandlers.PresignVoiceNoteHandler))
+	http.HandleFunc("/messages/list", middleware.CombinedAuthMiddleware(messageHandlers.ListMessagesHandler))
+	http.HandleFunc("/messages/read/put", middleware.CombinedAuthMiddleware(messageHandlers.MarkMessagesReadHandler))
+	http.HandleFunc("/messages/reactions/put", middleware.CombinedAuthMiddleware(messageHandlers.PutMessageReactionHandler))
+	// Realtime
+	http.HandleFunc("/ws", middleware.CombinedAuthMiddleware(realtime.ServeWS))
+
+	/// GROUPS ///
+	http.HandleFunc("/groups/create", middleware.CombinedAuthMiddleware(groupHandlers.CreateGroupHandler))
+	http.HandleFunc("/groups/update", middleware.CombinedAuthMiddleware(groupHandlers.UpdateGroupHandler))
+	http.HandleFunc("/groups/get", middleware.ValidateAPIKeyMiddleware(groupHandlers.GetGroupHandler))
+	http.HandleFunc("/groups/list", middleware.ValidateAPIKeyMiddleware(groupHandlers.ListGroupsHandler))
+	http.HandleFunc("/groups/feed/get", middleware.ValidateAPIKeyMiddleware(groupHandlers.GetGroupFeedHandler))
+	// Membership
+	http.HandleFunc("/groups/join", middleware.CombinedAuthMiddleware(groupHandlers.JoinGroupHandler))
+	http.HandleFunc("/groups/leave", middleware.CombinedAuthMiddleware(groupHandlers.LeaveGroupHandler))
+	http.HandleFunc("/groups/members/list", middleware.ValidateAPIKeyMiddleware(groupHandlers.ListGroupMembersHandler))
+	http.HandleFunc("/groups/members/role/update", middleware.CombinedAuthMiddleware(groupHandlers.UpdateMemberRoleHandler))
+	http.HandleFunc("/groups/requests/list", middleware.CombinedAuthMiddleware(groupHandlers.ListJoinRequestsHandler))
+	http.HandleFunc("/groups/requests/respond", middleware.CombinedAuthMiddleware(groupHandlers.RespondJoinRequestHandler))
+
+	/// NOTIFICATIONS ///
+	http.HandleFunc("/notifications/list", middleware.CombinedAuthMiddleware(notificationHandlers.ListNotificationsHandler))
+	http.HandleFunc("/notifications/read/put", middleware.CombinedAuthMiddleware(notificationHandlers.MarkNotificationsReadHandler))
+	http.HandleFunc("/notifications/unread/get/total", middleware.CombinedAuthMiddleware(notificationHandlers.GetUnreadCountHandler))
+
 	/// ANALYTICS
 	http.HandleFunc("/analytics/track", middleware.CombinedAuthMiddleware(analyticsHandlers.BatchTrackEventsHandler))
 	http.HandleFunc("/analytics/events/list", middleware.CombinedAuthMiddleware(analyticsHandlers.ListEventsHandler))
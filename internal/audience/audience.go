@@ -0,0 +1,136 @@
+// Package audience decides who may see a post. Every post has an audience chosen
+// by its author; the author and, for wall posts, the user whose wall it is always
+// see it, and blocks are enforced separately by package blocks. Posts in private
+// or closed groups are further limited to the group's members.
+package audience
+
+import (
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const (
+	Public           = "public"
+	Friends          = "friends"
+	FriendsOfFriends = "friends_of_friends"
+	OnlyMe           = "only_me"
+	Custom           = "custom"
+)
+
+// ErrNotVisible is returned for posts the viewer is not in the audience of. Handlers
+// report it like a missing post so the post's existence is not revealed.
+var ErrNotVisible = errors.New("post is not visible to this user")
+
+// Valid reports whether a is a known audience.
+func Valid(a string) bool {
+	switch a {
+	case Public, Friends, FriendsOfFriends, OnlyMe, Custom:
+		return true
+	}
+	return false
+}
+
+// Visible returns a SQL predicate that keeps only the posts viewerID is in the
+// audience of and whose group, if any, viewerID can read, along with the arguments
+// for its placeholders. postAlias is interpolated into the query, so it must be a
+// table name or alias and never user input.
+//
+//	clause, args := audience.Visible("p", viewerID)
+//	query := "SELECT ... FROM posts p WHERE p.to_user_id = -1 AND " + clause
+func Visible(postAlias string, viewerID int64) (string, []interface{}) {
+	clause := fmt.Sprintf(`((
+			%[1]s.group_id IS NULL
+			OR %[1]s.user_id = ?
+			OR EXISTS (
+				SELECT 1
+				FROM groups_table aud_g
+				WHERE aud_g.group_id = %[1]s.group_id AND aud_g.privacy = 'public'
+			)
+			OR EXISTS (
+				SELECT 1
+				FROM group_members aud_gm
+				WHERE aud_gm.group_id = %[1]s.group_id AND aud_gm.user_id = ?
+			)
+		) AND (
+			%[1]s.user_id = ?
+			OR %[1]s.to_user_id = ?
+			OR %[1]s.audience = 'public'
+			OR (%[1]s.audience IN ('friends', 'friends_of_friends') AND EXISTS (
+				SELECT 1
+				FROM friendships aud_f
+				WHERE aud_f.status = 'accepted'
+					AND aud_f.user_low = LEAST(%[1]s.user_id, ?)
+					AND aud_f.user_high = GREATEST(%[1]s.user_id, ?)
+			))
+			OR (%[1]s.audience = 'friends_of_friends' AND EXISTS (
+				SELECT 1
+				FROM friendships aud_f1
+				JOIN friendships aud_f2
+					ON aud_f2.status = 'accepted'
+					AND aud_f2.user_low = LEAST(IF(aud_f1.user_id = %[1]s.user_id, aud_f1.friend_id, aud_f1.user_id), ?)
+					AND aud_f2.user_high = GREATEST(IF(aud_f1.user_id = %[1]s.user_id, aud_f1.friend_id, aud_f1.user_id), ?)
+				WHERE aud_f1.status = 'accepted'
+					AND (aud_f1.user_id = %[1]s.user_id OR aud_f1.friend_id = %[1]s.user_id)
+			))
+			OR (%[1]s.audience = 'custom' AND EXISTS (
+				SELECT 1
+				FROM friend_list_members aud_m
+				JOIN friend_lists aud_l ON aud_l.list_id = aud_m.list_id
+				WHERE aud_m.list_id = %[1]s.audience_list_id
+					AND aud_l.user_id = %[1]s.user_id
+					AND aud_m.member_id = ?
+			))
+		))`, postAlias)
+	return clause, []interface{}{viewerID, viewerID, viewerID, viewerID, viewerID, viewerID, viewerID, viewerID, viewerID}
+}
+
+// CanView reports whether viewerID is in the audience of the post. It reports false
+// for posts that do not exist.
+func CanView(postID, viewerID int64) (bool, error) {
+	clause, args := Visible("p", viewerID)
+	var exists int
+	err := database.DB.QueryRow(`SELECT 1 FROM posts p WHERE p.post_id = ? AND `+clause, append([]interface{}{postID}, args...)...).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check post audience: %w", err)
+	}
+
+	return true, nil
+}
+
+// Check returns ErrNotVisible when viewerID is not in the audience of the post.
+func Check(postID, viewerID int64) error {
+	visible, err := CanView(postID, viewerID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrNotVisible
+	}
+	return nil
+}
+
+// ErrListNotFound is returned for friend lists that do not exist or belong to
+// another user.
+var ErrListNotFound = errors.New("friend list not found")
+
+// CheckList returns ErrListNotFound unless listID is one of userID's friend lists,
+// so users can only target their own lists with a custom audience.
+func CheckList(userID, listID int64) error {
+	var ownerID int64
+	err := database.DB.QueryRow(`SELECT user_id FROM friend_lists WHERE list_id = ?`, listID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		return ErrListNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get friend list: %w", err)
+	}
+	if ownerID != userID {
+		return ErrListNotFound
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Package blocks enforces user blocks recorded in friendships. A pair of users is
+// blocked when their single friendships row has status 'blocked', regardless of
+// which of the two placed the block, and blocked users never see or reach each other.
+package blocks
+
+import (
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrBlocked is returned for writes between two users where one has blocked the other.
+var ErrBlocked = errors.New("one of these users has blocked the other")
+
+// IsBlocked reports whether either user has blocked the other.
+func IsBlocked(userID, otherUserID int64) (bool, error) {
+	if userID == otherUserID {
+		return false, nil
+	}
+
+	var exists int
+	query := `
+		SELECT 1
+		FROM friendships
+		WHERE user_low = LEAST(?, ?) AND user_high = GREATEST(?, ?) AND status = 'blocked'
+		LIMIT 1
+	`
+	err := database.DB.QueryRow(query, userID, otherUserID, userID, otherUserID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	return true, nil
+}
+
+// Check returns ErrBlocked when either user has blocked the other.
+func Check(userID, otherUserID int64) error {
+	blocked, err := IsBlocked(userID, otherUserID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+	return nil
+}
+
+// HTTPStatus maps an error from Check to a response status: forbidden for a block,
+// internal server error for anything else.
+func HTTPStatus(err error) int {
+	if errors.Is(err, ErrBlocked) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// Exclude returns a SQL predicate that drops rows whose userColumn is in a block
+// with viewerID, along with the arguments for its placeholders. userColumn is
+// interpolated into the query, so it must be a column name and never user input,
+// and it must be qualified with its table or alias because the predicate's own
+// subquery reads friendships, which has a user_id column too.
+//
+//	clause, args := blocks.Exclude("p.user_id", viewerID)
+//	query := "SELECT ... FROM posts p WHERE p.to_user_id = -1 AND " + clause
+func Exclude(userColumn string, viewerID int64) (string, []interface{}) {
+	clause := fmt.Sprintf(`NOT EXISTS (
+			SELECT 1
+			FROM friendships blk
+			WHERE blk.status = 'blocked'
+				AND blk.user_low = LEAST(%[1]s, ?)
+				AND blk.user_high = GREATEST(%[1]s, ?)
+		)`, userColumn)
+	return clause, []interface{}{viewerID, viewerID}
+}
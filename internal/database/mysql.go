@@ -151,6 +151,12 @@ func createTables() error {
 		profile_secondary_color  VARCHAR(32) NOT NULL DEFAULT 'magenta',
 		profile_secondary_accent VARCHAR(32) NOT NULL DEFAULT 'pale-magenta',
 		profile_song_autoplay    BOOLEAN NOT NULL DEFAULT 0,
+		push_notifications       BOOLEAN NOT NULL DEFAULT 1,
+		notify_post_reactions    BOOLEAN NOT NULL DEFAULT 1,
+		notify_post_comments     BOOLEAN NOT NULL DEFAULT 1,
+		notify_friend_requests   BOOLEAN NOT NULL DEFAULT 1,
+		notify_wall_posts        BOOLEAN NOT NULL DEFAULT 1,
+		notify_mentions          BOOLEAN NOT NULL DEFAULT 1,
 		updated_at	             DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 		FOREIGN KEY (user_id)    REFERENCES users(user_id) ON DELETE CASCADE
 	);
@@ -164,10 +170,32 @@ func createTables() error {
 		friend_id     BIGINT NOT NULL,
 		status        ENUM('pending','accepted','blocked') NOT NULL DEFAULT 'pending',
 		created_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		user_low      BIGINT AS (LEAST(user_id, friend_id)) STORED,
+		user_high     BIGINT AS (GREATEST(user_id, friend_id)) STORED,
 		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
 		FOREIGN KEY (friend_id) REFERENCES users(user_id) ON DELETE CASCADE
 	);`
 
+	friendListsTable := `
+	CREATE TABLE IF NOT EXISTS friend_lists (
+		list_id    BIGINT AUTO_INCREMENT PRIMARY KEY,
+		user_id    BIGINT NOT NULL,
+		name       VARCHAR(100) NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+	);`
+
+	friendListMembersTable := `
+	CREATE TABLE IF NOT EXISTS friend_list_members (
+		list_member_id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		list_id        BIGINT NOT NULL,
+		member_id      BIGINT NOT NULL,
+		added_at       DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (list_id) REFERENCES friend_lists(list_id) ON DELETE CASCADE,
+		FOREIGN KEY (member_id) REFERENCES users(user_id) ON DELETE CASCADE
+	);`
+
 	// Groups
 	groupsTable := `
 	CREATE TABLE IF NOT EXISTS groups_table (
@@ -177,6 +205,7 @@ func createTables() error {
 		privacy     ENUM('public','private','closed') NOT NULL DEFAULT 'public',
 		creator_id  BIGINT NOT NULL,
 		created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 		FOREIGN KEY (creator_id) REFERENCES users(user_id) ON DELETE CASCADE
 	);`
 
@@ -191,6 +220,20 @@ func createTables() error {
 		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
 	);`
 
+	groupJoinRequestsTable := `
+	CREATE TABLE IF NOT EXISTS group_join_requests (
+		group_join_request_id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		group_id              BIGINT NOT NULL,
+		user_id               BIGINT NOT NULL,
+		status                ENUM('pending','approved','declined') NOT NULL DEFAULT 'pending',
+		reviewed_by           BIGINT NULL DEFAULT NULL,
+		created_at            DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		reviewed_at           DATETIME,
+		FOREIGN KEY (group_id) REFERENCES groups_table(group_id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+		FOREIGN KEY (reviewed_by) REFERENCES users(user_id) ON DELETE SET NULL
+	);`
+
 	// Posts
 	postsTable := `
 	CREATE TABLE IF NOT EXISTS posts (
@@ -211,7 +254,17 @@ func createTables() error {
 		poll_duration_type ENUM('hours','days','weeks') DEFAULT 'days',
 		poll_duration_length INT DEFAULT 1,
 		poll_end_datetime  DATETIME,
+		poll_closed        BOOLEAN NOT NULL DEFAULT 0,
+		group_id           BIGINT NULL DEFAULT NULL,
+		deleted_at         DATETIME NULL DEFAULT NULL,
+		audience           ENUM('public','friends','friends_of_friends','only_me','custom') NOT NULL DEFAULT 'public',
+		audience_list_id   BIGINT NULL DEFAULT NULL,
+		status             ENUM('published','draft','scheduled') NOT NULL DEFAULT 'published',
+		publish_at         DATETIME NULL DEFAULT NULL,
+		edited_at          DATETIME NULL DEFAULT NULL,
+		is_share           BOOLEAN NOT NULL DEFAULT 0,
 		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+		FOREIGN KEY (group_id) REFERENCES groups_table(group_id) ON DELETE CASCADE,
 	    FOREIGN KEY (original_post_id) REFERENCES posts(post_id) ON DELETE SET NULL
 	);`
 
@@ -265,14 +318,34 @@ func createTables() error {
 
 	commentsTable := `
 	CREATE TABLE IF NOT EXISTS comments (
-		comment_id   BIGINT AUTO_INCREMENT PRIMARY KEY,
-		post_id      BIGINT NOT NULL,
-		user_id      BIGINT NOT NULL,
-		content_text TEXT NOT NULL,
-		created_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-		updated_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		comment_id        BIGINT AUTO_INCREMENT PRIMARY KEY,
+		post_id           BIGINT NOT NULL,
+		user_id           BIGINT NOT NULL,
+		parent_comment_id BIGINT NULL DEFAULT NULL,
+		depth             TINYINT NOT NULL DEFAULT 0,
+		content_text      TEXT NOT NULL,
+		created_at        DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at        DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		edited_at         DATETIME NULL DEFAULT NULL,
 		FOREIGN KEY (post_id) REFERENCES posts(post_id) ON DELETE CASCADE,
-		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+		FOREIGN KEY (parent_comment_id) REFERENCES comments(comment_id) ON DELETE CASCADE
+	);`
+
+	mentionsTable := `
+	CREATE TABLE IF NOT EXISTS mentions (
+		mention_id        BIGINT AUTO_INCREMENT PRIMARY KEY,
+		post_id           BIGINT NOT NULL,
+		comment_id        BIGINT NULL DEFAULT NULL,
+		mentioned_user_id BIGINT NOT NULL,
+		mentioner_id      BIGINT NOT NULL,
+		start_offset      INT NOT NULL,
+		end_offset        INT NOT NULL,
+		created_at        DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (post_id) REFERENCES posts(post_id) ON DELETE CASCADE,
+		FOREIGN KEY (comment_id) REFERENCES comments(comment_id) ON DELETE CASCADE,
+		FOREIGN KEY (mentioned_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+		FOREIGN KEY (mentioner_id) REFERENCES users(user_id) ON DELETE CASCADE
 	);`
 
 	commentReactionsTable := `
@@ -288,10 +361,11 @@ func createTables() error {
 
 	postSharesTable := `
 	CREATE TABLE IF NOT EXISTS post_shares (
-		share_id   BIGINT AUTO_INCREMENT PRIMARY KEY,
-		post_id    BIGINT NOT NULL,
-		user_id    BIGINT NOT NULL,
-		shared_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		share_id      BIGINT AUTO_INCREMENT PRIMARY KEY,
+		post_id       BIGINT NOT NULL,
+		user_id       BIGINT NOT NULL,
+		share_post_id BIGINT NULL DEFAULT NULL,
+		shared_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (post_id) REFERENCES posts(post_id) ON DELETE CASCADE,
 		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
 	);`
@@ -301,11 +375,110 @@ func createTables() error {
 		media_id    BIGINT AUTO_INCREMENT PRIMARY KEY,
 		post_id     BIGINT NOT NULL,
 		media_url   VARCHAR(255) NOT NULL,
-		media_type  ENUM('image','video') NOT NULL,
+		media_type  ENUM('image','video','audio') NOT NULL,
 		uploaded_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (post_id) REFERENCES posts(post_id) ON DELETE CASCADE
 	);`
 
+	postRevisionsTable := `
+	CREATE TABLE IF NOT EXISTS post_revisions (
+		revision_id     BIGINT AUTO_INCREMENT PRIMARY KEY,
+		post_id         BIGINT NOT NULL,
+		revision_number INT NOT NULL,
+		editor_id       BIGINT NOT NULL,
+		content_text    TEXT,
+		location_name   VARCHAR(255),
+		location_lat    DECIMAL(9,6),
+		location_lng    DECIMAL(9,6),
+		created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (post_id) REFERENCES posts(post_id) ON DELETE CASCADE,
+		FOREIGN KEY (editor_id) REFERENCES users(user_id) ON DELETE CASCADE
+	);`
+
+	postRevisionMediaTable := `
+	CREATE TABLE IF NOT EXISTS post_revision_media (
+		revision_media_id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		revision_id       BIGINT NOT NULL,
+		media_url         VARCHAR(255) NOT NULL,
+		media_type        ENUM('image','video','audio') NOT NULL,
+		FOREIGN KEY (revision_id) REFERENCES post_revisions(revision_id) ON DELETE CASCADE
+	);`
+
+	savedCollectionsTable := `
+	CREATE TABLE IF NOT EXISTS saved_collections (
+		collection_id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		user_id       BIGINT NOT NULL,
+		name          VARCHAR(100) NOT NULL,
+		position      INT NOT NULL DEFAULT 0,
+		created_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+	);`
+
+	savedPostsTable := `
+	CREATE TABLE IF NOT EXISTS saved_posts (
+		saved_post_id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		user_id       BIGINT NOT NULL,
+		post_id       BIGINT NOT NULL,
+		collection_id BIGINT NULL DEFAULT NULL,
+		saved_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+		FOREIGN KEY (post_id) REFERENCES posts(post_id) ON DELETE CASCADE,
+		FOREIGN KEY (collection_id) REFERENCES saved_collections(collection_id) ON DELETE SET NULL
+	);`
+
+	pinnedPostsTable := `
+	CREATE TABLE IF NOT EXISTS pinned_posts (
+		pinned_post_id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		user_id        BIGINT NOT NULL,
+		post_id        BIGINT NOT NULL,
+		position       INT NOT NULL DEFAULT 0,
+		pinned_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+		FOREIGN KEY (post_id) REFERENCES posts(post_id) ON DELETE CASCADE
+	);`
+
+	uploadsTable := `
+	CREATE TABLE IF NOT EXISTS uploads (
+		upload_id     BIGINT AUTO_INCREMENT PRIMARY KEY,
+		user_id       BIGINT NOT NULL,
+		object_key    VARCHAR(512) NOT NULL,
+		purpose       ENUM('post_media','user_image','voice_note') NOT NULL,
+		content_type  VARCHAR(100) NOT NULL,
+		declared_size BIGINT NULL DEFAULT NULL,
+		size          BIGINT NULL DEFAULT NULL,
+		status        ENUM('pending','confirmed','rejected') NOT NULL DEFAULT 'pending',
+		created_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		confirmed_at  DATETIME NULL DEFAULT NULL,
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+	);`
+
+	imageVariantsTable := `
+	CREATE TABLE IF NOT EXISTS image_variants (
+		image_variant_id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		upload_id        BIGINT NOT NULL,
+		object_key       VARCHAR(600) NOT NULL,
+		width            INT NOT NULL,
+		height           INT NOT NULL,
+		content_type     VARCHAR(100) NOT NULL,
+		created_at       DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (upload_id) REFERENCES uploads(upload_id) ON DELETE CASCADE
+	);`
+
+	linkPreviewsTable := `
+	CREATE TABLE IF NOT EXISTS link_previews (
+		link_preview_id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		url_hash        CHAR(64) NOT NULL,
+		url             TEXT NOT NULL,
+		status          ENUM('ok','failed') NOT NULL,
+		title           VARCHAR(300) NULL DEFAULT NULL,
+		description     TEXT NULL,
+		image_url       TEXT NULL,
+		site_name       VARCHAR(100) NULL DEFAULT NULL,
+		fetched_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		expires_at      DATETIME NOT NULL
+	);`
+
 	postViewsTable := `
 	CREATE TABLE IF NOT EXISTS post_views (
 		view_id BIGINT AUTO_INCREMENT PRIMARY KEY,
@@ -323,7 +496,10 @@ func createTables() error {
 		conversation_id BIGINT AUTO_INCREMENT PRIMARY KEY,
 		conversation_name VARCHAR(255),
 		is_group_chat   BOOLEAN NOT NULL DEFAULT 0,
-		created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		created_by      BIGINT NULL DEFAULT NULL,
+		created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		FOREIGN KEY (created_by) REFERENCES users(user_id) ON DELETE SET NULL
 	);`
 
 	conversationMembersTable := `
@@ -363,7 +539,7 @@ func createTables() error {
 		attachment_id BIGINT AUTO_INCREMENT PRIMARY KEY,
 		message_id    BIGINT NOT NULL,
 		file_url      VARCHAR(255) NOT NULL,
-		file_type     ENUM('image','video','doc') DEFAULT 'image',
+		file_type     ENUM('image','video','doc','audio') DEFAULT 'image',
 		uploaded_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (message_id) REFERENCES messages(message_id) ON DELETE CASCADE
 	);`
@@ -392,6 +568,37 @@ func createTables() error {
 		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
 	);`
 
+	// Notifications
+	notificationsTable := `
+	CREATE TABLE IF NOT EXISTS notifications (
+		notification_id   BIGINT AUTO_INCREMENT PRIMARY KEY,
+		user_id           BIGINT NOT NULL,
+		actor_id          BIGINT NOT NULL,
+		notification_type VARCHAR(50) NOT NULL,
+		object_type       VARCHAR(50) NOT NULL,
+		object_id         BIGINT NOT NULL,
+		group_key         VARCHAR(150) NOT NULL,
+		is_read           BOOLEAN NOT NULL DEFAULT 0,
+		created_at        DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		read_at           DATETIME NULL DEFAULT NULL,
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+		FOREIGN KEY (actor_id) REFERENCES users(user_id) ON DELETE CASCADE
+	);`
+
+	notificationDeadLettersTable := `
+	CREATE TABLE IF NOT EXISTS notification_dead_letters (
+		dead_letter_id  BIGINT AUTO_INCREMENT PRIMARY KEY,
+		notification_id BIGINT NOT NULL,
+		user_id         BIGINT NOT NULL,
+		deliverer       VARCHAR(50) NOT NULL,
+		payload         JSON NOT NULL,
+		attempts        INT NOT NULL,
+		last_error      TEXT,
+		created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (notification_id) REFERENCES notifications(notification_id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+	);`
+
 	// Indexes
 	indexQueries := []string{
 		`CREATE INDEX idx_post_views_post_id ON post_views(post_id);`,
@@ -403,6 +610,157 @@ func createTables() error {
 		`CREATE INDEX idx_user_profiles_user_id ON user_profiles (user_id);`,
 		`CREATE INDEX idx_user_images_profile_pic ON user_images (user_id, is_profile_pic);`,
 		`CREATE UNIQUE INDEX uq_user_preferences_user_id ON user_preferences (user_id);`,
+		`CREATE UNIQUE INDEX uq_conversation_members ON conversation_members (conversation_id, user_id);`,
+		`CREATE INDEX idx_conversation_members_user_id ON conversation_members (user_id);`,
+		`CREATE INDEX idx_messages_conversation_sent ON messages (conversation_id, sent_at);`,
+		`CREATE UNIQUE INDEX uq_message_recipients ON message_recipients (message_id, recipient_id);`,
+		`CREATE INDEX idx_message_recipients_unread ON message_recipients (recipient_id, is_read);`,
+		`CREATE UNIQUE INDEX uq_message_reactions ON message_reactions (message_id, user_id);`,
+		`CREATE UNIQUE INDEX uq_group_members ON group_members (group_id, user_id);`,
+		`CREATE INDEX idx_group_members_user_id ON group_members (user_id);`,
+		`CREATE INDEX idx_group_join_requests_group_status ON group_join_requests (group_id, status);`,
+		`CREATE INDEX idx_posts_group_id ON posts (group_id, created_at);`,
+		`CREATE UNIQUE INDEX uq_poll_votes ON poll_votes (post_id, user_id);`,
+		`CREATE INDEX idx_posts_poll_end ON posts (is_poll, poll_closed, poll_end_datetime);`,
+		`CREATE UNIQUE INDEX uq_friendships_pair ON friendships (user_low, user_high);`,
+		`CREATE INDEX idx_comments_post_parent ON comments (post_id, parent_comment_id, created_at);`,
+		`CREATE INDEX idx_mentions_user_created ON mentions (mentioned_user_id, comment_id, created_at);`,
+		`CREATE INDEX idx_mentions_post ON mentions (post_id, comment_id);`,
+		`CREATE UNIQUE INDEX uq_post_hashtags ON post_hashtags (post_id, hashtag_id);`,
+		`CREATE INDEX idx_post_hashtags_hashtag ON post_hashtags (hashtag_id, post_id);`,
+		`CREATE INDEX idx_notifications_user_read ON notifications (user_id, is_read, group_key);`,
+		`CREATE INDEX idx_notifications_user_created ON notifications (user_id, created_at);`,
+		`CREATE INDEX idx_posts_deleted_at ON posts (deleted_at);`,
+		`CREATE INDEX idx_posts_status_publish_at ON posts (status, publish_at);`,
+		`CREATE UNIQUE INDEX uq_friend_lists_user_name ON friend_lists (user_id, name);`,
+		`CREATE UNIQUE INDEX uq_friend_list_members ON friend_list_members (list_id, member_id);`,
+		`CREATE INDEX idx_friend_list_members_member ON friend_list_members (member_id);`,
+		`CREATE UNIQUE INDEX uq_post_revisions_number ON post_revisions (post_id, revision_number);`,
+		`CREATE UNIQUE INDEX uq_saved_collections_user_name ON saved_collections (user_id, name);`,
+		`CREATE UNIQUE INDEX uq_saved_posts ON saved_posts (user_id, post_id);`,
+		`CREATE INDEX idx_saved_posts_user_collection ON saved_posts (user_id, collection_id, saved_at);`,
+		`CREATE UNIQUE INDEX uq_pinned_posts_post ON pinned_posts (post_id);`,
+		`CREATE INDEX idx_pinned_posts_user_position ON pinned_posts (user_id, position);`,
+		`CREATE UNIQUE INDEX uq_post_reactions ON post_reactions (post_id, user_id);`,
+		`CREATE UNIQUE INDEX uq_comment_reactions ON comment_reactions (comment_id, user_id);`,
+		`CREATE INDEX idx_post_shares_post_shared ON post_shares (post_id, shared_at);`,
+		`CREATE INDEX idx_post_shares_share_post ON post_shares (share_post_id);`,
+		`CREATE UNIQUE INDEX uq_link_previews_url_hash ON link_previews (url_hash);`,
+		`CREATE UNIQUE INDEX uq_uploads_object_key ON uploads (object_key);`,
+		`CREATE INDEX idx_uploads_processing ON uploads (status, processing_status);`,
+		`CREATE UNIQUE INDEX uq_image_variants_upload_width ON image_variants (upload_id, width);`,
+	}
+
+	// Columns added to tables that may already exist from an older schema
+	alterQueries := []string{
+		`ALTER TABLE conversations ADD COLUMN created_by BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE conversations ADD COLUMN updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP;`,
+		`ALTER TABLE groups_table ADD COLUMN updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP;`,
+		`ALTER TABLE posts ADD COLUMN group_id BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE posts ADD COLUMN poll_closed BOOLEAN NOT NULL DEFAULT 0;`,
+		`ALTER TABLE posts ADD COLUMN deleted_at DATETIME NULL DEFAULT NULL;`,
+		`ALTER TABLE posts ADD COLUMN audience ENUM('public','friends','friends_of_friends','only_me','custom') NOT NULL DEFAULT 'public';`,
+		`ALTER TABLE posts ADD COLUMN audience_list_id BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE posts ADD COLUMN status ENUM('published','draft','scheduled') NOT NULL DEFAULT 'published';`,
+		`ALTER TABLE posts ADD COLUMN publish_at DATETIME NULL DEFAULT NULL;`,
+		`ALTER TABLE posts ADD COLUMN edited_at DATETIME NULL DEFAULT NULL;`,
+		`ALTER TABLE friendships ADD COLUMN user_low BIGINT AS (LEAST(user_id, friend_id)) STORED;`,
+		`ALTER TABLE friendships ADD COLUMN user_high BIGINT AS (GREATEST(user_id, friend_id)) STORED;`,
+		`ALTER TABLE comments ADD COLUMN parent_comment_id BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE comments ADD COLUMN depth TINYINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE comments ADD COLUMN edited_at DATETIME NULL DEFAULT NULL;`,
+		`ALTER TABLE user_preferences ADD COLUMN push_notifications BOOLEAN NOT NULL DEFAULT 1;`,
+		`ALTER TABLE user_preferences ADD COLUMN notify_post_reactions BOOLEAN NOT NULL DEFAULT 1;`,
+		`ALTER TABLE user_preferences ADD COLUMN notify_post_comments BOOLEAN NOT NULL DEFAULT 1;`,
+		`ALTER TABLE user_preferences ADD COLUMN notify_friend_requests BOOLEAN NOT NULL DEFAULT 1;`,
+		`ALTER TABLE user_preferences ADD COLUMN notify_wall_posts BOOLEAN NOT NULL DEFAULT 1;`,
+		`ALTER TABLE user_preferences ADD COLUMN notify_mentions BOOLEAN NOT NULL DEFAULT 1;`,
+		`ALTER TABLE posts ADD COLUMN is_share BOOLEAN NOT NULL DEFAULT 0;`,
+		`ALTER TABLE post_shares ADD COLUMN share_post_id BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE uploads ADD COLUMN width INT NULL DEFAULT NULL;`,
+		`ALTER TABLE uploads ADD COLUMN height INT NULL DEFAULT NULL;`,
+		`ALTER TABLE uploads ADD COLUMN blurhash VARCHAR(64) NULL DEFAULT NULL;`,
+		`ALTER TABLE uploads ADD COLUMN processing_status ENUM('pending','processing','done','failed') NOT NULL DEFAULT 'pending';`,
+		`ALTER TABLE uploads ADD COLUMN processed_at DATETIME NULL DEFAULT NULL;`,
+		`ALTER TABLE uploads ADD COLUMN duration_ms BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE uploads ADD COLUMN codec VARCHAR(64) NULL DEFAULT NULL;`,
+		`ALTER TABLE uploads ADD COLUMN multipart_upload_id VARCHAR(1024) NULL DEFAULT NULL;`,
+		`ALTER TABLE post_media MODIFY COLUMN media_type ENUM('image','video','audio') NOT NULL;`,
+		`ALTER TABLE post_media ADD COLUMN duration_ms BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE post_revision_media MODIFY COLUMN media_type ENUM('image','video','audio') NOT NULL;`,
+		`ALTER TABLE post_revision_media ADD COLUMN duration_ms BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE uploads MODIFY COLUMN purpose ENUM('post_media','user_image','voice_note') NOT NULL;`,
+		`ALTER TABLE uploads ADD COLUMN waveform VARBINARY(255) NULL DEFAULT NULL;`,
+		`ALTER TABLE message_attachments MODIFY COLUMN file_type ENUM('image','video','doc','audio') DEFAULT 'image';`,
+		`ALTER TABLE message_attachments ADD COLUMN duration_ms BIGINT NULL DEFAULT NULL;`,
+		`ALTER TABLE message_attachments ADD COLUMN waveform VARBINARY(255) NULL DEFAULT NULL;`,
+	}
+
+	// hashtagKey is util.NormalizeHashtag as near as SQL gets. MySQL cannot apply NFKC
+	// or full case folding, so keys are compared under utf8mb4_0900_as_ci, which
+	// ignores case and width but keeps accents apart.
+	hashtagKey := func(column string) string {
+		return `LEFT(REGEXP_REPLACE(LOWER(` + column + `), '[^\\p{L}\\p{M}\\p{Nd}_]', ''), 100) COLLATE utf8mb4_0900_as_ci`
+	}
+	// canonicalHashtags picks one hashtag per key, preferring a row already stored in
+	// normalized form over the oldest one.
+	canonicalHashtags := `WITH canonical AS (
+			SELECT ` + hashtagKey("tag") + ` AS tag_key,
+				COALESCE(
+					MIN(CASE WHEN CAST(tag AS BINARY) = CAST(` + hashtagKey("tag") + ` AS BINARY) THEN hashtag_id END),
+					MIN(hashtag_id)
+				) AS hashtag_id
+			FROM hashtags
+			GROUP BY tag_key
+			HAVING tag_key <> ''
+		)`
+
+	// Data fixes that have to run before the unique indexes above can be created
+	cleanupQueries := []string{
+		// Keep one friendship per unordered pair: blocked wins over accepted, accepted over pending,
+		// and the oldest row wins a tie.
+		`DELETE f1 FROM friendships f1
+		JOIN friendships f2
+			ON f1.user_low = f2.user_low AND f1.user_high = f2.user_high
+		WHERE FIELD(f1.status, 'pending', 'accepted', 'blocked') < FIELD(f2.status, 'pending', 'accepted', 'blocked')
+			OR (f1.status = f2.status AND f1.friendship_id > f2.friendship_id);`,
+		// Tags stored before normalization that now mean the same tag: link their posts
+		// to the canonical one, drop the rest (cascading links that would duplicate one),
+		// and store the canonical one in normalized form.
+		canonicalHashtags + `
+		UPDATE IGNORE post_hashtags ph
+		JOIN hashtags h ON h.hashtag_id = ph.hashtag_id
+		JOIN canonical c ON c.tag_key = ` + hashtagKey("h.tag") + `
+		SET ph.hashtag_id = c.hashtag_id
+		WHERE ph.hashtag_id <> c.hashtag_id;`,
+		canonicalHashtags + `
+		DELETE h FROM hashtags h
+		JOIN canonical c ON c.tag_key = ` + hashtagKey("h.tag") + `
+		WHERE h.hashtag_id <> c.hashtag_id;`,
+		`UPDATE IGNORE hashtags SET tag = ` + hashtagKey("tag") + `
+		WHERE ` + hashtagKey("tag") + ` <> ''
+			AND CAST(tag AS BINARY) <> CAST(` + hashtagKey("tag") + ` AS BINARY);`,
+		// A tag is linked to a post at most once.
+		`DELETE ph1 FROM post_hashtags ph1
+		JOIN post_hashtags ph2
+			ON ph1.post_id = ph2.post_id AND ph1.hashtag_id = ph2.hashtag_id
+		WHERE ph1.post_hashtag_id > ph2.post_hashtag_id;`,
+		// A user has one reaction per post or comment; the most recent one wins.
+		`DELETE pr1 FROM post_reactions pr1
+		JOIN post_reactions pr2
+			ON pr1.post_id = pr2.post_id AND pr1.user_id = pr2.user_id
+		WHERE pr1.post_reaction_id < pr2.post_reaction_id;`,
+		`DELETE cr1 FROM comment_reactions cr1
+		JOIN comment_reactions cr2
+			ON cr1.comment_id = cr2.comment_id AND cr1.user_id = cr2.user_id
+		WHERE cr1.comment_reaction_id < cr2.comment_reaction_id;`,
+		// Shares made before share posts were tracked: flag them, and link each share to
+		// the sharer's post of the original.
+		`UPDATE posts SET is_share = 1 WHERE original_post_id IS NOT NULL AND is_share = 0;`,
+		`UPDATE post_shares ps
+		JOIN posts p ON p.original_post_id = ps.post_id AND p.user_id = ps.user_id
+		SET ps.share_post_id = p.post_id
+		WHERE ps.share_post_id IS NULL;`,
 	}
 
 	if _, err := DB.Exec(apiKeysTable); err != nil {
@@ -441,12 +799,21 @@ func createTables() error {
 	if _, err := DB.Exec(friendshipsTable); err != nil {
 		return err
 	}
+	if _, err := DB.Exec(friendListsTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(friendListMembersTable); err != nil {
+		return err
+	}
 	if _, err := DB.Exec(groupsTable); err != nil {
 		return err
 	}
 	if _, err := DB.Exec(groupMembersTable); err != nil {
 		return err
 	}
+	if _, err := DB.Exec(groupJoinRequestsTable); err != nil {
+		return err
+	}
 	if _, err := DB.Exec(postsTable); err != nil {
 		return err
 	}
@@ -468,6 +835,9 @@ func createTables() error {
 	if _, err := DB.Exec(commentsTable); err != nil {
 		return err
 	}
+	if _, err := DB.Exec(mentionsTable); err != nil {
+		return err
+	}
 	if _, err := DB.Exec(commentReactionsTable); err != nil {
 		return err
 	}
@@ -477,6 +847,30 @@ func createTables() error {
 	if _, err := DB.Exec(postMediaTable); err != nil {
 		return err
 	}
+	if _, err := DB.Exec(postRevisionsTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(postRevisionMediaTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(savedCollectionsTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(savedPostsTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(pinnedPostsTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(uploadsTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(imageVariantsTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(linkPreviewsTable); err != nil {
+		return err
+	}
 	if _, err := DB.Exec(postViewsTable); err != nil {
 		return err
 	}
@@ -501,6 +895,29 @@ func createTables() error {
 	if _, err := DB.Exec(analyticsEventsTable); err != nil {
 		return err
 	}
+	if _, err := DB.Exec(notificationsTable); err != nil {
+		return err
+	}
+	if _, err := DB.Exec(notificationDeadLettersTable); err != nil {
+		return err
+	}
+	for _, query := range alterQueries {
+		_, err := DB.Exec(query)
+		if err != nil {
+			if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1060 {
+				log.Printf("Column already exists, skipping: %s", query)
+				continue
+			}
+			log.Printf("Error executing query: %s, error: %v", query, err)
+			return err
+		}
+	}
+	for _, query := range cleanupQueries {
+		if _, err := DB.Exec(query); err != nil {
+			log.Printf("Error executing query: %s, error: %v", query, err)
+			return err
+		}
+	}
 	for _, query := range indexQueries {
 		_, err := DB.Exec(query)
 		if err != nil {
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/friendlists"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func CreateFriendListHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateFriendListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := createFriendList(userID, req)
+	if err != nil {
+		log.Println("Failed to create friend list due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to create friend list (%v).", err), statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "create_friend_list", "friend_list", &response.ListID, map[string]interface{}{
+		"members_added": response.MembersAdded,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// createFriendList inserts the list and its initial members in one transaction.
+func createFriendList(userID int64, req models.CreateFriendListRequest) (models.CreateFriendListResponse, error) {
+	name, err := normalizeListName(req.Name)
+	if err != nil {
+		return models.CreateFriendListResponse{Success: false, Message: err.Error()}, err
+	}
+	if err := checkListNameFree(userID, name, 0); err != nil {
+		return models.CreateFriendListResponse{Success: false, Message: err.Error()}, err
+	}
+	memberIDs, err := requireFriends(userID, req.MemberIDs)
+	if err != nil {
+		return models.CreateFriendListResponse{Success: false, Message: err.Error()}, err
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.CreateFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.Exec(`INSERT INTO friend_lists (user_id, name) VALUES (?, ?)`, userID, name)
+	if err != nil {
+		tx.Rollback()
+		return models.CreateFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to insert friend list: %v", err),
+		}, err
+	}
+	listID, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return models.CreateFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get list id: %v", err),
+		}, err
+	}
+
+	membersAdded, err := addListMembers(tx, listID, memberIDs)
+	if err != nil {
+		tx.Rollback()
+		return models.CreateFriendListResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.CreateFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.CreateFriendListResponse{
+		Success:      true,
+		Message:      "Successfully created friend list.",
+		ListID:       listID,
+		MembersAdded: membersAdded,
+	}, nil
+}
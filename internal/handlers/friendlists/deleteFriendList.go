@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/friendlists"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func DeleteFriendListHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	listIDString := r.URL.Query().Get("id")
+	if listIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	listID, err := strconv.ParseInt(listIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse listIDString (string) to listID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := deleteFriendList(userID, listID)
+	if err != nil {
+		log.Println("Failed to delete friend list due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to delete friend list (%v).", err), statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "delete_friend_list", "friend_list", &listID, map[string]interface{}{
+		"posts_updated": response.PostsUpdated,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// deleteFriendList removes one of the user's lists. Posts shared with the list fall
+// back to only_me rather than widening to a bigger audience than the author chose.
+func deleteFriendList(userID, listID int64) (models.DeleteFriendListResponse, error) {
+	if err := audience.CheckList(userID, listID); err != nil {
+		return models.DeleteFriendListResponse{Success: false, Message: err.Error()}, err
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.DeleteFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// The posts become visible to fewer people, so any of them pinned to the profile
+	// lose their pin.
+	_, err = tx.Exec(`
+		DELETE pp
+		FROM pinned_posts pp
+		JOIN posts p ON p.post_id = pp.post_id
+		WHERE p.user_id = ? AND p.audience_list_id = ?
+	`, userID, listID)
+	if err != nil {
+		tx.Rollback()
+		return models.DeleteFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to unpin posts shared with the list: %v", err),
+		}, err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE posts
+		SET audience = 'only_me', audience_list_id = NULL, updated_at = updated_at
+		WHERE user_id = ? AND audience_list_id = ?
+	`, userID, listID)
+	if err != nil {
+		tx.Rollback()
+		return models.DeleteFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update posts shared with the list: %v", err),
+		}, err
+	}
+	postsUpdated, _ := result.RowsAffected()
+
+	_, err = tx.Exec(`DELETE FROM friend_lists WHERE list_id = ?`, listID)
+	if err != nil {
+		tx.Rollback()
+		return models.DeleteFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to delete friend list: %v", err),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.DeleteFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.DeleteFriendListResponse{
+		Success:      true,
+		Message:      "Successfully deleted friend list.",
+		PostsUpdated: postsUpdated,
+	}, nil
+}
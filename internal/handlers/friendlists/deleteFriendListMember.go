@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/friendlists"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func DeleteFriendListMemberHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	listIDString := q.Get("id")
+	if listIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	listID, err := strconv.ParseInt(listIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse listIDString (string) to listID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	memberIDString := q.Get("memberID")
+	if memberIDString == "" {
+		http.Error(w, "Missing required param 'memberID'.", http.StatusBadRequest)
+		return
+	}
+	memberID, err := strconv.ParseInt(memberIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse memberIDString (string) to memberID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'memberID'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := deleteFriendListMember(userID, listID, memberID)
+	if err != nil {
+		log.Println("Failed to remove friend list member due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to remove friend list member (%v).", err), statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "remove_friend_list_member", "friend_list", &listID, map[string]interface{}{
+		"member_id": memberID,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// deleteFriendListMember takes a user off one of the owner's lists, which hides the
+// posts shared with that list from them straight away.
+func deleteFriendListMember(userID, listID, memberID int64) (models.DeleteFriendListMemberResponse, error) {
+	if err := audience.CheckList(userID, listID); err != nil {
+		return models.DeleteFriendListMemberResponse{Success: false, Message: err.Error()}, err
+	}
+
+	_, err := database.DB.Exec(`
+		DELETE FROM friend_list_members
+		WHERE list_id = ? AND member_id = ?
+	`, listID, memberID)
+	if err != nil {
+		return models.DeleteFriendListMemberResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to remove friend list member: %v", err),
+		}, err
+	}
+
+	return models.DeleteFriendListMemberResponse{
+		Success: true,
+		Message: "Successfully removed friend list member.",
+	}, nil
+}
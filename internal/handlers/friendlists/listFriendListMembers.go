@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/friendlists"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListFriendListMembersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	listIDString := q.Get("id")
+	if listIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	listID, err := strconv.ParseInt(listIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse listIDString (string) to listID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := audience.CheckList(userID, listID); err != nil {
+		log.Println("Failed to list friend list members due to the following error: ", err)
+		http.Error(w, "Failed to list friend list members.", statusForError(err))
+		return
+	}
+
+	response, err := listFriendListMembers(listID, limit, page)
+	if err != nil {
+		log.Println("Failed to list friend list members due to the following error: ", err)
+		http.Error(w, "Failed to list friend list members.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func listFriendListMembers(listID, limit, page int64) (models.ListFriendListMembersResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalMembers int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM friend_list_members
+		WHERE list_id = ?
+	`
+	err := database.DB.QueryRow(countQuery, listID).Scan(&totalMembers)
+	if err != nil {
+		return models.ListFriendListMembersResponse{}, fmt.Errorf("failed to get totalMembers: %w", err)
+	}
+
+	query := `
+		SELECT
+			flm.member_id,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_pic_url,
+			flm.added_at
+		FROM friend_list_members flm
+		LEFT JOIN users u ON u.user_id = flm.member_id
+		LEFT JOIN user_profiles up ON up.user_id = flm.member_id
+		LEFT JOIN user_images ui ON ui.user_id = flm.member_id AND ui.is_profile_pic = 1
+		WHERE flm.list_id = ?
+		ORDER BY flm.added_at ASC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(query, listID, limit, offset)
+	if err != nil {
+		return models.ListFriendListMembersResponse{}, fmt.Errorf("failed to select friend list members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.FriendListMember
+	for rows.Next() {
+		var m models.FriendListMember
+		var (
+			username      sql.NullString
+			firstName     sql.NullString
+			lastName      sql.NullString
+			preferredName sql.NullString
+			profilePicURL sql.NullString
+			addedAt       sql.NullTime
+		)
+		err := rows.Scan(
+			&m.UserID,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&addedAt,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		m.Username = util.SqlNullStringToPtr(username)
+		m.FirstName = util.SqlNullStringToPtr(firstName)
+		m.LastName = util.SqlNullStringToPtr(lastName)
+		m.PreferredName = util.SqlNullStringToPtr(preferredName)
+		m.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+		m.AddedAt = util.SqlNullTimeToPtr(addedAt)
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListFriendListMembersResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalMembers) / float64(limit)))
+
+	return models.ListFriendListMembersResponse{
+		Members:      members,
+		Limit:        limit,
+		Page:         page,
+		TotalMembers: totalMembers,
+		TotalPages:   totalPages,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/friendlists"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func ListFriendListsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	response, err := listFriendLists(userID)
+	if err != nil {
+		log.Println("Failed to list friend lists due to the following error: ", err)
+		http.Error(w, "Failed to list friend lists.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func listFriendLists(userID int64) (models.ListFriendListsResponse, error) {
+	query := `
+		SELECT
+			fl.list_id,
+			fl.name,
+			(SELECT COUNT(*) FROM friend_list_members flm WHERE flm.list_id = fl.list_id) AS member_count,
+			fl.created_at,
+			fl.updated_at
+		FROM friend_lists fl
+		WHERE fl.user_id = ?
+		ORDER BY fl.name ASC
+	`
+	rows, err := database.DB.Query(query, userID)
+	if err != nil {
+		return models.ListFriendListsResponse{}, fmt.Errorf("failed to select friend lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []models.FriendList{}
+	for rows.Next() {
+		var l models.FriendList
+		var createdAt, updatedAt sql.NullTime
+		if err := rows.Scan(&l.ListID, &l.Name, &l.MemberCount, &createdAt, &updatedAt); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		l.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		l.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+		lists = append(lists, l)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListFriendListsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return models.ListFriendListsResponse{Lists: lists}, nil
+}
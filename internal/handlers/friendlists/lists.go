@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxFriendListNameLength = 100
+
+var (
+	errInvalidListName = errors.New("list name must be between 1 and 100 characters")
+	errListNameTaken   = errors.New("user already has a list with this name")
+	errNotFriends      = errors.New("friend lists can only contain accepted friends")
+)
+
+// normalizeListName trims the name and checks it fits the friend_lists column.
+func normalizeListName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxFriendListNameLength {
+		return "", errInvalidListName
+	}
+	return name, nil
+}
+
+// checkListNameFree returns errListNameTaken when another of userID's lists, other
+// than exceptListID, already has the name.
+func checkListNameFree(userID int64, name string, exceptListID int64) error {
+	var exists int
+	err := database.DB.QueryRow(`
+		SELECT 1
+		FROM friend_lists
+		WHERE user_id = ? AND name = ? AND list_id <> ?
+		LIMIT 1
+	`, userID, name, exceptListID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check list name: %w", err)
+	}
+	return errListNameTaken
+}
+
+// requireFriends dedupes memberIDs and fails with errNotFriends unless every one of
+// them is an accepted friend of userID. A block replaces the friendship row, so
+// blocked users are never accepted friends.
+func requireFriends(userID int64, memberIDs []int64) ([]int64, error) {
+	seen := make(map[int64]bool, len(memberIDs))
+	var unique []int64
+	for _, memberID := range memberIDs {
+		if memberID <= 0 || memberID == userID || seen[memberID] {
+			continue
+		}
+		seen[memberID] = true
+		unique = append(unique, memberID)
+	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(unique)), ",")
+	query := `
+		SELECT COUNT(*)
+		FROM friendships
+		WHERE status = 'accepted'
+			AND ((user_id = ? AND friend_id IN (` + placeholders + `))
+				OR (friend_id = ? AND user_id IN (` + placeholders + `)))
+	`
+	args := []interface{}{userID}
+	for _, id := range unique {
+		args = append(args, id)
+	}
+	args = append(args, userID)
+	for _, id := range unique {
+		args = append(args, id)
+	}
+
+	var friends int
+	if err := database.DB.QueryRow(query, args...).Scan(&friends); err != nil {
+		return nil, fmt.Errorf("failed to check friendships: %w", err)
+	}
+	if friends < len(unique) {
+		return nil, errNotFriends
+	}
+
+	return unique, nil
+}
+
+// addListMembers adds memberIDs to the list, skipping users already on it, and
+// returns how many were added.
+func addListMembers(tx *sql.Tx, listID int64, memberIDs []int64) (int64, error) {
+	var added int64
+	for _, memberID := range memberIDs {
+		result, err := tx.Exec(`
+			INSERT IGNORE INTO friend_list_members (list_id, member_id)
+			VALUES (?, ?)
+		`, listID, memberID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to add list member: %w", err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		added += rowsAffected
+	}
+	return added, nil
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, audience.ErrListNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errInvalidListName), errors.Is(err, errNotFriends):
+		return http.StatusBadRequest
+	case errors.Is(err, errListNameTaken):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/friendlists"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func PutFriendListMembersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PutFriendListMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.ListID <= 0 {
+		http.Error(w, "Missing required field 'listID'.", http.StatusBadRequest)
+		return
+	}
+	if len(req.MemberIDs) == 0 {
+		http.Error(w, "Missing required field 'memberIDs'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := putFriendListMembers(userID, req)
+	if err != nil {
+		log.Println("Failed to add friend list members due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to add friend list members (%v).", err), statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "add_friend_list_members", "friend_list", &req.ListID, map[string]interface{}{
+		"members_added": response.MembersAdded,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func putFriendListMembers(userID int64, req models.PutFriendListMembersRequest) (models.PutFriendListMembersResponse, error) {
+	if err := audience.CheckList(userID, req.ListID); err != nil {
+		return models.PutFriendListMembersResponse{Success: false, Message: err.Error()}, err
+	}
+	memberIDs, err := requireFriends(userID, req.MemberIDs)
+	if err != nil {
+		return models.PutFriendListMembersResponse{Success: false, Message: err.Error()}, err
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.PutFriendListMembersResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	membersAdded, err := addListMembers(tx, req.ListID, memberIDs)
+	if err != nil {
+		tx.Rollback()
+		return models.PutFriendListMembersResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PutFriendListMembersResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.PutFriendListMembersResponse{
+		Success:      true,
+		Message:      "Successfully added friend list members.",
+		MembersAdded: membersAdded,
+	}, nil
+}
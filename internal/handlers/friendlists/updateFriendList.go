@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/friendlists"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func UpdateFriendListHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.UpdateFriendListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.ListID <= 0 {
+		http.Error(w, "Missing required field 'listID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := updateFriendList(userID, req)
+	if err != nil {
+		log.Println("Failed to update friend list due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to update friend list (%v).", err), statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "update_friend_list", "friend_list", &req.ListID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// updateFriendList renames one of the user's lists.
+func updateFriendList(userID int64, req models.UpdateFriendListRequest) (models.UpdateFriendListResponse, error) {
+	if err := audience.CheckList(userID, req.ListID); err != nil {
+		return models.UpdateFriendListResponse{Success: false, Message: err.Error()}, err
+	}
+	name, err := normalizeListName(req.Name)
+	if err != nil {
+		return models.UpdateFriendListResponse{Success: false, Message: err.Error()}, err
+	}
+	if err := checkListNameFree(userID, name, req.ListID); err != nil {
+		return models.UpdateFriendListResponse{Success: false, Message: err.Error()}, err
+	}
+
+	_, err = database.DB.Exec(`UPDATE friend_lists SET name = ? WHERE list_id = ?`, name, req.ListID)
+	if err != nil {
+		return models.UpdateFriendListResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update friend list: %v", err),
+		}, err
+	}
+
+	return models.UpdateFriendListResponse{
+		Success: true,
+		Message: "Successfully updated friend list.",
+	}, nil
+}
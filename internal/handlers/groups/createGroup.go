@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+func CreateGroupHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		http.Error(w, "Missing required field 'name'.", http.StatusBadRequest)
+		return
+	}
+	if req.Privacy == "" {
+		req.Privacy = groupPrivacyPublic
+	}
+	if !isValidGroupPrivacy(req.Privacy) {
+		http.Error(w, "Invalid field 'privacy'; must be one of public, private or closed.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := createGroup(userID, req)
+	if err != nil {
+		log.Println("Failed to create group due to the following error: ", err)
+		http.Error(w, "Failed to create group.", http.StatusInternalServerError)
+		return
+	}
+
+	go util.TrackEvent(userID, "create_group", "group", &response.GroupID, map[string]interface{}{
+		"privacy": req.Privacy,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// createGroup inserts the group and makes its creator the first admin in the same transaction.
+func createGroup(userID int64, req models.CreateGroupRequest) (models.CreateGroupResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.CreateGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.Exec(`
+		INSERT INTO groups_table (name, description, privacy, creator_id)
+		VALUES (?, ?, ?, ?)
+	`, req.Name, req.Description, req.Privacy, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.CreateGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to insert group: %v", err),
+		}, err
+	}
+	groupID, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return models.CreateGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get group id: %v", err),
+		}, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO group_members (group_id, user_id, role)
+		VALUES (?, ?, 'admin')
+	`, groupID, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.CreateGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to insert group admin: %v", err),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.CreateGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.CreateGroupResponse{
+		Success: true,
+		Message: "Successfully created group.",
+		GroupID: groupID,
+	}, nil
+}
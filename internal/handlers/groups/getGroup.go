@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func GetGroupHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	groupIDString := r.URL.Query().Get("id")
+	if groupIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	groupID, err := strconv.ParseInt(groupIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse groupIDString (string) to groupID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := getGroup(groupID, userID)
+	if err != nil {
+		log.Println("Failed to get group due to the following error: ", err)
+		http.Error(w, "Failed to get group.", statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "view_group", "group", &groupID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// getGroup returns the group's details to anyone so that private and closed
+// groups can still be discovered and requested; their content stays members-only.
+func getGroup(groupID, userID int64) (models.GetGroupResponse, error) {
+	var (
+		g           models.Group
+		description sql.NullString
+		createdAt   sql.NullTime
+		updatedAt   sql.NullTime
+	)
+	query := `
+		SELECT
+			g.group_id,
+			g.name,
+			g.description,
+			g.privacy,
+			g.creator_id,
+			g.created_at,
+			g.updated_at,
+			(SELECT COUNT(*) FROM group_members gm WHERE gm.group_id = g.group_id) AS total_members
+		FROM groups_table g
+		WHERE g.group_id = ?
+	`
+	err := database.DB.QueryRow(query, groupID).Scan(
+		&g.GroupID,
+		&g.Name,
+		&description,
+		&g.Privacy,
+		&g.CreatorID,
+		&createdAt,
+		&updatedAt,
+		&g.TotalMembers,
+	)
+	if err == sql.ErrNoRows {
+		return models.GetGroupResponse{}, errGroupNotFound
+	}
+	if err != nil {
+		return models.GetGroupResponse{}, fmt.Errorf("failed to get group: %w", err)
+	}
+	g.Description = util.SqlNullStringToPtr(description)
+	g.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+	g.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+
+	response := models.GetGroupResponse{Group: g}
+
+	role, err := getGroupRole(groupID, userID)
+	if err == nil {
+		response.ViewerRole = &role
+		return response, nil
+	}
+	if !errors.Is(err, errNotGroupMember) {
+		return models.GetGroupResponse{}, err
+	}
+
+	var pending int64
+	pendingQuery := `
+		SELECT COUNT(*)
+		FROM group_join_requests
+		WHERE group_id = ? AND user_id = ? AND status = 'pending'
+	`
+	if err := database.DB.QueryRow(pendingQuery, groupID, userID).Scan(&pending); err != nil {
+		return models.GetGroupResponse{}, fmt.Errorf("failed to check pending join request: %w", err)
+	}
+	response.PendingRequest = pending > 0
+
+	return response, nil
+}
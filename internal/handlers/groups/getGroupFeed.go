@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func GetGroupFeedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	groupIDString := q.Get("id")
+	if groupIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	groupID, err := strconv.ParseInt(groupIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse groupIDString (string) to groupID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := requireGroupReadAccess(groupID, userID); err != nil {
+		log.Println("Failed to get group feed due to the following error: ", err)
+		http.Error(w, "Failed to get group feed.", statusForError(err))
+		return
+	}
+
+	response, err := getGroupFeed(groupID, userID, limit, page)
+	if err != nil {
+		log.Println("Failed to get group feed due to the following error: ", err)
+		http.Error(w, "Failed to get group feed.", http.StatusInternalServerError)
+		return
+	}
+
+	go util.TrackEvent(userID, "view_group_feed", "group", &groupID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func getGroupFeed(groupID, userID, limit, page int64) (models.GetGroupFeedResponse, error) {
+	offset := (page - 1) * limit
+	visible, visibleArgs := audience.Visible("p", userID)
+
+	var totalPosts int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM posts p
+		WHERE p.group_id = ? AND p.deleted_at IS NULL AND p.status = 'published'
+			AND ` + visible
+	err := database.DB.QueryRow(countQuery, append([]interface{}{groupID}, visibleArgs...)...).Scan(&totalPosts)
+	if err != nil {
+		return models.GetGroupFeedResponse{}, fmt.Errorf("failed to get totalPosts: %w", err)
+	}
+
+	query := `
+		SELECT
+			p.post_id,
+			p.user_id,
+			p.group_id,
+			p.original_post_id,
+			p.impressions,
+			(SELECT COUNT(*) FROM post_views pv WHERE pv.post_id = p.post_id) AS views,
+			p.content_text,
+			p.created_at,
+			p.updated_at,
+			p.location_name,
+			p.location_lat,
+			p.location_lng,
+			p.is_poll,
+			p.poll_question,
+			p.poll_duration_type,
+			p.poll_duration_length,
+			p.edited_at,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_pic_url,
+			pr_user.reaction_type AS user_reaction,
+			(SELECT COUNT(*) FROM post_reactions pr WHERE pr.post_id = p.post_id) AS total_reactions,
+			(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.post_id) AS total_comments,
+			(SELECT COUNT(*) FROM post_shares ps WHERE ps.post_id = p.post_id) AS total_post_shares
+		FROM posts p
+		LEFT JOIN users u ON p.user_id = u.user_id
+		LEFT JOIN user_profiles up ON p.user_id = up.user_id
+		LEFT JOIN user_images ui ON p.user_id = ui.user_id AND ui.is_profile_pic = 1
+		LEFT JOIN post_reactions pr_user ON p.post_id = pr_user.post_id AND pr_user.user_id = ?
+		WHERE p.group_id = ?
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND ` + visible + `
+		ORDER BY p.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+	args := []interface{}{userID, groupID}
+	args = append(args, visibleArgs...)
+	args = append(args, limit, offset)
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return models.GetGroupFeedResponse{}, fmt.Errorf("failed to execute query for group posts: %w", err)
+	}
+	defer rows.Close()
+
+	var groupPosts []models.GroupPost
+	for rows.Next() {
+		var p models.GroupPost
+		var (
+			originalPostID     sql.NullInt64
+			contentText        sql.NullString
+			createdAt          sql.NullTime
+			updatedAt          sql.NullTime
+			locationName       sql.NullString
+			locationLat        sql.NullFloat64
+			locationLong       sql.NullFloat64
+			isPoll             sql.NullBool
+			pollQuestion       sql.NullString
+			pollDurationType   sql.NullString
+			pollDurationLength sql.NullInt64
+			editedAt           sql.NullTime
+			username           sql.NullString
+			firstName          sql.NullString
+			lastName           sql.NullString
+			preferredName      sql.NullString
+			profilePicURL      sql.NullString
+			userReaction       sql.NullString
+		)
+
+		err := rows.Scan(
+			&p.PostID,
+			&p.UserID,
+			&p.GroupID,
+			&originalPostID,
+			&p.Impressions,
+			&p.Views,
+			&contentText,
+			&createdAt,
+			&updatedAt,
+			&locationName,
+			&locationLat,
+			&locationLong,
+			&isPoll,
+			&pollQuestion,
+			&pollDurationType,
+			&pollDurationLength,
+			&editedAt,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&userReaction,
+			&p.TotalReactions,
+			&p.TotalComments,
+			&p.TotalPostShares,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		p.OriginalPostID = util.SqlNullInt64ToPtr(originalPostID)
+		p.ContentText = util.SqlNullStringToPtr(contentText)
+		p.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		p.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+		p.LocationName = util.SqlNullStringToPtr(locationName)
+		p.LocationLat = util.SqlNullFloat64ToPtr(locationLat)
+		p.LocationLong = util.SqlNullFloat64ToPtr(locationLong)
+		p.IsPoll = util.SqlNullBoolToPtr(isPoll)
+		p.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
+		p.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
+		p.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
+		p.Edited = editedAt.Valid
+		p.EditedAt = util.SqlNullTimeToPtr(editedAt)
+		p.Username = util.SqlNullStringToPtr(username)
+		p.FirstName = util.SqlNullStringToPtr(firstName)
+		p.LastName = util.SqlNullStringToPtr(lastName)
+		p.PreferredName = util.SqlNullStringToPtr(preferredName)
+		p.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+		p.UserReaction = util.SqlNullStringToPtr(userReaction)
+		groupPosts = append(groupPosts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return models.GetGroupFeedResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	postIDs := make([]int64, len(groupPosts))
+	for i, p := range groupPosts {
+		postIDs[i] = p.PostID
+	}
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return models.GetGroupFeedResponse{}, err
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.GetGroupFeedResponse{}, err
+	}
+	texts := make(map[int64]string, len(groupPosts))
+	for _, p := range groupPosts {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.GetGroupFeedResponse{}, err
+	}
+	profilePics := make([]string, 0, len(groupPosts))
+	for _, p := range groupPosts {
+		if p.ProfilePicURL != nil {
+			profilePics = append(profilePics, *p.ProfilePicURL)
+		}
+	}
+	profilePicImages, err := imaging.ForURLs(profilePics)
+	if err != nil {
+		return models.GetGroupFeedResponse{}, err
+	}
+	for i := range groupPosts {
+		groupPosts[i].ReactionCounts = reactionCounts[groupPosts[i].PostID]
+		groupPosts[i].OriginalPost = originals[groupPosts[i].PostID]
+		groupPosts[i].LinkPreviews = linkPreviews[groupPosts[i].PostID]
+		groupPosts[i].ProfilePicVariants = imaging.Variants(profilePicImages, groupPosts[i].ProfilePicURL)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalPosts) / float64(limit)))
+
+	return models.GetGroupFeedResponse{
+		GroupPosts: groupPosts,
+		Limit:      limit,
+		Page:       page,
+		TotalPosts: totalPosts,
+		TotalPages: totalPages,
+	}, nil
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func JoinGroupHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.JoinGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.GroupID <= 0 {
+		http.Error(w, "Missing required field 'groupID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := joinGroup(userID, req.GroupID)
+	if err != nil {
+		log.Println("Failed to join group due to the following error: ", err)
+		http.Error(w, "Failed to join group.", statusForError(err))
+		return
+	}
+
+	eventType := "join_group"
+	if response.Status == "requested" {
+		eventType = "request_join_group"
+	}
+	go util.TrackEvent(userID, eventType, "group", &req.GroupID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// joinGroup adds the user straight away to public groups. Private and closed
+// groups get a pending join request that a moderator or admin has to review.
+func joinGroup(userID, groupID int64) (models.JoinGroupResponse, error) {
+	privacy, err := getGroupPrivacy(groupID)
+	if err != nil {
+		return models.JoinGroupResponse{Success: false, Message: err.Error()}, err
+	}
+
+	_, err = getGroupRole(groupID, userID)
+	if err == nil {
+		return models.JoinGroupResponse{Success: false, Message: errAlreadyGroupMember.Error()}, errAlreadyGroupMember
+	}
+	if !errors.Is(err, errNotGroupMember) {
+		return models.JoinGroupResponse{Success: false, Message: err.Error()}, err
+	}
+
+	if privacy == groupPrivacyPublic {
+		_, err := database.DB.Exec(`
+			INSERT IGNORE INTO group_members (group_id, user_id, role)
+			VALUES (?, ?, 'member')
+		`, groupID, userID)
+		if err != nil {
+			return models.JoinGroupResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to join group due to the following error: %v", err),
+			}, err
+		}
+
+		return models.JoinGroupResponse{
+			Success: true,
+			Message: "Successfully joined group.",
+			Status:  "joined",
+		}, nil
+	}
+
+	var pending int64
+	pendingQuery := `
+		SELECT COUNT(*)
+		FROM group_join_requests
+		WHERE group_id = ? AND user_id = ? AND status = 'pending'
+	`
+	if err := database.DB.QueryRow(pendingQuery, groupID, userID).Scan(&pending); err != nil {
+		return models.JoinGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to check pending join request due to the following error: %v", err),
+		}, err
+	}
+	if pending > 0 {
+		return models.JoinGroupResponse{
+			Success: true,
+			Message: "Join request is already pending.",
+			Status:  "requested",
+		}, nil
+	}
+
+	_, err = database.DB.Exec(`
+		INSERT INTO group_join_requests (group_id, user_id)
+		VALUES (?, ?)
+	`, groupID, userID)
+	if err != nil {
+		return models.JoinGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to request to join group due to the following error: %v", err),
+		}, err
+	}
+
+	return models.JoinGroupResponse{
+		Success: true,
+		Message: "Successfully requested to join group.",
+		Status:  "requested",
+	}, nil
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func LeaveGroupHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.LeaveGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.GroupID <= 0 {
+		http.Error(w, "Missing required field 'groupID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := leaveGroup(userID, req.GroupID)
+	if err != nil {
+		log.Println("Failed to leave group due to the following error: ", err)
+		http.Error(w, "Failed to leave group.", statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "leave_group", "group", &req.GroupID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// leaveGroup refuses to let the last admin walk away while other members remain,
+// otherwise nobody would be able to manage the group afterwards.
+func leaveGroup(userID, groupID int64) (models.LeaveGroupResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.LeaveGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var role string
+	err = tx.QueryRow(`
+		SELECT role
+		FROM group_members
+		WHERE group_id = ? AND user_id = ?
+		FOR UPDATE
+	`, groupID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return models.LeaveGroupResponse{Success: false, Message: errNotGroupMember.Error()}, errNotGroupMember
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.LeaveGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get group role: %v", err),
+		}, err
+	}
+
+	if role == groupRoleAdmin {
+		admins, err := countGroupAdmins(tx, groupID)
+		if err != nil {
+			tx.Rollback()
+			return models.LeaveGroupResponse{Success: false, Message: err.Error()}, err
+		}
+		var totalMembers int64
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM group_members WHERE group_id = ?`, groupID).Scan(&totalMembers); err != nil {
+			tx.Rollback()
+			return models.LeaveGroupResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to count group members: %v", err),
+			}, err
+		}
+		if admins <= 1 && totalMembers > 1 {
+			tx.Rollback()
+			return models.LeaveGroupResponse{Success: false, Message: errLastGroupAdmin.Error()}, errLastGroupAdmin
+		}
+	}
+
+	_, err = tx.Exec(`DELETE FROM group_members WHERE group_id = ? AND user_id = ?`, groupID, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.LeaveGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to leave group: %v", err),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.LeaveGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.LeaveGroupResponse{
+		Success: true,
+		Message: "Successfully left group.",
+	}, nil
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListGroupMembersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	groupIDString := q.Get("id")
+	if groupIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	groupID, err := strconv.ParseInt(groupIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse groupIDString (string) to groupID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := requireGroupReadAccess(groupID, userID); err != nil {
+		log.Println("Failed to list group members due to the following error: ", err)
+		http.Error(w, "Failed to list group members.", statusForError(err))
+		return
+	}
+
+	response, err := listGroupMembers(groupID, limit, page)
+	if err != nil {
+		log.Println("Failed to list group members due to the following error: ", err)
+		http.Error(w, "Failed to list group members.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func listGroupMembers(groupID, limit, page int64) (models.ListGroupMembersResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalMembers int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM group_members
+		WHERE group_id = ?
+	`
+	err := database.DB.QueryRow(countQuery, groupID).Scan(&totalMembers)
+	if err != nil {
+		return models.ListGroupMembersResponse{}, fmt.Errorf("failed to get totalMembers: %w", err)
+	}
+
+	query := `
+		SELECT
+			gm.user_id,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_pic_url,
+			gm.role,
+			gm.joined_at
+		FROM group_members gm
+		LEFT JOIN users u ON u.user_id = gm.user_id
+		LEFT JOIN user_profiles up ON up.user_id = gm.user_id
+		LEFT JOIN user_images ui ON ui.user_id = gm.user_id AND ui.is_profile_pic = 1
+		WHERE gm.group_id = ?
+		ORDER BY FIELD(gm.role, 'admin', 'moderator', 'member'), gm.joined_at ASC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(query, groupID, limit, offset)
+	if err != nil {
+		return models.ListGroupMembersResponse{}, fmt.Errorf("failed to select group members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.GroupMember
+	for rows.Next() {
+		var m models.GroupMember
+		var (
+			username      sql.NullString
+			firstName     sql.NullString
+			lastName      sql.NullString
+			preferredName sql.NullString
+			profilePicURL sql.NullString
+			joinedAt      sql.NullTime
+		)
+		err := rows.Scan(
+			&m.UserID,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&m.Role,
+			&joinedAt,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		m.Username = util.SqlNullStringToPtr(username)
+		m.FirstName = util.SqlNullStringToPtr(firstName)
+		m.LastName = util.SqlNullStringToPtr(lastName)
+		m.PreferredName = util.SqlNullStringToPtr(preferredName)
+		m.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+		m.JoinedAt = util.SqlNullTimeToPtr(joinedAt)
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListGroupMembersResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalMembers) / float64(limit)))
+
+	return models.ListGroupMembersResponse{
+		Members:      members,
+		Limit:        limit,
+		Page:         page,
+		TotalMembers: totalMembers,
+		TotalPages:   totalPages,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := listGroups(userID, limit, page)
+	if err != nil {
+		log.Println("Failed to list groups due to the following error: ", err)
+		http.Error(w, "Failed to list groups.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func listGroups(userID, limit, page int64) (models.ListGroupsResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalGroups int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM group_members
+		WHERE user_id = ?
+	`
+	err := database.DB.QueryRow(countQuery, userID).Scan(&totalGroups)
+	if err != nil {
+		return models.ListGroupsResponse{}, fmt.Errorf("failed to get totalGroups: %w", err)
+	}
+
+	query := `
+		SELECT
+			g.group_id,
+			g.name,
+			g.description,
+			g.privacy,
+			g.creator_id,
+			g.created_at,
+			g.updated_at,
+			(SELECT COUNT(*) FROM group_members c WHERE c.group_id = g.group_id) AS total_members,
+			gm.role
+		FROM groups_table g
+		JOIN group_members gm
+			ON gm.group_id = g.group_id AND gm.user_id = ?
+		ORDER BY gm.joined_at DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(query, userID, limit, offset)
+	if err != nil {
+		return models.ListGroupsResponse{}, fmt.Errorf("failed to select groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []models.ListGroup
+	for rows.Next() {
+		var g models.ListGroup
+		var (
+			description sql.NullString
+			createdAt   sql.NullTime
+			updatedAt   sql.NullTime
+		)
+		err := rows.Scan(
+			&g.GroupID,
+			&g.Name,
+			&description,
+			&g.Privacy,
+			&g.CreatorID,
+			&createdAt,
+			&updatedAt,
+			&g.TotalMembers,
+			&g.Role,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		g.Description = util.SqlNullStringToPtr(description)
+		g.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		g.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+		groups = append(groups, g)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListGroupsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalGroups) / float64(limit)))
+
+	return models.ListGroupsResponse{
+		Groups:      groups,
+		Limit:       limit,
+		Page:        page,
+		TotalGroups: totalGroups,
+		TotalPages:  totalPages,
+	}, nil
+}
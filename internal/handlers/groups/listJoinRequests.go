@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListJoinRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	groupIDString := q.Get("id")
+	if groupIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	groupID, err := strconv.ParseInt(groupIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse groupIDString (string) to groupID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := requireJoinRequestReviewer(groupID, userID); err != nil {
+		log.Println("Failed to list join requests due to the following error: ", err)
+		http.Error(w, "Failed to list join requests.", statusForError(err))
+		return
+	}
+
+	response, err := listJoinRequests(groupID, limit, page)
+	if err != nil {
+		log.Println("Failed to list join requests due to the following error: ", err)
+		http.Error(w, "Failed to list join requests.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func listJoinRequests(groupID, limit, page int64) (models.ListJoinRequestsResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalRequests int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM group_join_requests
+		WHERE group_id = ? AND status = 'pending'
+	`
+	err := database.DB.QueryRow(countQuery, groupID).Scan(&totalRequests)
+	if err != nil {
+		return models.ListJoinRequestsResponse{}, fmt.Errorf("failed to get totalRequests: %w", err)
+	}
+
+	query := `
+		SELECT
+			gjr.group_join_request_id,
+			gjr.group_id,
+			gjr.user_id,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_pic_url,
+			gjr.created_at
+		FROM group_join_requests gjr
+		LEFT JOIN users u ON u.user_id = gjr.user_id
+		LEFT JOIN user_profiles up ON up.user_id = gjr.user_id
+		LEFT JOIN user_images ui ON ui.user_id = gjr.user_id AND ui.is_profile_pic = 1
+		WHERE gjr.group_id = ? AND gjr.status = 'pending'
+		ORDER BY gjr.created_at ASC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(query, groupID, limit, offset)
+	if err != nil {
+		return models.ListJoinRequestsResponse{}, fmt.Errorf("failed to select join requests: %w", err)
+	}
+	defer rows.Close()
+
+	var joinRequests []models.GroupJoinRequest
+	for rows.Next() {
+		var jr models.GroupJoinRequest
+		var (
+			username      sql.NullString
+			firstName     sql.NullString
+			lastName      sql.NullString
+			preferredName sql.NullString
+			profilePicURL sql.NullString
+			createdAt     sql.NullTime
+		)
+		err := rows.Scan(
+			&jr.GroupJoinRequestID,
+			&jr.GroupID,
+			&jr.UserID,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&createdAt,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		jr.Username = util.SqlNullStringToPtr(username)
+		jr.FirstName = util.SqlNullStringToPtr(firstName)
+		jr.LastName = util.SqlNullStringToPtr(lastName)
+		jr.PreferredName = util.SqlNullStringToPtr(preferredName)
+		jr.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+		jr.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		joinRequests = append(joinRequests, jr)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListJoinRequestsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalRequests) / float64(limit)))
+
+	return models.ListJoinRequestsResponse{
+		JoinRequests:  joinRequests,
+		Limit:         limit,
+		Page:          page,
+		TotalRequests: totalRequests,
+		TotalPages:    totalPages,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func RespondJoinRequestHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.RespondJoinRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.GroupJoinRequestID <= 0 {
+		http.Error(w, "Missing required field 'groupJoinRequestID'.", http.StatusBadRequest)
+		return
+	}
+
+	groupID, requesterID, response, err := respondJoinRequest(userID, req)
+	if err != nil {
+		log.Println("Failed to respond to join request due to the following error: ", err)
+		http.Error(w, "Failed to respond to join request.", statusForError(err))
+		return
+	}
+
+	eventType := "decline_group_join_request"
+	if req.Approve {
+		eventType = "approve_group_join_request"
+	}
+	go util.TrackEvent(userID, eventType, "group", &groupID, map[string]interface{}{
+		"requester_id": requesterID,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// respondJoinRequest marks a pending request as reviewed and, when approved,
+// adds the requester as a member in the same transaction.
+func respondJoinRequest(userID int64, req models.RespondJoinRequestRequest) (int64, int64, models.RespondJoinRequestResponse, error) {
+	var groupID, requesterID int64
+	query := `
+		SELECT group_id, user_id
+		FROM group_join_requests
+		WHERE group_join_request_id = ? AND status = 'pending'
+	`
+	err := database.DB.QueryRow(query, req.GroupJoinRequestID).Scan(&groupID, &requesterID)
+	if err == sql.ErrNoRows {
+		return 0, 0, models.RespondJoinRequestResponse{Success: false, Message: errJoinRequestNotFound.Error()}, errJoinRequestNotFound
+	}
+	if err != nil {
+		return 0, 0, models.RespondJoinRequestResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get join request: %v", err),
+		}, err
+	}
+
+	if err := requireJoinRequestReviewer(groupID, userID); err != nil {
+		return 0, 0, models.RespondJoinRequestResponse{Success: false, Message: err.Error()}, err
+	}
+
+	status := "declined"
+	if req.Approve {
+		status = "approved"
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return 0, 0, models.RespondJoinRequestResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.Exec(`
+		UPDATE group_join_requests
+		SET status = ?, reviewed_by = ?, reviewed_at = NOW()
+		WHERE group_join_request_id = ? AND status = 'pending'
+	`, status, userID, req.GroupJoinRequestID)
+	if err != nil {
+		tx.Rollback()
+		return 0, 0, models.RespondJoinRequestResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update join request: %v", err),
+		}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return 0, 0, models.RespondJoinRequestResponse{Success: false, Message: errJoinRequestNotFound.Error()}, errJoinRequestNotFound
+	}
+
+	if req.Approve {
+		_, err = tx.Exec(`
+			INSERT IGNORE INTO group_members (group_id, user_id, role)
+			VALUES (?, ?, 'member')
+		`, groupID, requesterID)
+		if err != nil {
+			tx.Rollback()
+			return 0, 0, models.RespondJoinRequestResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to insert group member: %v", err),
+			}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, models.RespondJoinRequestResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return groupID, requesterID, models.RespondJoinRequestResponse{
+		Success: true,
+		Message: fmt.Sprintf("Successfully %s join request.", status),
+	}, nil
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	groupRoleMember    = "member"
+	groupRoleModerator = "moderator"
+	groupRoleAdmin     = "admin"
+
+	groupPrivacyPublic  = "public"
+	groupPrivacyPrivate = "private"
+	groupPrivacyClosed  = "closed"
+)
+
+var (
+	errGroupNotFound         = errors.New("group not found")
+	errNotGroupMember        = errors.New("user is not a member of this group")
+	errInsufficientGroupRole = errors.New("user does not have the required role in this group")
+	errLastGroupAdmin        = errors.New("a group must keep at least one admin")
+	errJoinRequestNotFound   = errors.New("pending join request not found")
+	errAlreadyGroupMember    = errors.New("user is already a member of this group")
+)
+
+// groupRoleRank orders roles so that requireGroupRole can accept "at least" a given role.
+var groupRoleRank = map[string]int{
+	groupRoleMember:    1,
+	groupRoleModerator: 2,
+	groupRoleAdmin:     3,
+}
+
+func isValidGroupPrivacy(privacy string) bool {
+	return privacy == groupPrivacyPublic || privacy == groupPrivacyPrivate || privacy == groupPrivacyClosed
+}
+
+func isValidGroupRole(role string) bool {
+	_, ok := groupRoleRank[role]
+	return ok
+}
+
+func getGroupPrivacy(groupID int64) (string, error) {
+	var privacy string
+	query := `SELECT privacy FROM groups_table WHERE group_id = ?`
+	err := database.DB.QueryRow(query, groupID).Scan(&privacy)
+	if err == sql.ErrNoRows {
+		return "", errGroupNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return privacy, nil
+}
+
+// getGroupRole always reads the role from group_members so a client can never assert its own role.
+func getGroupRole(groupID, userID int64) (string, error) {
+	var role string
+	query := `
+		SELECT role
+		FROM group_members
+		WHERE group_id = ? AND user_id = ?
+		LIMIT 1
+	`
+	err := database.DB.QueryRow(query, groupID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", errNotGroupMember
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get group role: %w", err)
+	}
+
+	return role, nil
+}
+
+func requireGroupRole(groupID, userID int64, minRole string) (string, error) {
+	if _, err := getGroupPrivacy(groupID); err != nil {
+		return "", err
+	}
+	role, err := getGroupRole(groupID, userID)
+	if err != nil {
+		return "", err
+	}
+	if groupRoleRank[role] < groupRoleRank[minRole] {
+		return role, errInsufficientGroupRole
+	}
+
+	return role, nil
+}
+
+// requireGroupReadAccess lets anyone read a public group, and only members read private or closed ones.
+func requireGroupReadAccess(groupID, userID int64) error {
+	privacy, err := getGroupPrivacy(groupID)
+	if err != nil {
+		return err
+	}
+	if privacy == groupPrivacyPublic {
+		return nil
+	}
+	_, err = getGroupRole(groupID, userID)
+	return err
+}
+
+// canReviewJoinRequests reports whether a role may approve or decline requests. Moderators
+// can review requests for private groups, while closed groups only admit members an admin approves.
+func canReviewJoinRequests(privacy, role string) bool {
+	if privacy == groupPrivacyClosed {
+		return role == groupRoleAdmin
+	}
+	return groupRoleRank[role] >= groupRoleRank[groupRoleModerator]
+}
+
+func requireJoinRequestReviewer(groupID, userID int64) error {
+	privacy, err := getGroupPrivacy(groupID)
+	if err != nil {
+		return err
+	}
+	role, err := getGroupRole(groupID, userID)
+	if err != nil {
+		return err
+	}
+	if !canReviewJoinRequests(privacy, role) {
+		return errInsufficientGroupRole
+	}
+
+	return nil
+}
+
+func countGroupAdmins(tx *sql.Tx, groupID int64) (int64, error) {
+	var total int64
+	query := `
+		SELECT COUNT(*)
+		FROM group_members
+		WHERE group_id = ? AND role = 'admin'
+		FOR UPDATE
+	`
+	if err := tx.QueryRow(query, groupID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count group admins: %w", err)
+	}
+
+	return total, nil
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, errNotGroupMember), errors.Is(err, errInsufficientGroupRole):
+		return http.StatusForbidden
+	case errors.Is(err, errGroupNotFound), errors.Is(err, errJoinRequestNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errLastGroupAdmin), errors.Is(err, errAlreadyGroupMember):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
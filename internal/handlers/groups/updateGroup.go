@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+func UpdateGroupHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.UpdateGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.GroupID <= 0 {
+		http.Error(w, "Missing required field 'groupID'.", http.StatusBadRequest)
+		return
+	}
+	if req.Name != nil {
+		trimmed := strings.TrimSpace(*req.Name)
+		if trimmed == "" {
+			http.Error(w, "Field 'name' cannot be empty.", http.StatusBadRequest)
+			return
+		}
+		req.Name = &trimmed
+	}
+	if req.Privacy != nil && !isValidGroupPrivacy(*req.Privacy) {
+		http.Error(w, "Invalid field 'privacy'; must be one of public, private or closed.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := updateGroup(userID, req)
+	if err != nil {
+		log.Println("Failed to update group due to the following error: ", err)
+		http.Error(w, "Failed to update group.", statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "update_group", "group", &req.GroupID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// updateGroup is limited to admins; moderators manage membership but not the group itself.
+func updateGroup(userID int64, req models.UpdateGroupRequest) (models.UpdateGroupResponse, error) {
+	if _, err := requireGroupRole(req.GroupID, userID, groupRoleAdmin); err != nil {
+		return models.UpdateGroupResponse{Success: false, Message: err.Error()}, err
+	}
+
+	var (
+		setClauses []string
+		args       []interface{}
+	)
+	if req.Name != nil {
+		setClauses = append(setClauses, "name = ?")
+		args = append(args, *req.Name)
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, "description = ?")
+		args = append(args, *req.Description)
+	}
+	if req.Privacy != nil {
+		setClauses = append(setClauses, "privacy = ?")
+		args = append(args, *req.Privacy)
+	}
+	if len(setClauses) == 0 {
+		return models.UpdateGroupResponse{
+			Success: true,
+			Message: "Nothing to update.",
+		}, nil
+	}
+
+	query := fmt.Sprintf("UPDATE groups_table SET %s WHERE group_id = ?", strings.Join(setClauses, ", "))
+	args = append(args, req.GroupID)
+	if _, err := database.DB.Exec(query, args...); err != nil {
+		return models.UpdateGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update group due to the following error: %v", err),
+		}, err
+	}
+
+	return models.UpdateGroupResponse{
+		Success: true,
+		Message: "Successfully updated group.",
+	}, nil
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/groups"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func UpdateMemberRoleHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.UpdateMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.GroupID <= 0 || req.MemberID <= 0 {
+		http.Error(w, "Missing required fields 'groupID' and 'memberID'.", http.StatusBadRequest)
+		return
+	}
+	if !isValidGroupRole(req.Role) {
+		http.Error(w, "Invalid field 'role'; must be one of member, moderator or admin.", http.StatusBadRequest)
+		return
+	}
+
+	previousRole, response, err := updateMemberRole(userID, req)
+	if err != nil {
+		log.Println("Failed to update member role due to the following error: ", err)
+		http.Error(w, "Failed to update member role.", statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "update_group_member_role", "group", &req.GroupID, map[string]interface{}{
+		"member_id":     req.MemberID,
+		"previous_role": previousRole,
+		"role":          req.Role,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// updateMemberRole promotes or demotes a member. Only admins may change roles,
+// and the last remaining admin cannot be demoted.
+func updateMemberRole(userID int64, req models.UpdateMemberRoleRequest) (string, models.UpdateMemberRoleResponse, error) {
+	if _, err := requireGroupRole(req.GroupID, userID, groupRoleAdmin); err != nil {
+		return "", models.UpdateMemberRoleResponse{Success: false, Message: err.Error()}, err
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return "", models.UpdateMemberRoleResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var currentRole string
+	err = tx.QueryRow(`
+		SELECT role
+		FROM group_members
+		WHERE group_id = ? AND user_id = ?
+		FOR UPDATE
+	`, req.GroupID, req.MemberID).Scan(&currentRole)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return "", models.UpdateMemberRoleResponse{Success: false, Message: errNotGroupMember.Error()}, errNotGroupMember
+	}
+	if err != nil {
+		tx.Rollback()
+		return "", models.UpdateMemberRoleResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get member role: %v", err),
+		}, err
+	}
+
+	if currentRole == req.Role {
+		tx.Rollback()
+		return currentRole, models.UpdateMemberRoleResponse{
+			Success: true,
+			Message: "Member already has this role.",
+		}, nil
+	}
+
+	if currentRole == groupRoleAdmin {
+		admins, err := countGroupAdmins(tx, req.GroupID)
+		if err != nil {
+			tx.Rollback()
+			return "", models.UpdateMemberRoleResponse{Success: false, Message: err.Error()}, err
+		}
+		if admins <= 1 {
+			tx.Rollback()
+			return "", models.UpdateMemberRoleResponse{Success: false, Message: errLastGroupAdmin.Error()}, errLastGroupAdmin
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE group_members
+		SET role = ?
+		WHERE group_id = ? AND user_id = ?
+	`, req.Role, req.GroupID, req.MemberID)
+	if err != nil {
+		tx.Rollback()
+		return "", models.UpdateMemberRoleResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update member role: %v", err),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", models.UpdateMemberRoleResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return currentRole, models.UpdateMemberRoleResponse{
+		Success: true,
+		Message: "Successfully updated member role.",
+	}, nil
+}
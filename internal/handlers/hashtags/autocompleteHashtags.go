@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	models "VoizyServer/internal/models/hashtags"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func AutocompleteHashtagsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+
+	prefix := util.NormalizeHashtag(q.Get("prefix"))
+	if prefix == "" {
+		http.Error(w, "Missing required param 'prefix'.", http.StatusBadRequest)
+		return
+	}
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := autocompleteHashtags(prefix, limit)
+	if err != nil {
+		log.Println("Failed to autocomplete hashtags due to the following error: ", err)
+		http.Error(w, "Failed to autocomplete hashtags.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// autocompleteHashtags suggests tags starting with the normalized prefix, most
+// used first.
+func autocompleteHashtags(prefix string, limit int64) (models.AutocompleteHashtagsResponse, error) {
+	query := `
+		SELECT h.hashtag_id, h.tag, COUNT(ph.post_id) AS post_count
+		FROM hashtags h
+		LEFT JOIN post_hashtags ph ON ph.hashtag_id = h.hashtag_id
+		WHERE h.tag LIKE ?
+		GROUP BY h.hashtag_id, h.tag
+		ORDER BY post_count DESC, h.tag ASC
+		LIMIT ?
+	`
+	rows, err := database.DB.Query(query, likeEscaper.Replace(prefix)+"%", limit)
+	if err != nil {
+		return models.AutocompleteHashtagsResponse{}, fmt.Errorf("failed to query hashtags: %w", err)
+	}
+	defer rows.Close()
+
+	hashtags := []models.HashtagSuggestion{}
+	for rows.Next() {
+		var h models.HashtagSuggestion
+		if err := rows.Scan(&h.HashtagID, &h.Tag, &h.PostCount); err != nil {
+			return models.AutocompleteHashtagsResponse{}, fmt.Errorf("failed to scan hashtag: %w", err)
+		}
+		hashtags = append(hashtags, h)
+	}
+	if err := rows.Err(); err != nil {
+		return models.AutocompleteHashtagsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return models.AutocompleteHashtagsResponse{
+		Hashtags: hashtags,
+		Prefix:   prefix,
+		Limit:    limit,
+	}, nil
+}
@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/hashtags"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func GetHashtagFeedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	tag := util.NormalizeHashtag(q.Get("tag"))
+	if tag == "" {
+		http.Error(w, "Missing required param 'tag'.", http.StatusBadRequest)
+		return
+	}
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := getHashtagFeed(tag, userID, limit, page)
+	if err != nil {
+		log.Println("Failed to get hashtag feed due to the following error: ", err)
+		http.Error(w, "Failed to get hashtag feed.", http.StatusInternalServerError)
+		return
+	}
+
+	go util.TrackEvent(userID, "view_hashtag_feed", "hashtag", nil, map[string]interface{}{
+		"tag": tag,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// getHashtagFeed lists public posts carrying the tag, newest first, leaving out
+// authors in a block with the viewer.
+func getHashtagFeed(tag string, userID, limit, page int64) (models.GetHashtagFeedResponse, error) {
+	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("p.user_id", userID)
+	visible, visibleArgs := audience.Visible("p", userID)
+
+	var totalPosts int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM post_hashtags ph
+		JOIN hashtags h ON h.hashtag_id = ph.hashtag_id
+		JOIN posts p ON p.post_id = ph.post_id
+		WHERE h.tag = ?
+			AND p.group_id IS NULL
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible
+	countArgs := append([]interface{}{tag}, blockArgs...)
+	countArgs = append(countArgs, visibleArgs...)
+	if err := database.DB.QueryRow(countQuery, countArgs...).Scan(&totalPosts); err != nil {
+		return models.GetHashtagFeedResponse{}, fmt.Errorf("failed to get totalPosts: %w", err)
+	}
+
+	query := `
+		SELECT
+			p.post_id,
+			p.user_id,
+			p.to_user_id,
+			p.original_post_id,
+			p.impressions,
+			(SELECT COUNT(*) FROM post_views pv WHERE pv.post_id = p.post_id) AS views,
+			p.content_text,
+			p.created_at,
+			p.updated_at,
+			p.location_name,
+			p.location_lat,
+			p.location_lng,
+			p.is_poll,
+			p.poll_question,
+			p.poll_duration_type,
+			p.poll_duration_length,
+			p.edited_at,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_pic_url,
+			pr_user.reaction_type AS user_reaction,
+			(SELECT COUNT(*) FROM post_reactions pr WHERE pr.post_id = p.post_id) AS total_reactions,
+			(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.post_id) AS total_comments,
+			(SELECT COUNT(*) FROM post_shares ps WHERE ps.post_id = p.post_id) AS total_post_shares
+		FROM post_hashtags ph
+		JOIN hashtags h ON h.hashtag_id = ph.hashtag_id
+		JOIN posts p ON p.post_id = ph.post_id
+		LEFT JOIN users u ON p.user_id = u.user_id
+		LEFT JOIN user_profiles up ON p.user_id = up.user_id
+		LEFT JOIN user_images ui ON p.user_id = ui.user_id AND ui.is_profile_pic = 1
+		LEFT JOIN post_reactions pr_user ON p.post_id = pr_user.post_id AND pr_user.user_id = ?
+		WHERE h.tag = ?
+			AND p.group_id IS NULL
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible + `
+		ORDER BY p.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+	args := []interface{}{userID, tag}
+	args = append(args, blockArgs...)
+	args = append(args, visibleArgs...)
+	args = append(args, limit, offset)
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return models.GetHashtagFeedResponse{}, fmt.Errorf("failed to execute query for hashtag posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []models.HashtagPost
+	for rows.Next() {
+		var p models.HashtagPost
+		var (
+			originalPostID     sql.NullInt64
+			contentText        sql.NullString
+			createdAt          sql.NullTime
+			updatedAt          sql.NullTime
+			locationName       sql.NullString
+			locationLat        sql.NullFloat64
+			locationLong       sql.NullFloat64
+			isPoll             sql.NullBool
+			pollQuestion       sql.NullString
+			pollDurationType   sql.NullString
+			pollDurationLength sql.NullInt64
+			editedAt           sql.NullTime
+			username           sql.NullString
+			firstName          sql.NullString
+			lastName           sql.NullString
+			preferredName      sql.NullString
+			profilePicURL      sql.NullString
+			userReaction       sql.NullString
+		)
+
+		err := rows.Scan(
+			&p.PostID,
+			&p.UserID,
+			&p.ToUserID,
+			&originalPostID,
+			&p.Impressions,
+			&p.Views,
+			&contentText,
+			&createdAt,
+			&updatedAt,
+			&locationName,
+			&locationLat,
+			&locationLong,
+			&isPoll,
+			&pollQuestion,
+			&pollDurationType,
+			&pollDurationLength,
+			&editedAt,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&userReaction,
+			&p.TotalReactions,
+			&p.TotalComments,
+			&p.TotalPostShares,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		p.OriginalPostID = util.SqlNullInt64ToPtr(originalPostID)
+		p.ContentText = util.SqlNullStringToPtr(contentText)
+		p.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		p.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+		p.LocationName = util.SqlNullStringToPtr(locationName)
+		p.LocationLat = util.SqlNullFloat64ToPtr(locationLat)
+		p.LocationLong = util.SqlNullFloat64ToPtr(locationLong)
+		p.IsPoll = util.SqlNullBoolToPtr(isPoll)
+		p.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
+		p.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
+		p.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
+		p.Edited = editedAt.Valid
+		p.EditedAt = util.SqlNullTimeToPtr(editedAt)
+		p.Username = util.SqlNullStringToPtr(username)
+		p.FirstName = util.SqlNullStringToPtr(firstName)
+		p.LastName = util.SqlNullStringToPtr(lastName)
+		p.PreferredName = util.SqlNullStringToPtr(preferredName)
+		p.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+		p.UserReaction = util.SqlNullStringToPtr(userReaction)
+		posts = append(posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return models.GetHashtagFeedResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	postIDs := make([]int64, len(posts))
+	for i, p := range posts {
+		postIDs[i] = p.PostID
+	}
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return models.GetHashtagFeedResponse{}, err
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.GetHashtagFeedResponse{}, err
+	}
+	texts := make(map[int64]string, len(posts))
+	for _, p := range posts {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.GetHashtagFeedResponse{}, err
+	}
+	profilePics := make([]string, 0, len(posts))
+	for _, p := range posts {
+		if p.ProfilePicURL != nil {
+			profilePics = append(profilePics, *p.ProfilePicURL)
+		}
+	}
+	profilePicImages, err := imaging.ForURLs(profilePics)
+	if err != nil {
+		return models.GetHashtagFeedResponse{}, err
+	}
+	for i := range posts {
+		posts[i].ReactionCounts = reactionCounts[posts[i].PostID]
+		posts[i].OriginalPost = originals[posts[i].PostID]
+		posts[i].LinkPreviews = linkPreviews[posts[i].PostID]
+		posts[i].ProfilePicVariants = imaging.Variants(profilePicImages, posts[i].ProfilePicURL)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalPosts) / float64(limit)))
+	return models.GetHashtagFeedResponse{
+		Tag:        tag,
+		Posts:      posts,
+		Limit:      limit,
+		Page:       page,
+		TotalPosts: totalPosts,
+		TotalPages: totalPages,
+	}, nil
+}
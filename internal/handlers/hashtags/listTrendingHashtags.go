@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	models "VoizyServer/internal/models/hashtags"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// Trending windows, in hours. A tag's uses decay with a half-life of a quarter of
+// the window, so within a 24 hour window a use from 6 hours ago counts half as
+// much as one from just now.
+const (
+	defaultTrendingWindowHours = 24
+	maxTrendingWindowHours     = 24 * 30
+)
+
+func ListTrendingHashtagsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	windowHours := int64(defaultTrendingWindowHours)
+	if hoursString := q.Get("hours"); hoursString != "" {
+		windowHours, err = strconv.ParseInt(hoursString, 10, 64)
+		if err != nil {
+			log.Println("Failed to parse hoursString (string) to windowHours (int64) due to the following error: ", err)
+			http.Error(w, "Failed to parse param 'hours'.", http.StatusInternalServerError)
+			return
+		}
+		if windowHours < 1 || windowHours > maxTrendingWindowHours {
+			http.Error(w, fmt.Sprintf("Param 'hours' must be between 1 and %d.", maxTrendingWindowHours), http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := listTrendingHashtags(windowHours, limit)
+	if err != nil {
+		log.Println("Failed to list trending hashtags due to the following error: ", err)
+		http.Error(w, "Failed to list trending hashtags.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// listTrendingHashtags ranks tags used on public posts within the window. Each use
+// is weighted by how recent it is, and the total is scaled by the share of uses
+// that came from distinct authors, so one account repeating a tag cannot make it
+// trend on its own.
+func listTrendingHashtags(windowHours, limit int64) (models.ListTrendingHashtagsResponse, error) {
+	halfLifeMinutes := float64(windowHours*60) / 4
+
+	query := `
+		SELECT
+			h.hashtag_id,
+			h.tag,
+			COUNT(*) AS uses,
+			COUNT(DISTINCT p.user_id) AS distinct_authors,
+			SUM(POW(0.5, TIMESTAMPDIFF(MINUTE, p.created_at, NOW()) / ?))
+				* COUNT(DISTINCT p.user_id) / COUNT(*) AS score
+		FROM post_hashtags ph
+		JOIN posts p ON p.post_id = ph.post_id
+		JOIN hashtags h ON h.hashtag_id = ph.hashtag_id
+		WHERE p.created_at >= DATE_SUB(NOW(), INTERVAL ? HOUR)
+			AND p.group_id IS NULL
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND p.audience = 'public'
+		GROUP BY h.hashtag_id, h.tag
+		ORDER BY score DESC, uses DESC
+		LIMIT ?
+	`
+	rows, err := database.DB.Query(query, halfLifeMinutes, windowHours, limit)
+	if err != nil {
+		return models.ListTrendingHashtagsResponse{}, fmt.Errorf("failed to query trending hashtags: %w", err)
+	}
+	defer rows.Close()
+
+	hashtags := []models.TrendingHashtag{}
+	for rows.Next() {
+		var h models.TrendingHashtag
+		if err := rows.Scan(&h.HashtagID, &h.Tag, &h.Uses, &h.DistinctAuthors, &h.Score); err != nil {
+			return models.ListTrendingHashtagsResponse{}, fmt.Errorf("failed to scan trending hashtag: %w", err)
+		}
+		hashtags = append(hashtags, h)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListTrendingHashtagsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return models.ListTrendingHashtagsResponse{
+		Hashtags:    hashtags,
+		WindowHours: windowHours,
+		Limit:       limit,
+	}, nil
+}
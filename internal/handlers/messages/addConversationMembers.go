@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func AddConversationMembersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.AddConversationMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	memberIDs := uniqueMemberIDs(req.MemberIDs, userID)
+	if req.ConversationID <= 0 || len(memberIDs) == 0 {
+		http.Error(w, "Missing required fields 'conversationID' and 'memberIDs'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := addConversationMembers(userID, req.ConversationID, memberIDs)
+	if err != nil {
+		log.Println("Failed to add conversation members due to the following error: ", err)
+		http.Error(w, "Failed to add conversation members.", statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "add_conversation_members", "conversation", &req.ConversationID, map[string]interface{}{
+		"member_ids": memberIDs,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func addConversationMembers(userID, conversationID int64, memberIDs []int64) (models.AddConversationMembersResponse, error) {
+	isGroupChat, _, err := getConversation(conversationID)
+	if err != nil {
+		return models.AddConversationMembersResponse{Success: false, Message: err.Error()}, err
+	}
+	if !isGroupChat {
+		return models.AddConversationMembersResponse{Success: false, Message: errNotGroupChat.Error()}, errNotGroupChat
+	}
+	if err := requireConversationMember(conversationID, userID); err != nil {
+		return models.AddConversationMembersResponse{Success: false, Message: err.Error()}, err
+	}
+	if err := requireNotBlocked(userID, memberIDs); err != nil {
+		return models.AddConversationMembersResponse{Success: false, Message: err.Error()}, err
+	}
+
+	query := `
+		INSERT IGNORE INTO conversation_members (conversation_id, user_id)
+		VALUES (?, ?)
+	`
+	var membersAdded int64
+	for _, memberID := range memberIDs {
+		result, err := database.DB.Exec(query, conversationID, memberID)
+		if err != nil {
+			return models.AddConversationMembersResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to add conversation member due to the following error: %v", err),
+			}, err
+		}
+		rowsAffected, _ := result.RowsAffected()
+		membersAdded += rowsAffected
+	}
+
+	return models.AddConversationMembersResponse{
+		Success:      true,
+		Message:      "Successfully added conversation members.",
+		MembersAdded: membersAdded,
+	}, nil
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func CreateConversationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateConversationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	memberIDs := uniqueMemberIDs(req.MemberIDs, userID)
+	if len(memberIDs) == 0 {
+		http.Error(w, "Missing required field 'memberIDs'.", http.StatusBadRequest)
+		return
+	}
+	if !req.IsGroupChat && len(memberIDs) != 1 {
+		http.Error(w, "A direct conversation must have exactly one other member.", http.StatusBadRequest)
+		return
+	}
+
+	if err := requireNotBlocked(userID, memberIDs); err != nil {
+		log.Println("Failed to create conversation due to the following error: ", err)
+		http.Error(w, "Failed to create conversation.", statusForError(err))
+		return
+	}
+
+	response, err := createConversation(userID, memberIDs, req)
+	if err != nil {
+		log.Println("Failed to create conversation due to the following error: ", err)
+		http.Error(w, "Failed to create conversation.", http.StatusInternalServerError)
+		return
+	}
+
+	if !response.AlreadyExisted {
+		go util.TrackEvent(userID, "create_conversation", "conversation", &response.ConversationID, map[string]interface{}{
+			"is_group_chat": req.IsGroupChat,
+			"member_count":  len(memberIDs) + 1,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// uniqueMemberIDs drops duplicates, invalid IDs and the creator, who is always added separately.
+func uniqueMemberIDs(ids []int64, userID int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	var unique []int64
+	for _, id := range ids {
+		if id <= 0 || id == userID || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func createConversation(userID int64, memberIDs []int64, req models.CreateConversationRequest) (models.CreateConversationResponse, error) {
+	if !req.IsGroupChat {
+		existingID, err := findDirectConversation(userID, memberIDs[0])
+		if err != nil {
+			return models.CreateConversationResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to look up existing conversation: %v", err),
+			}, err
+		}
+		if existingID > 0 {
+			return models.CreateConversationResponse{
+				Success:        true,
+				Message:        "Conversation already exists.",
+				ConversationID: existingID,
+				AlreadyExisted: true,
+			}, nil
+		}
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.CreateConversationResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.Exec(`
+		INSERT INTO conversations (conversation_name, is_group_chat, created_by)
+		VALUES (?, ?, ?)
+	`, req.ConversationName, req.IsGroupChat, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.CreateConversationResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to insert conversation: %v", err),
+		}, err
+	}
+	conversationID, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return models.CreateConversationResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get conversation id: %v", err),
+		}, err
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO conversation_members (conversation_id, user_id) VALUES (?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return models.CreateConversationResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to prepare member insert: %v", err),
+		}, err
+	}
+	defer stmt.Close()
+
+	for _, memberID := range append([]int64{userID}, memberIDs...) {
+		if _, err := stmt.Exec(conversationID, memberID); err != nil {
+			tx.Rollback()
+			return models.CreateConversationResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to insert conversation member: %v", err),
+			}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.CreateConversationResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.CreateConversationResponse{
+		Success:        true,
+		Message:        "Successfully created conversation.",
+		ConversationID: conversationID,
+	}, nil
+}
+
+func findDirectConversation(userID, otherUserID int64) (int64, error) {
+	var conversationID int64
+	query := `
+		SELECT c.conversation_id
+		FROM conversations c
+		JOIN conversation_members cm1
+			ON cm1.conversation_id = c.conversation_id AND cm1.user_id = ?
+		JOIN conversation_members cm2
+			ON cm2.conversation_id = c.conversation_id AND cm2.user_id = ?
+		WHERE c.is_group_chat = 0
+			AND (SELECT COUNT(*) FROM conversation_members cm WHERE cm.conversation_id = c.conversation_id) = 2
+		ORDER BY c.conversation_id ASC
+		LIMIT 1
+	`
+	err := database.DB.QueryRow(query, userID, otherUserID).Scan(&conversationID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return conversationID, nil
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListConversationsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := listConversations(userID, limit, page)
+	if err != nil {
+		log.Println("Failed to list conversations due to the following error: ", err)
+		http.Error(w, "Failed to list conversations.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func listConversations(userID, limit, page int64) (models.ListConversationsResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalConversations int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM conversation_members
+		WHERE user_id = ?
+	`
+	err := database.DB.QueryRow(countQuery, userID).Scan(&totalConversations)
+	if err != nil {
+		return models.ListConversationsResponse{}, fmt.Errorf("failed to get totalConversations: %w", err)
+	}
+
+	selectQuery := `
+		SELECT
+			c.conversation_id,
+			c.conversation_name,
+			c.is_group_chat,
+			c.created_by,
+			c.created_at,
+			lm.message_id,
+			lm.content_text,
+			lm.sender_id,
+			lm.sent_at,
+			(
+				SELECT COUNT(*)
+				FROM message_recipients mr
+				JOIN messages m ON m.message_id = mr.message_id
+				WHERE m.conversation_id = c.conversation_id
+					AND mr.recipient_id = ?
+					AND mr.is_read = 0
+			) AS unread_count
+		FROM conversations c
+		JOIN conversation_members cm
+			ON cm.conversation_id = c.conversation_id AND cm.user_id = ?
+		LEFT JOIN messages lm
+			ON lm.message_id = (
+				SELECT m2.message_id
+				FROM messages m2
+				WHERE m2.conversation_id = c.conversation_id
+				ORDER BY m2.sent_at DESC, m2.message_id DESC
+				LIMIT 1
+			)
+		ORDER BY COALESCE(lm.sent_at, c.created_at) DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(selectQuery, userID, userID, limit, offset)
+	if err != nil {
+		return models.ListConversationsResponse{}, fmt.Errorf("failed to select conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var conversations []models.ListConversation
+	for rows.Next() {
+		var c models.ListConversation
+		var (
+			conversationName  sql.NullString
+			createdBy         sql.NullInt64
+			lastMessageID     sql.NullInt64
+			lastMessageText   sql.NullString
+			lastMessageSender sql.NullInt64
+			lastMessageSentAt sql.NullTime
+		)
+		err := rows.Scan(
+			&c.ConversationID,
+			&conversationName,
+			&c.IsGroupChat,
+			&createdBy,
+			&c.CreatedAt,
+			&lastMessageID,
+			&lastMessageText,
+			&lastMessageSender,
+			&lastMessageSentAt,
+			&c.UnreadCount,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		c.ConversationName = util.SqlNullStringToPtr(conversationName)
+		c.CreatedBy = util.SqlNullInt64ToPtr(createdBy)
+		c.LastMessageID = util.SqlNullInt64ToPtr(lastMessageID)
+		c.LastMessageText = util.SqlNullStringToPtr(lastMessageText)
+		c.LastMessageSender = util.SqlNullInt64ToPtr(lastMessageSender)
+		c.LastMessageSentAt = util.SqlNullTimeToPtr(lastMessageSentAt)
+		conversations = append(conversations, c)
+	}
+	if err = rows.Err(); err != nil {
+		return models.ListConversationsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	for i := range conversations {
+		members, err := listConversationMembers(conversations[i].ConversationID)
+		if err != nil {
+			log.Println("Failed to list conversation members: ", err)
+			continue
+		}
+		conversations[i].Members = members
+	}
+
+	totalPages := int64(math.Ceil(float64(totalConversations) / float64(limit)))
+
+	return models.ListConversationsResponse{
+		Conversations:      conversations,
+		Limit:              limit,
+		Page:               page,
+		TotalConversations: totalConversations,
+		TotalPages:         totalPages,
+	}, nil
+}
+
+func listConversationMembers(conversationID int64) ([]models.ConversationMember, error) {
+	query := `
+		SELECT
+			cm.user_id,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_pic_url,
+			cm.joined_at
+		FROM conversation_members cm
+		LEFT JOIN users u ON u.user_id = cm.user_id
+		LEFT JOIN user_profiles up ON up.user_id = cm.user_id
+		LEFT JOIN user_images ui ON ui.user_id = cm.user_id AND ui.is_profile_pic = 1
+		WHERE cm.conversation_id = ?
+		ORDER BY cm.joined_at ASC
+	`
+	rows, err := database.DB.Query(query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.ConversationMember
+	for rows.Next() {
+		var m models.ConversationMember
+		var username, firstName, lastName, preferredName, profilePicURL sql.NullString
+		err := rows.Scan(
+			&m.UserID,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&m.JoinedAt,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		m.Username = util.SqlNullStringToPtr(username)
+		m.FirstName = util.SqlNullStringToPtr(firstName)
+		m.LastName = util.SqlNullStringToPtr(lastName)
+		m.PreferredName = util.SqlNullStringToPtr(preferredName)
+		m.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
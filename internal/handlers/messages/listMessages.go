@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func ListMessagesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	conversationIDString := q.Get("id")
+	if conversationIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	conversationID, err := strconv.ParseInt(conversationIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse conversationIDString (string) to conversationID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := requireConversationMember(conversationID, userID); err != nil {
+		log.Println("Failed to list messages due to the following error: ", err)
+		http.Error(w, "Failed to list messages.", statusForError(err))
+		return
+	}
+
+	response, err := listMessages(conversationID, userID, limit, page)
+	if err != nil {
+		log.Println("Failed to list messages due to the following error: ", err)
+		http.Error(w, "Failed to list messages.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func listMessages(conversationID, userID, limit, page int64) (models.ListMessagesResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalMessages int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM messages
+		WHERE conversation_id = ?
+	`
+	err := database.DB.QueryRow(countQuery, conversationID).Scan(&totalMessages)
+	if err != nil {
+		return models.ListMessagesResponse{}, fmt.Errorf("failed to get totalMessages: %w", err)
+	}
+
+	selectQuery := messageSelect + `
+		WHERE m.conversation_id = ?
+		ORDER BY m.sent_at DESC, m.message_id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(selectQuery, userID, conversationID, limit, offset)
+	if err != nil {
+		return models.ListMessagesResponse{}, fmt.Errorf("failed to select messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []models.ListMessage
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		messages = append(messages, m)
+	}
+	if err = rows.Err(); err != nil {
+		return models.ListMessagesResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	if err := attachMessageAttachments(messages); err != nil {
+		return models.ListMessagesResponse{}, err
+	}
+	if err := attachMessageLinkPreviews(messages); err != nil {
+		return models.ListMessagesResponse{}, err
+	}
+
+	totalPages := int64(math.Ceil(float64(totalMessages) / float64(limit)))
+
+	return models.ListMessagesResponse{
+		Messages:      messages,
+		Limit:         limit,
+		Page:          page,
+		TotalMessages: totalMessages,
+		TotalPages:    totalPages,
+	}, nil
+}
+
+const messageSelect = `
+		SELECT
+			m.message_id,
+			m.conversation_id,
+			m.sender_id,
+			u.username,
+			up.preferred_name,
+			ui.image_url AS profile_pic_url,
+			m.content_text,
+			m.sent_at,
+			mr_user.is_read,
+			mr_user.read_at,
+			(SELECT COUNT(*) FROM message_recipients mr WHERE mr.message_id = m.message_id AND mr.is_read = 1) AS read_by_count
+		FROM messages m
+		LEFT JOIN users u ON u.user_id = m.sender_id
+		LEFT JOIN user_profiles up ON up.user_id = m.sender_id
+		LEFT JOIN user_images ui ON ui.user_id = m.sender_id AND ui.is_profile_pic = 1
+		LEFT JOIN message_recipients mr_user
+			ON mr_user.message_id = m.message_id AND mr_user.recipient_id = ?
+	`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMessage(row rowScanner) (models.ListMessage, error) {
+	var m models.ListMessage
+	var (
+		username      sql.NullString
+		preferredName sql.NullString
+		profilePicURL sql.NullString
+		contentText   sql.NullString
+		isRead        sql.NullBool
+		readAt        sql.NullTime
+	)
+	err := row.Scan(
+		&m.MessageID,
+		&m.ConversationID,
+		&m.SenderID,
+		&username,
+		&preferredName,
+		&profilePicURL,
+		&contentText,
+		&m.SentAt,
+		&isRead,
+		&readAt,
+		&m.ReadByCount,
+	)
+	if err != nil {
+		return models.ListMessage{}, err
+	}
+	m.Username = util.SqlNullStringToPtr(username)
+	m.PreferredName = util.SqlNullStringToPtr(preferredName)
+	m.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+	m.ContentText = util.SqlNullStringToPtr(contentText)
+	// The sender has no recipient row, so their own messages count as read.
+	m.IsRead = !isRead.Valid || isRead.Bool
+	m.ReadAt = util.SqlNullTimeToPtr(readAt)
+	m.Attachments = []models.MessageAttachment{}
+	return m, nil
+}
+
+func getMessage(messageID, viewerID int64) (models.ListMessage, error) {
+	m, err := scanMessage(database.DB.QueryRow(messageSelect+` WHERE m.message_id = ?`, viewerID, messageID))
+	if err != nil {
+		return models.ListMessage{}, fmt.Errorf("failed to get message: %w", err)
+	}
+	messages := []models.ListMessage{m}
+	if err := attachMessageAttachments(messages); err != nil {
+		return models.ListMessage{}, err
+	}
+	if err := attachMessageLinkPreviews(messages); err != nil {
+		return models.ListMessage{}, err
+	}
+	return messages[0], nil
+}
+
+func attachMessageAttachments(messages []models.ListMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(messages))
+	args := make([]interface{}, len(messages))
+	index := make(map[int64]int, len(messages))
+	for i, m := range messages {
+		placeholders[i] = "?"
+		args[i] = m.MessageID
+		index[m.MessageID] = i
+	}
+
+	query := `
+		SELECT attachment_id, message_id, file_url, file_type, duration_ms, waveform, uploaded_at
+		FROM message_attachments
+		WHERE message_id IN (` + strings.Join(placeholders, ",") + `)
+		ORDER BY attachment_id ASC
+	`
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to select message attachments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a models.MessageAttachment
+		var messageID int64
+		var durationMS sql.NullInt64
+		var waveform []byte
+		if err := rows.Scan(&a.AttachmentID, &messageID, &a.FileURL, &a.FileType, &durationMS, &waveform, &a.UploadedAt); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		if durationMS.Valid {
+			a.DurationMS = &durationMS.Int64
+		}
+		for _, level := range waveform {
+			a.Waveform = append(a.Waveform, int(level))
+		}
+		if i, ok := index[messageID]; ok {
+			messages[i].Attachments = append(messages[i].Attachments, a)
+		}
+	}
+
+	return rows.Err()
+}
+
+func attachMessageLinkPreviews(messages []models.ListMessage) error {
+	texts := make(map[int64]string, len(messages))
+	for _, m := range messages {
+		if m.ContentText != nil {
+			texts[m.MessageID] = *m.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return err
+	}
+	for i := range messages {
+		messages[i].LinkPreviews = linkPreviews[messages[i].MessageID]
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/realtime"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func MarkMessagesReadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.MarkMessagesReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.ConversationID <= 0 {
+		http.Error(w, "Missing or invalid conversationID.", http.StatusBadRequest)
+		return
+	}
+
+	if err := requireConversationMember(req.ConversationID, userID); err != nil {
+		log.Println("Failed to mark messages read due to the following error: ", err)
+		http.Error(w, "Failed to mark messages read.", statusForError(err))
+		return
+	}
+
+	response, err := markMessagesRead(userID, req)
+	if err != nil {
+		log.Println("Failed to mark messages read due to the following error: ", err)
+		http.Error(w, "Failed to mark messages read.", http.StatusInternalServerError)
+		return
+	}
+
+	if response.MessagesRead > 0 {
+		go realtime.PublishToConversation(req.ConversationID, userID, false, realtime.EventMessageRead, models.MessagesReadEvent{
+			MessageIDs: req.MessageIDs,
+			ReadAt:     time.Now(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// markMessagesRead marks the given messages as read for the caller, or every
+// unread message in the conversation when no messageIDs are passed.
+func markMessagesRead(userID int64, req models.MarkMessagesReadRequest) (models.MarkMessagesReadResponse, error) {
+	query := `
+		UPDATE message_recipients mr
+		JOIN messages m ON m.message_id = mr.message_id
+		SET mr.is_read = 1, mr.read_at = NOW()
+		WHERE mr.recipient_id = ?
+			AND m.conversation_id = ?
+			AND mr.is_read = 0
+	`
+	args := []interface{}{userID, req.ConversationID}
+	if len(req.MessageIDs) > 0 {
+		placeholders := make([]string, len(req.MessageIDs))
+		for i, id := range req.MessageIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		query += " AND mr.message_id IN (" + strings.Join(placeholders, ",") + ")"
+	}
+
+	result, err := database.DB.Exec(query, args...)
+	if err != nil {
+		return models.MarkMessagesReadResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to mark messages read due to the following error: %v", err),
+		}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+
+	return models.MarkMessagesReadResponse{
+		Success:      true,
+		Message:      "Successfully marked messages read.",
+		MessagesRead: rowsAffected,
+	}, nil
+}
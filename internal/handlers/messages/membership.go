@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/uploads"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	errNotConversationMember = errors.New("user is not a member of this conversation")
+	errConversationNotFound  = errors.New("conversation not found")
+	errNotGroupChat          = errors.New("members can only be changed on group chats")
+	errNotConversationOwner  = errors.New("only the creator of the conversation can remove other members")
+)
+
+func requireConversationMember(conversationID, userID int64) error {
+	var exists int
+	query := `
+		SELECT 1
+		FROM conversation_members
+		WHERE conversation_id = ? AND user_id = ?
+		LIMIT 1
+	`
+	err := database.DB.QueryRow(query, conversationID, userID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return errNotConversationMember
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check conversation membership: %w", err)
+	}
+
+	return nil
+}
+
+// requireNotBlocked fails with blocks.ErrBlocked when userID is blocked with any of
+// memberIDs, so blocked users can never be placed in a conversation together.
+func requireNotBlocked(userID int64, memberIDs []int64) error {
+	for _, memberID := range memberIDs {
+		if err := blocks.Check(userID, memberID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireDirectConversationNotBlocked refuses messages in a one-to-one conversation
+// once either member has blocked the other. Group chats stay open to everyone in them.
+func requireDirectConversationNotBlocked(conversationID, userID int64) error {
+	isGroupChat, _, err := getConversation(conversationID)
+	if err != nil {
+		return err
+	}
+	if isGroupChat {
+		return nil
+	}
+
+	memberIDs, err := getConversationMemberIDs(conversationID)
+	if err != nil {
+		return err
+	}
+	return requireNotBlocked(userID, memberIDs)
+}
+
+func getConversationMemberIDs(conversationID int64) ([]int64, error) {
+	query := `
+		SELECT user_id
+		FROM conversation_members
+		WHERE conversation_id = ?
+	`
+	rows, err := database.DB.Query(query, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation members: %w", err)
+	}
+	defer rows.Close()
+
+	var memberIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return memberIDs, nil
+}
+
+func getConversation(conversationID int64) (bool, sql.NullInt64, error) {
+	var (
+		isGroupChat bool
+		createdBy   sql.NullInt64
+	)
+	query := `
+		SELECT is_group_chat, created_by
+		FROM conversations
+		WHERE conversation_id = ?
+	`
+	err := database.DB.QueryRow(query, conversationID).Scan(&isGroupChat, &createdBy)
+	if err == sql.ErrNoRows {
+		return false, createdBy, errConversationNotFound
+	}
+	if err != nil {
+		return false, createdBy, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	return isGroupChat, createdBy, nil
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, errNotConversationMember), errors.Is(err, errNotConversationOwner), errors.Is(err, blocks.ErrBlocked):
+		return http.StatusForbidden
+	case errors.Is(err, errConversationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errNotGroupChat):
+		return http.StatusBadRequest
+	default:
+		return uploads.HTTPStatus(err)
+	}
+}
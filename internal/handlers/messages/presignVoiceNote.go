@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/uploads"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func PresignVoiceNoteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PresignVoiceNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.ConversationID <= 0 {
+		http.Error(w, "Missing or invalid conversationID.", http.StatusBadRequest)
+		return
+	}
+	if req.FileName == "" {
+		http.Error(w, "Missing required field 'fileName'.", http.StatusBadRequest)
+		return
+	}
+
+	if err := requireConversationMember(req.ConversationID, userID); err != nil {
+		log.Println("Failed to presign voice note due to the following error: ", err)
+		http.Error(w, "Failed to presign voice note.", statusForError(err))
+		return
+	}
+	if err := requireDirectConversationNotBlocked(req.ConversationID, userID); err != nil {
+		log.Println("Failed to presign voice note due to the following error: ", err)
+		http.Error(w, "Failed to presign voice note.", statusForError(err))
+		return
+	}
+
+	response, err := presignVoiceNote(r.Context(), userID, req)
+	if err != nil {
+		log.Println("Failed to presign voice note due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to presign voice note (%v).", err), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// presignVoiceNote hands out an upload URL for a recording. Once uploaded it is
+// confirmed through /uploads/confirm like any other upload, which is where its
+// duration is checked and its waveform drawn, and then sent as an audio attachment.
+func presignVoiceNote(ctx context.Context, userID int64, req models.PresignVoiceNoteRequest) (models.PresignVoiceNoteResponse, error) {
+	dir := fmt.Sprintf("%d/messages/%d", userID, req.ConversationID)
+	contentType := uploads.ContentType(req.FileName, req.ContentType)
+	upload, err := uploads.Presign(ctx, userID, uploads.VoiceNote, dir, req.FileName, contentType, req.Size)
+	if err != nil {
+		return models.PresignVoiceNoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to presign voice note: %v", err),
+		}, err
+	}
+
+	return models.PresignVoiceNoteResponse{
+		Success:       true,
+		Message:       "Presigned voice note.",
+		FileName:      upload.Key,
+		PresignedURL:  upload.PresignedURL,
+		FinalURL:      upload.FinalURL,
+		ContentType:   upload.ContentType,
+		MaxSize:       upload.MaxSize,
+		MaxDurationMS: uploads.MaxVoiceNoteDuration.Milliseconds(),
+		Headers:       upload.Headers,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/realtime"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+var validReactionTypes = map[string]bool{
+	"like":         true,
+	"love":         true,
+	"laugh":        true,
+	"congratulate": true,
+	"shocked":      true,
+	"sad":          true,
+	"angry":        true,
+}
+
+func PutMessageReactionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PutMessageReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.MessageID <= 0 || !validReactionTypes[req.ReactionType] {
+		http.Error(w, "Missing or invalid 'messageID' or 'reactionType'.", http.StatusBadRequest)
+		return
+	}
+
+	conversationID, err := getMessageConversationID(req.MessageID)
+	if err != nil {
+		log.Println("Failed to put reaction to message due to the following error: ", err)
+		http.Error(w, "Failed to put reaction to message.", statusForError(err))
+		return
+	}
+	if err := requireConversationMember(conversationID, userID); err != nil {
+		log.Println("Failed to put reaction to message due to the following error: ", err)
+		http.Error(w, "Failed to put reaction to message.", statusForError(err))
+		return
+	}
+
+	response, err := putMessageReaction(userID, req)
+	if err != nil {
+		log.Println("Failed to put reaction to message due to the following error: ", err)
+		http.Error(w, "Failed to put reaction to message.", http.StatusInternalServerError)
+		return
+	}
+
+	go realtime.PublishToConversation(conversationID, userID, false, realtime.EventMessageReaction, models.MessageReactionEvent{
+		MessageID:    req.MessageID,
+		ReactionType: req.ReactionType,
+		Removed:      response.Removed,
+	})
+	go util.TrackEvent(userID, "react_to_message", "message_reaction", &response.ReactionID, map[string]interface{}{
+		"reaction_type": req.ReactionType,
+		"message_id":    req.MessageID,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func getMessageConversationID(messageID int64) (int64, error) {
+	var conversationID int64
+	err := database.DB.QueryRow(`SELECT conversation_id FROM messages WHERE message_id = ?`, messageID).Scan(&conversationID)
+	if err == sql.ErrNoRows {
+		return 0, errConversationNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get message conversation: %w", err)
+	}
+	return conversationID, nil
+}
+
+// putMessageReaction mirrors putPostReaction: reacting with the same type
+// again removes the reaction, and a different type replaces it.
+func putMessageReaction(userID int64, req models.PutMessageReactionRequest) (models.PutMessageReactionResponse, error) {
+	var (
+		existingID   int64
+		existingType string
+	)
+
+	err := database.DB.
+		QueryRow(`SELECT message_reaction_id, reaction_type
+                  FROM message_reactions
+                  WHERE message_id = ? AND user_id = ?`,
+			req.MessageID, userID).
+		Scan(&existingID, &existingType)
+	if err != nil && err != sql.ErrNoRows {
+		return models.PutMessageReactionResponse{
+			Success: false,
+			Message: fmt.Sprintf("error checking existing reaction: %v", err),
+		}, err
+	}
+
+	if err == sql.ErrNoRows {
+		res, err := database.DB.Exec(
+			`INSERT INTO message_reactions (message_id, user_id, reaction_type)
+             VALUES (?, ?, ?)`,
+			req.MessageID, userID, req.ReactionType,
+		)
+		if err != nil {
+			return models.PutMessageReactionResponse{
+				Success: false,
+				Message: fmt.Sprintf("error inserting reaction: %v", err),
+			}, err
+		}
+		newID, _ := res.LastInsertId()
+		return models.PutMessageReactionResponse{
+			Success:    true,
+			Message:    "Reaction added",
+			ReactionID: newID,
+		}, nil
+	}
+
+	if existingType == req.ReactionType {
+		_, err := database.DB.Exec(`DELETE FROM message_reactions WHERE message_reaction_id = ?`, existingID)
+		if err != nil {
+			return models.PutMessageReactionResponse{
+				Success: false,
+				Message: fmt.Sprintf("error removing reaction: %v", err),
+			}, err
+		}
+		return models.PutMessageReactionResponse{
+			Success:    true,
+			Message:    "Reaction removed",
+			ReactionID: existingID,
+			Removed:    true,
+		}, nil
+	}
+
+	_, err = database.DB.Exec(
+		`UPDATE message_reactions
+         SET reaction_type = ?, reacted_at = NOW()
+         WHERE message_reaction_id = ?`,
+		req.ReactionType, existingID,
+	)
+	if err != nil {
+		return models.PutMessageReactionResponse{
+			Success: false,
+			Message: fmt.Sprintf("error updating reaction: %v", err),
+		}, err
+	}
+	return models.PutMessageReactionResponse{
+		Success:    true,
+		Message:    "Reaction updated",
+		ReactionID: existingID,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func RemoveConversationMemberHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.RemoveConversationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.ConversationID <= 0 || req.MemberID <= 0 {
+		http.Error(w, "Missing required fields 'conversationID' and 'memberID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := removeConversationMember(userID, req)
+	if err != nil {
+		log.Println("Failed to remove conversation member due to the following error: ", err)
+		http.Error(w, "Failed to remove conversation member.", statusForError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "remove_conversation_member", "conversation", &req.ConversationID, map[string]interface{}{
+		"member_id": req.MemberID,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// removeConversationMember lets any member leave a group chat, but only the
+// creator of the group can remove somebody else.
+func removeConversationMember(userID int64, req models.RemoveConversationMemberRequest) (models.RemoveConversationMemberResponse, error) {
+	isGroupChat, createdBy, err := getConversation(req.ConversationID)
+	if err != nil {
+		return models.RemoveConversationMemberResponse{Success: false, Message: err.Error()}, err
+	}
+	if !isGroupChat {
+		return models.RemoveConversationMemberResponse{Success: false, Message: errNotGroupChat.Error()}, errNotGroupChat
+	}
+	if err := requireConversationMember(req.ConversationID, userID); err != nil {
+		return models.RemoveConversationMemberResponse{Success: false, Message: err.Error()}, err
+	}
+	if req.MemberID != userID && (!createdBy.Valid || createdBy.Int64 != userID) {
+		return models.RemoveConversationMemberResponse{Success: false, Message: errNotConversationOwner.Error()}, errNotConversationOwner
+	}
+
+	result, err := database.DB.Exec(`
+		DELETE FROM conversation_members
+		WHERE conversation_id = ? AND user_id = ?
+	`, req.ConversationID, req.MemberID)
+	if err != nil {
+		return models.RemoveConversationMemberResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to remove conversation member due to the following error: %v", err),
+		}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return models.RemoveConversationMemberResponse{
+			Success: false,
+			Message: "Member is not part of this conversation.",
+		}, errNotConversationMember
+	}
+
+	return models.RemoveConversationMemberResponse{
+		Success: true,
+		Message: "Successfully removed conversation member.",
+	}, nil
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/messages"
+	"VoizyServer/internal/realtime"
+	"VoizyServer/internal/uploads"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+var validAttachmentTypes = map[string]bool{
+	"image": true,
+	"video": true,
+	"doc":   true,
+	"audio": true,
+}
+
+func SendMessageHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.SendMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.ConversationID <= 0 {
+		http.Error(w, "Missing or invalid conversationID.", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.ContentText) == "" && len(req.Attachments) == 0 {
+		http.Error(w, "A message needs either contentText or attachments.", http.StatusBadRequest)
+		return
+	}
+	for _, a := range req.Attachments {
+		if a.FileURL == "" || !validAttachmentTypes[a.FileType] {
+			http.Error(w, "Invalid attachment; 'fileURL' is required and 'fileType' must be one of image, video, doc or audio.", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := requireConversationMember(req.ConversationID, userID); err != nil {
+		log.Println("Failed to send message due to the following error: ", err)
+		http.Error(w, "Failed to send message.", statusForError(err))
+		return
+	}
+	if err := requireDirectConversationNotBlocked(req.ConversationID, userID); err != nil {
+		log.Println("Failed to send message due to the following error: ", err)
+		http.Error(w, "Failed to send message.", statusForError(err))
+		return
+	}
+
+	voiceNotes, err := getVoiceNotes(userID, req.Attachments)
+	if err != nil {
+		log.Println("Failed to send message due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to send message (%v).", err), statusForError(err))
+		return
+	}
+
+	response, err := sendMessage(userID, req, voiceNotes)
+	if err != nil {
+		log.Println("Failed to send message due to the following error: ", err)
+		http.Error(w, "Failed to send message.", http.StatusInternalServerError)
+		return
+	}
+
+	go publishNewMessage(response.MessageID, req.ConversationID, userID)
+	go linkpreview.Refresh(linkpreview.ExtractURLs(req.ContentText))
+	go util.TrackEvent(userID, "send_message", "message", &response.MessageID, map[string]interface{}{
+		"conversation_id":  req.ConversationID,
+		"attachment_count": len(req.Attachments),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// getVoiceNotes checks that the audio attachments are voice notes the sender has
+// uploaded, and returns them keyed by URL.
+func getVoiceNotes(userID int64, attachments []models.SendMessageAttachment) (map[string]uploads.Confirmed, error) {
+	var urls []string
+	for _, a := range attachments {
+		if a.FileType == "audio" {
+			urls = append(urls, a.FileURL)
+		}
+	}
+	return uploads.Lookup(userID, uploads.VoiceNote, urls)
+}
+
+func sendMessage(userID int64, req models.SendMessageRequest, voiceNotes map[string]uploads.Confirmed) (models.SendMessageResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.Exec(`
+		INSERT INTO messages (conversation_id, sender_id, content_text)
+		VALUES (?, ?, ?)
+	`, req.ConversationID, userID, req.ContentText)
+	if err != nil {
+		tx.Rollback()
+		return models.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to insert message: %v", err),
+		}, err
+	}
+	messageID, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return models.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get message id: %v", err),
+		}, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO message_recipients (message_id, recipient_id)
+		SELECT ?, user_id
+		FROM conversation_members
+		WHERE conversation_id = ? AND user_id != ?
+	`, messageID, req.ConversationID, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to insert message recipients: %v", err),
+		}, err
+	}
+
+	if len(req.Attachments) > 0 {
+		stmt, err := tx.Prepare(`
+			INSERT INTO message_attachments (message_id, file_url, file_type, duration_ms, waveform)
+			VALUES (?, ?, ?, ?, ?)
+		`)
+		if err != nil {
+			tx.Rollback()
+			return models.SendMessageResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to prepare attachment insert: %v", err),
+			}, err
+		}
+		defer stmt.Close()
+
+		for _, a := range req.Attachments {
+			var durationMS interface{}
+			var waveform []byte
+			if voiceNote, ok := voiceNotes[a.FileURL]; ok && a.FileType == "audio" {
+				durationMS = voiceNote.DurationMS
+				waveform = voiceNote.Waveform
+			}
+			if _, err := stmt.Exec(messageID, a.FileURL, a.FileType, durationMS, waveform); err != nil {
+				tx.Rollback()
+				return models.SendMessageResponse{
+					Success: false,
+					Message: fmt.Sprintf("Failed to insert attachment: %v", err),
+				}, err
+			}
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE conversations SET updated_at = NOW() WHERE conversation_id = ?`, req.ConversationID); err != nil {
+		tx.Rollback()
+		return models.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to touch conversation: %v", err),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.SendMessageResponse{
+		Success:   true,
+		Message:   "Successfully sent message.",
+		MessageID: messageID,
+	}, nil
+}
+
+// publishNewMessage pushes the stored message, in the same shape /messages/list
+// returns, to every member of the conversation including the sender's other devices.
+func publishNewMessage(messageID, conversationID, senderID int64) {
+	message, err := getMessage(messageID, senderID)
+	if err != nil {
+		log.Println("Failed to load message for realtime delivery: ", err)
+		return
+	}
+	realtime.PublishToConversation(conversationID, senderID, false, realtime.EventMessageNew, message)
+}
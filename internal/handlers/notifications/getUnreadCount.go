@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/notifications"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func GetUnreadCountHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	response, err := getUnreadCount(userID)
+	if err != nil {
+		log.Println("Failed to get unread notification count due to the following error: ", err)
+		http.Error(w, "Failed to get unread notification count.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// getUnreadCount counts unread groups, which is what a badge should show since the
+// list collapses each group into one entry, along with the raw unread rows.
+func getUnreadCount(userID int64) (models.GetUnreadCountResponse, error) {
+	notBlocked, blockArgs := blocks.Exclude("notifications.actor_id", userID)
+
+	var response models.GetUnreadCountResponse
+	query := `
+		SELECT COUNT(DISTINCT notifications.group_key), COUNT(*)
+		FROM notifications
+		WHERE notifications.user_id = ?
+			AND notifications.is_read = 0
+			AND ` + notBlocked
+	args := append([]interface{}{userID}, blockArgs...)
+	if err := database.DB.QueryRow(query, args...).Scan(&response.UnreadCount, &response.UnreadNotifications); err != nil {
+		return models.GetUnreadCountResponse{}, fmt.Errorf("failed to get unread count: %w", err)
+	}
+
+	return response, nil
+}
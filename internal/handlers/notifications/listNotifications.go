@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/notifications"
+	"VoizyServer/internal/notifications"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+
+	limitString := q.Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := q.Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := listNotifications(userID, limit, page)
+	if err != nil {
+		log.Println("Failed to list notifications due to the following error: ", err)
+		http.Error(w, "Failed to list notifications.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// listNotifications returns the user's notifications collapsed by group key and
+// read state, newest group first. Each group is described by its latest row.
+func listNotifications(userID, limit, page int64) (models.ListNotificationsResponse, error) {
+	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("notifications.actor_id", userID)
+
+	var totalGroups int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM (
+			SELECT 1
+			FROM notifications
+			WHERE notifications.user_id = ?
+				AND ` + notBlocked + `
+			GROUP BY notifications.group_key, notifications.is_read
+		) g
+	`
+	countArgs := append([]interface{}{userID}, blockArgs...)
+	if err := database.DB.QueryRow(countQuery, countArgs...).Scan(&totalGroups); err != nil {
+		return models.ListNotificationsResponse{}, fmt.Errorf("failed to get totalGroups: %w", err)
+	}
+
+	selectQuery := `
+		SELECT
+			g.group_key,
+			n.notification_type,
+			n.object_type,
+			n.object_id,
+			g.is_read,
+			n.actor_id,
+			u.username,
+			COALESCE(up.preferred_name, up.first_name) AS actor_name,
+			ui.image_url AS profile_pic_url,
+			g.actor_count,
+			g.notification_count,
+			g.latest_at
+		FROM (
+			SELECT
+				notifications.group_key,
+				notifications.is_read,
+				COUNT(DISTINCT notifications.actor_id) AS actor_count,
+				COUNT(*) AS notification_count,
+				MAX(notifications.created_at) AS latest_at,
+				MAX(notifications.notification_id) AS latest_id
+			FROM notifications
+			WHERE notifications.user_id = ?
+				AND ` + notBlocked + `
+			GROUP BY notifications.group_key, notifications.is_read
+			ORDER BY latest_at DESC, latest_id DESC
+			LIMIT ? OFFSET ?
+		) g
+		JOIN notifications n ON n.notification_id = g.latest_id
+		JOIN users u ON u.user_id = n.actor_id
+		LEFT JOIN user_profiles up ON up.user_id = n.actor_id
+		LEFT JOIN user_images ui ON ui.user_id = n.actor_id AND ui.is_profile_pic = 1
+		ORDER BY g.latest_at DESC, g.latest_id DESC
+	`
+	selectArgs := append([]interface{}{userID}, blockArgs...)
+	selectArgs = append(selectArgs, limit, offset)
+	rows, err := database.DB.Query(selectQuery, selectArgs...)
+	if err != nil {
+		return models.ListNotificationsResponse{}, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []models.NotificationGroup
+	for rows.Next() {
+		var (
+			g             models.NotificationGroup
+			actorName     sql.NullString
+			profilePicURL sql.NullString
+		)
+		if err := rows.Scan(
+			&g.GroupKey,
+			&g.NotificationType,
+			&g.ObjectType,
+			&g.ObjectID,
+			&g.IsRead,
+			&g.LatestActorID,
+			&g.LatestActorUsername,
+			&actorName,
+			&profilePicURL,
+			&g.ActorCount,
+			&g.NotificationCount,
+			&g.LatestAt,
+		); err != nil {
+			return models.ListNotificationsResponse{}, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		g.LatestActorName = util.SqlNullStringToPtr(actorName)
+		g.LatestActorProfilePic = util.SqlNullStringToPtr(profilePicURL)
+
+		displayName := g.LatestActorUsername
+		if g.LatestActorName != nil && *g.LatestActorName != "" {
+			displayName = *g.LatestActorName
+		}
+		g.Summary = notifications.Summary(g.NotificationType, displayName, g.ActorCount)
+
+		groups = append(groups, g)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListNotificationsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalGroups) / float64(limit)))
+
+	return models.ListNotificationsResponse{
+		Notifications: groups,
+		Limit:         limit,
+		Page:          page,
+		TotalGroups:   totalGroups,
+		TotalPages:    totalPages,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/notifications"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+func MarkNotificationsReadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.MarkNotificationsReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := markNotificationsRead(userID, req)
+	if err != nil {
+		log.Println("Failed to mark notifications read due to the following error: ", err)
+		http.Error(w, "Failed to mark notifications read.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// markNotificationsRead marks every unread notification in the given groups as
+// read, or all of the user's unread notifications when no groupKeys are passed.
+func markNotificationsRead(userID int64, req models.MarkNotificationsReadRequest) (models.MarkNotificationsReadResponse, error) {
+	query := `
+		UPDATE notifications
+		SET is_read = 1, read_at = NOW()
+		WHERE user_id = ? AND is_read = 0
+	`
+	args := []interface{}{userID}
+	if len(req.GroupKeys) > 0 {
+		placeholders := make([]string, len(req.GroupKeys))
+		for i, key := range req.GroupKeys {
+			placeholders[i] = "?"
+			args = append(args, key)
+		}
+		query += " AND group_key IN (" + strings.Join(placeholders, ",") + ")"
+	}
+
+	result, err := database.DB.Exec(query, args...)
+	if err != nil {
+		return models.MarkNotificationsReadResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to mark notifications read due to the following error: %v", err),
+		}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+
+	return models.MarkNotificationsReadResponse{
+		Success:           true,
+		Message:           "Successfully marked notifications read.",
+		NotificationsRead: rowsAffected,
+	}, nil
+}
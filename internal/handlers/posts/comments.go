@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxCommentDepth is the deepest a reply can nest: 0 is a comment on the post, 1 a
+// reply to it and 2 a reply to that reply. Replying any deeper adds the reply next
+// to the comment being answered instead of below it.
+const maxCommentDepth = 2
+
+var (
+	errInvalidParentComment = errors.New("parent comment does not belong to this post")
+	errCommentForbidden     = errors.New("user is not allowed to change this comment")
+)
+
+// commentParent is where a new reply is stored: under commentID at depth.
+// repliedToUserID is the author of the comment the user actually answered, which
+// differs from commentID's author when the reply was moved up a level.
+type commentParent struct {
+	commentID       int64
+	depth           int
+	repliedToUserID int64
+}
+
+// resolveCommentParent finds where a reply to parentCommentID should hang and the
+// depth it gets there.
+func resolveCommentParent(postID, parentCommentID int64) (commentParent, error) {
+	var (
+		parentUserID  int64
+		parentDepth   int
+		parentPostID  int64
+		grandparentID sql.NullInt64
+	)
+	query := `
+		SELECT user_id, depth, post_id, parent_comment_id
+		FROM comments
+		WHERE comment_id = ?
+	`
+	err := database.DB.QueryRow(query, parentCommentID).Scan(&parentUserID, &parentDepth, &parentPostID, &grandparentID)
+	if err == sql.ErrNoRows {
+		return commentParent{}, errCommentNotFound
+	}
+	if err != nil {
+		return commentParent{}, fmt.Errorf("failed to get parent comment: %w", err)
+	}
+	if parentPostID != postID {
+		return commentParent{}, errInvalidParentComment
+	}
+
+	if parentDepth >= maxCommentDepth && grandparentID.Valid {
+		return commentParent{
+			commentID:       grandparentID.Int64,
+			depth:           parentDepth,
+			repliedToUserID: parentUserID,
+		}, nil
+	}
+	return commentParent{
+		commentID:       parentCommentID,
+		depth:           parentDepth + 1,
+		repliedToUserID: parentUserID,
+	}, nil
+}
+
+// getCommentOwners returns the author of commentID and the author of the post it
+// was left on, the two users allowed to delete it.
+func getCommentOwners(commentID int64) (authorID, postAuthorID int64, err error) {
+	query := `
+		SELECT c.user_id, p.user_id
+		FROM comments c
+		JOIN posts p ON p.post_id = c.post_id
+		WHERE c.comment_id = ?
+	`
+	err = database.DB.QueryRow(query, commentID).Scan(&authorID, &postAuthorID)
+	if err == sql.ErrNoRows {
+		return 0, 0, errCommentNotFound
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get comment owners: %w", err)
+	}
+
+	return authorID, postAuthorID, nil
+}
+
+func getCommentAuthorAndPost(commentID int64) (authorID, postID int64, err error) {
+	query := `
+		SELECT user_id, post_id
+		FROM comments
+		WHERE comment_id = ?
+	`
+	err = database.DB.QueryRow(query, commentID).Scan(&authorID, &postID)
+	if err == sql.ErrNoRows {
+		return 0, 0, errCommentNotFound
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return authorID, postID, nil
+}
+
+func statusForCommentError(err error) int {
+	switch {
+	case errors.Is(err, errInvalidParentComment):
+		return http.StatusBadRequest
+	case errors.Is(err, errCommentForbidden):
+		return http.StatusForbidden
+	default:
+		return statusForPostAccessError(err)
+	}
+}
@@ -1,14 +1,21 @@
 package handlers
 
 import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/uploads"
 	"VoizyServer/internal/util"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 )
 
 func CreatePostHandler(w http.ResponseWriter, r *http.Request) {
@@ -17,35 +24,120 @@ func CreatePostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
 	var req models.CreatePostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body.", http.StatusBadRequest)
 		return
 	}
 
-	if req.UserID <= 0 {
-		http.Error(w, "Missing or invalid userID.", http.StatusBadRequest)
+	if req.UserID != 0 && req.UserID != userID {
+		http.Error(w, "Field 'userID' does not match the authenticated user.", http.StatusForbidden)
+		return
+	}
+	req.UserID = userID
+
+	if status, err := validatePostRequest(userID, &req); err != nil {
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	response, err := createPost(req)
+	response, err := createPost(req, postStatusPublished, nil)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Error creating post (%v).", err), http.StatusInternalServerError)
 		return
 	}
 
-	go util.TrackEvent(req.UserID, "create_post", "post", &response.PostID, nil)
-	if req.OriginalPostID != nil {
-		go util.TrackEvent(req.UserID, "share_post", "post", req.OriginalPostID, map[string]interface{}{
-			"shared_post_id": response.PostID,
-		})
-	}
+	go announcePost(publishedPost{
+		postID:         response.PostID,
+		userID:         req.UserID,
+		toUserID:       req.ToUserID,
+		groupID:        req.GroupID,
+		originalPostID: req.OriginalPostID,
+	})
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-func createPost(req models.CreatePostRequest) (models.CreatePostResponse, error) {
+// validatePostRequest checks a new post or draft before it is stored and fills in
+// the poll and audience defaults. On failure the error is the message to send back
+// with the returned status. userID is the signed-in user the post is made as.
+func validatePostRequest(userID int64, req *models.CreatePostRequest) (int, error) {
+	if req.IsPoll {
+		if req.PollDurationType == "" {
+			req.PollDurationType = "days"
+		}
+		if req.PollDurationLength == 0 {
+			req.PollDurationLength = 1
+		}
+		if _, err := pollDurationHours(req.PollDurationType, req.PollDurationLength); err != nil {
+			return http.StatusBadRequest, fmt.Errorf("Invalid poll duration (%v).", err)
+		}
+		if len(req.PollOptions) < 2 {
+			return http.StatusBadRequest, errors.New("A poll needs at least two options.")
+		}
+	}
+
+	if req.GroupID != nil {
+		isMember, err := isGroupMember(*req.GroupID, userID)
+		if err != nil {
+			log.Println("Failed to check group membership due to the following error: ", err)
+			return http.StatusInternalServerError, errors.New("Failed to check group membership.")
+		}
+		if !isMember {
+			return http.StatusForbidden, errors.New("Only members of the group can post in it.")
+		}
+	}
+
+	if req.Audience == "" {
+		req.Audience = audience.Public
+	}
+	if !audience.Valid(req.Audience) {
+		return http.StatusBadRequest, fmt.Errorf("Invalid audience '%s'.", req.Audience)
+	}
+	if req.Audience == audience.Custom {
+		if req.AudienceListID == nil {
+			return http.StatusBadRequest, errors.New("A custom audience needs an audienceListID.")
+		}
+		if err := audience.CheckList(userID, *req.AudienceListID); err != nil {
+			log.Println("Failed to check friend list due to the following error: ", err)
+			return statusForPostAccessError(err), fmt.Errorf("Error creating post (%v).", err)
+		}
+	} else {
+		req.AudienceListID = nil
+	}
+
+	if req.ToUserID > 0 && req.ToUserID != userID {
+		if err := blocks.Check(userID, req.ToUserID); err != nil {
+			log.Println("Failed to check block due to the following error: ", err)
+			return blocks.HTTPStatus(err), fmt.Errorf("Error creating post (%v).", err)
+		}
+	}
+	if req.OriginalPostID != nil {
+		if err := checkPostVisible(*req.OriginalPostID, userID); err != nil {
+			log.Println("Failed to check post access due to the following error: ", err)
+			return statusForPostAccessError(err), fmt.Errorf("Error sharing post (%v).", err)
+		}
+	}
+	media, err := resolvePostMedia(userID, req.Images, req.Media)
+	if err != nil {
+		log.Println("Failed to verify post media due to the following error: ", err)
+		return uploads.HTTPStatus(err), fmt.Errorf("Error creating post (%v).", err)
+	}
+	req.Media = media
+
+	return http.StatusOK, nil
+}
+
+// createPost stores a post with the given status. Drafts and scheduled posts keep
+// their publishAt, and their mentions are only announced once they are published.
+func createPost(req models.CreatePostRequest, status string, publishAt *time.Time) (models.CreatePostResponse, error) {
 	tx, err := database.DB.Begin()
 	if err != nil {
 		log.Println("Error beginning transaction: ", err)
@@ -72,8 +164,20 @@ func createPost(req models.CreatePostRequest) (models.CreatePostResponse, error)
 		}, err
 	}
 
+	if status != postStatusPublished {
+		_, err = tx.Exec(`UPDATE posts SET status = ?, publish_at = ?, updated_at = updated_at WHERE post_id = ?`, status, publishAt, postID)
+		if err != nil {
+			tx.Rollback()
+			log.Println("Failed to set post status: ", err)
+			return models.CreatePostResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to set post status: %v", err),
+			}, err
+		}
+	}
+
 	if req.OriginalPostID != nil {
-		err = insertSharedPost(tx, req.OriginalPostID, req.UserID)
+		err = insertSharedPost(tx, postID, req.OriginalPostID, req.UserID)
 		if err != nil {
 			tx.Rollback()
 			log.Println("Failed to insert post share: ", err)
@@ -96,7 +200,7 @@ func createPost(req models.CreatePostRequest) (models.CreatePostResponse, error)
 		}
 	}
 
-	err = insertPostMedia(tx, postID, req.Images)
+	err = insertPostMedia(tx, postID, req.Media)
 	if err != nil {
 		tx.Rollback()
 		log.Println("Failed to insert post media: ", err)
@@ -116,6 +220,16 @@ func createPost(req models.CreatePostRequest) (models.CreatePostResponse, error)
 		}, err
 	}
 
+	mentions, mentionedUserIDs, err := saveMentions(tx, postID, nil, req.UserID, req.ContentText)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to save mentions: ", err)
+		return models.CreatePostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to save mentions: %v", err),
+		}, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Println("Failed to commit transaction: ", err)
 		return models.CreatePostResponse{
@@ -124,10 +238,14 @@ func createPost(req models.CreatePostRequest) (models.CreatePostResponse, error)
 		}, err
 	}
 
+	go notifyMentioned(mentionedUserIDs, req.UserID, postID, nil)
+	go linkpreview.Refresh(linkpreview.ExtractURLs(req.ContentText))
+
 	return models.CreatePostResponse{
-		Success: true,
-		Message: "Post created successfully",
-		PostID:  postID,
+		Success:  true,
+		Message:  "Post created successfully",
+		PostID:   postID,
+		Mentions: mentions,
 	}, nil
 }
 
@@ -138,6 +256,9 @@ func insertPost(tx *sql.Tx, req models.CreatePostRequest) (int64, error) {
 				INSERT INTO posts (
 					user_id,
 				    to_user_id,
+				    group_id,
+				    audience,
+				    audience_list_id,
 				    original_post_id,
 					content_text,
 					location_name,
@@ -145,11 +266,14 @@ func insertPost(tx *sql.Tx, req models.CreatePostRequest) (int64, error) {
 					location_lng,
 					is_poll
 				)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			`
 			result, err := tx.Exec(query,
 				req.UserID,
 				req.ToUserID,
+				req.GroupID,
+				req.Audience,
+				req.AudienceListID,
 				*req.OriginalPostID,
 				req.ContentText,
 				req.LocationName,
@@ -168,17 +292,23 @@ func insertPost(tx *sql.Tx, req models.CreatePostRequest) (int64, error) {
 			INSERT INTO posts (
 				user_id,
 			    to_user_id,
+			    group_id,
+			    audience,
+			    audience_list_id,
 				content_text,
 				location_name,
 				location_lat,
 				location_lng,
 				is_poll
 			)
-			VALUES (?, ?, ?, ?, ?, ?, ?)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
 		result, err := tx.Exec(query,
 			req.UserID,
 			req.ToUserID,
+			req.GroupID,
+			req.Audience,
+			req.AudienceListID,
 			req.ContentText,
 			req.LocationName,
 			req.LocationLat,
@@ -192,17 +322,25 @@ func insertPost(tx *sql.Tx, req models.CreatePostRequest) (int64, error) {
 		return result.LastInsertId()
 	}
 
+	pollHours, err := pollDurationHours(req.PollDurationType, req.PollDurationLength)
+	if err != nil {
+		return 0, err
+	}
+
 	if req.OriginalPostID != nil {
 		query := `
 			INSERT INTO posts (
-				user_id, to_user_id, original_post_id, content_text, location_name, location_lat, location_lng,
-				is_poll, poll_question, poll_duration_type, poll_duration_length
+				user_id, to_user_id, group_id, audience, audience_list_id, original_post_id, content_text, location_name, location_lat, location_lng,
+				is_poll, poll_question, poll_duration_type, poll_duration_length, poll_end_datetime
 			)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, DATE_ADD(NOW(), INTERVAL ? HOUR))
 		`
 		result, err := tx.Exec(query,
 			req.UserID,
 			req.ToUserID,
+			req.GroupID,
+			req.Audience,
+			req.AudienceListID,
 			*req.OriginalPostID,
 			req.ContentText,
 			req.LocationName,
@@ -212,6 +350,7 @@ func insertPost(tx *sql.Tx, req models.CreatePostRequest) (int64, error) {
 			req.PollQuestion,
 			req.PollDurationType,
 			req.PollDurationLength,
+			pollHours,
 		)
 		if err != nil {
 			log.Println("Error inserting into posts: ", err)
@@ -222,14 +361,17 @@ func insertPost(tx *sql.Tx, req models.CreatePostRequest) (int64, error) {
 
 	query := `
 		INSERT INTO posts (
-			user_id, to_user_id, content_text, location_name, location_lat, location_lng,
-			is_poll, poll_question, poll_duration_type, poll_duration_length
+			user_id, to_user_id, group_id, audience, audience_list_id, content_text, location_name, location_lat, location_lng,
+			is_poll, poll_question, poll_duration_type, poll_duration_length, poll_end_datetime
 		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, DATE_ADD(NOW(), INTERVAL ? HOUR))
 	`
 	result, err := tx.Exec(query,
 		req.UserID,
 		req.ToUserID,
+		req.GroupID,
+		req.Audience,
+		req.AudienceListID,
 		req.ContentText,
 		req.LocationName,
 		req.LocationLat,
@@ -238,6 +380,7 @@ func insertPost(tx *sql.Tx, req models.CreatePostRequest) (int64, error) {
 		req.PollQuestion,
 		req.PollDurationType,
 		req.PollDurationLength,
+		pollHours,
 	)
 	if err != nil {
 		log.Println("Error inserting into posts: ", err)
@@ -253,15 +396,37 @@ func insertPost(tx *sql.Tx, req models.CreatePostRequest) (int64, error) {
 	return postID, nil
 }
 
-func insertSharedPost(tx *sql.Tx, originalPostID *int64, userID int64) error {
+func isGroupMember(groupID, userID int64) (bool, error) {
+	var exists int
+	query := `
+		SELECT 1
+		FROM group_members
+		WHERE group_id = ? AND user_id = ?
+		LIMIT 1
+	`
+	err := database.DB.QueryRow(query, groupID, userID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// insertSharedPost records that postID shares originalPostID and flags postID as a
+// share, so it is still shown as one after the original is purged.
+func insertSharedPost(tx *sql.Tx, postID int64, originalPostID *int64, userID int64) error {
 	query := `
 		INSERT INTO post_shares (
 			post_id,
-			user_id
+			user_id,
+			share_post_id
 		)
-		VALUES (?, ?)
+		VALUES (?, ?, ?)
 	`
-	result, err := tx.Exec(query, *originalPostID, userID)
+	result, err := tx.Exec(query, *originalPostID, userID, postID)
 	if err != nil {
 		log.Println("Error inserting into post_shares: ", err)
 		return err
@@ -272,6 +437,12 @@ func insertSharedPost(tx *sql.Tx, originalPostID *int64, userID int64) error {
 		log.Println("No rows affected in post_shares!")
 	}
 
+	_, err = tx.Exec(`UPDATE posts SET is_share = 1, updated_at = updated_at WHERE post_id = ?`, postID)
+	if err != nil {
+		log.Println("Error flagging post as a share: ", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -302,15 +473,62 @@ func insertPollOptions(tx *sql.Tx, postID int64, options []string) error {
 	return nil
 }
 
-func insertPostMedia(tx *sql.Tx, postID int64, images []string) error {
-	if len(images) == 0 {
-		log.Println("No images were passed to insertPostMedia...")
+// resolvePostMedia checks that images and media are confirmed uploads of userID
+// and returns them as one list, images first, with each item's media type and
+// duration filled in from what was uploaded.
+func resolvePostMedia(userID int64, images []string, media []models.PostMediaItem) ([]models.PostMediaItem, error) {
+	items := make([]models.PostMediaItem, 0, len(images)+len(media))
+	for _, image := range images {
+		if image != "" {
+			items = append(items, models.PostMediaItem{URL: image, MediaType: "image"})
+		}
+	}
+	for _, item := range media {
+		if item.URL != "" {
+			items = append(items, item)
+		}
+	}
+
+	urls := make([]string, len(items))
+	for i, item := range items {
+		urls[i] = item.URL
+	}
+	found, err := uploads.Lookup(userID, uploads.PostMedia, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		item := &items[i]
+		kind := found[item.URL].Kind()
+		if item.MediaType == "" {
+			item.MediaType = kind
+		}
+		if item.MediaType != kind {
+			return nil, fmt.Errorf("%w: %s is not %s", uploads.ErrTypeMismatch, item.URL, item.MediaType)
+		}
+		switch {
+		case kind == "image":
+			item.DurationMS = nil
+		case found[item.URL].DurationMS > 0:
+			durationMS := found[item.URL].DurationMS
+			item.DurationMS = &durationMS
+		case item.DurationMS != nil && *item.DurationMS <= 0:
+			item.DurationMS = nil
+		}
+	}
+	return items, nil
+}
+
+func insertPostMedia(tx *sql.Tx, postID int64, media []models.PostMediaItem) error {
+	if len(media) == 0 {
+		log.Println("No media was passed to insertPostMedia...")
 		return nil
 	}
 
 	query := `
-		INSERT INTO post_media (post_id, media_url, media_type)
-		VALUES (?, ?, 'image')
+		INSERT INTO post_media (post_id, media_url, media_type, duration_ms)
+		VALUES (?, ?, ?, ?)
 	`
 	stmt, err := tx.Prepare(query)
 	if err != nil {
@@ -319,8 +537,8 @@ func insertPostMedia(tx *sql.Tx, postID int64, images []string) error {
 	}
 	defer stmt.Close()
 
-	for _, imgURL := range images {
-		_, err = stmt.Exec(postID, imgURL)
+	for _, item := range media {
+		_, err = stmt.Exec(postID, item.URL, item.MediaType, item.DurationMS)
 		if err != nil {
 			log.Println("Error executing insert into post_media: ", err)
 			return err
@@ -369,12 +587,7 @@ func insertPostHashtags(tx *sql.Tx, postID int64, tags []string) error {
 	}
 	defer postHashtagStmt.Close()
 
-	for _, t := range tags {
-		cleanedTag := t
-		if len(cleanedTag) > 0 && cleanedTag[0] == '#' {
-			cleanedTag = cleanedTag[1:]
-		}
-
+	for _, cleanedTag := range util.NormalizeHashtags(tags) {
 		_, err = insertTagStmt.Exec(cleanedTag)
 		if err != nil {
 			log.Println("Error executing upsertTag: ", err)
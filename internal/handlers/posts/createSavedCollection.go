@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func CreateSavedCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateSavedCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := createSavedCollection(userID, req)
+	if err != nil {
+		log.Println("Failed to create collection due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to create collection (%v).", err), statusForSavedError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "create_saved_collection", "saved_collection", &response.CollectionID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// createSavedCollection adds a collection after the user's existing ones.
+func createSavedCollection(userID int64, req models.CreateSavedCollectionRequest) (models.CreateSavedCollectionResponse, error) {
+	name, err := normalizeCollectionName(req.Name)
+	if err != nil {
+		return models.CreateSavedCollectionResponse{Success: false, Message: err.Error()}, err
+	}
+	if err := checkCollectionNameFree(userID, name, 0); err != nil {
+		return models.CreateSavedCollectionResponse{Success: false, Message: err.Error()}, err
+	}
+
+	var position int64
+	err = database.DB.QueryRow(`SELECT COALESCE(MAX(position) + 1, 0) FROM saved_collections WHERE user_id = ?`, userID).Scan(&position)
+	if err != nil {
+		return models.CreateSavedCollectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get collection position: %v", err),
+		}, err
+	}
+
+	result, err := database.DB.Exec(`
+		INSERT INTO saved_collections (user_id, name, position)
+		VALUES (?, ?, ?)
+	`, userID, name, position)
+	if err != nil {
+		return models.CreateSavedCollectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to insert collection: %v", err),
+		}, err
+	}
+	collectionID, err := result.LastInsertId()
+	if err != nil {
+		return models.CreateSavedCollectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get collection id: %v", err),
+		}, err
+	}
+
+	return models.CreateSavedCollectionResponse{
+		Success:      true,
+		Message:      "Successfully created collection.",
+		CollectionID: collectionID,
+		Position:     position,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func DeleteCommentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	commentIDString := r.URL.Query().Get("id")
+	if commentIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	commentID, err := strconv.ParseInt(commentIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse commentIDString (string) to commentID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := deleteComment(userID, commentID)
+	if err != nil {
+		log.Println("Failed to delete comment due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to delete comment (%v).", err), statusForCommentError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "delete_comment", "comment", &commentID, map[string]interface{}{
+		"comments_deleted": response.CommentsDeleted,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// deleteComment removes a comment along with every reply below it. The comment's
+// author and the author of the post it is on may delete it.
+func deleteComment(userID, commentID int64) (models.DeleteCommentResponse, error) {
+	authorID, postAuthorID, err := getCommentOwners(commentID)
+	if err != nil {
+		return models.DeleteCommentResponse{Success: false, Message: err.Error()}, err
+	}
+	if userID != authorID && userID != postAuthorID {
+		return models.DeleteCommentResponse{Success: false, Message: errCommentForbidden.Error()}, errCommentForbidden
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.DeleteCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// Replies nest at most maxCommentDepth levels, so deleting grandchildren, then
+	// children, then the comment clears the whole thread without relying on the
+	// parent_comment_id foreign key, which older schemas do not have.
+	queries := []string{
+		`DELETE r FROM comments r
+		JOIN comments c ON c.comment_id = r.parent_comment_id
+		WHERE c.parent_comment_id = ?`,
+		`DELETE FROM comments WHERE parent_comment_id = ?`,
+		`DELETE FROM comments WHERE comment_id = ?`,
+	}
+	var commentsDeleted int64
+	for _, query := range queries {
+		result, err := tx.Exec(query, commentID)
+		if err != nil {
+			tx.Rollback()
+			return models.DeleteCommentResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to delete comment due to the following error: %v", err),
+			}, err
+		}
+		rowsAffected, _ := result.RowsAffected()
+		commentsDeleted += rowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.DeleteCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.DeleteCommentResponse{
+		Success:         true,
+		Message:         "Successfully deleted comment.",
+		CommentsDeleted: commentsDeleted,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func DeletePinnedPostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	postIDString := r.URL.Query().Get("id")
+	if postIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	postID, err := strconv.ParseInt(postIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse postIDString (string) to postID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := deletePinnedPost(userID, postID)
+	if err != nil {
+		log.Println("Failed to unpin post due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to unpin post (%v).", err), statusForPinError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "unpin_post", "post", &postID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// deletePinnedPost unpins one of the user's pinned posts. Only pins on the user's
+// own profile can be removed, and pins are only ever made on the user's own posts.
+func deletePinnedPost(userID, postID int64) (models.DeletePinnedPostResponse, error) {
+	result, err := database.DB.Exec(`DELETE FROM pinned_posts WHERE user_id = ? AND post_id = ?`, userID, postID)
+	if err != nil {
+		return models.DeletePinnedPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to unpin post: %v", err),
+			PostID:  postID,
+		}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return models.DeletePinnedPostResponse{Success: false, Message: errPostNotPinned.Error(), PostID: postID}, errPostNotPinned
+	}
+
+	return models.DeletePinnedPostResponse{
+		Success: true,
+		Message: "Successfully unpinned post.",
+		PostID:  postID,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func DeletePollVoteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.DeletePollVoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.PostID <= 0 {
+		http.Error(w, "Missing required field 'postID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := deletePollVote(userID, req.PostID)
+	if err != nil {
+		log.Println("Failed to retract poll vote due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to retract poll vote (%v).", err), statusForPollError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "retract_poll_vote", "post", &req.PostID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func deletePollVote(userID, postID int64) (models.DeletePollVoteResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.DeletePollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := lockOpenPoll(tx, postID); err != nil {
+		tx.Rollback()
+		return models.DeletePollVoteResponse{Success: false, Message: err.Error()}, err
+	}
+
+	var existingOptionID int64
+	err = tx.QueryRow(`
+		SELECT poll_option_id
+		FROM poll_votes
+		WHERE post_id = ? AND user_id = ?
+		FOR UPDATE
+	`, postID, userID).Scan(&existingOptionID)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return models.DeletePollVoteResponse{Success: false, Message: errPollVoteNotFound.Error()}, errPollVoteNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.DeletePollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to check existing vote: %v", err),
+		}, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM poll_votes WHERE post_id = ? AND user_id = ?`, postID, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.DeletePollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to delete poll vote: %v", err),
+		}, err
+	}
+	if err := adjustPollOptionVoteCount(tx, existingOptionID, -1); err != nil {
+		tx.Rollback()
+		return models.DeletePollVoteResponse{Success: false, Message: err.Error()}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.DeletePollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.DeletePollVoteResponse{
+		Success: true,
+		Message: "Vote retracted.",
+	}, nil
+}
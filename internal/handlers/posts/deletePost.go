@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func DeletePostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	postIDString := r.URL.Query().Get("id")
+	if postIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	postID, err := strconv.ParseInt(postIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse postIDString (string) to postID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := deletePost(userID, postID)
+	if err != nil {
+		log.Println("Failed to delete post due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to delete post (%v).", err), statusForTrashError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "delete_post", "post", &postID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// deletePost moves a post to its author's trash. The post disappears from every
+// listing straight away and is purged once trashRetentionDays have passed unless
+// the author restores it first.
+func deletePost(userID, postID int64) (models.DeletePostResponse, error) {
+	authorID, deleted, err := getPostDeletionState(postID)
+	if err != nil {
+		return models.DeletePostResponse{Success: false, Message: err.Error(), PostID: postID}, err
+	}
+	if authorID != userID {
+		return models.DeletePostResponse{Success: false, Message: errPostForbidden.Error(), PostID: postID}, errPostForbidden
+	}
+
+	if !deleted {
+		_, err = database.DB.Exec(`
+			UPDATE posts
+			SET deleted_at = NOW(), updated_at = updated_at
+			WHERE post_id = ? AND deleted_at IS NULL
+		`, postID)
+		if err != nil {
+			return models.DeletePostResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to delete post due to the following error: %v", err),
+				PostID:  postID,
+			}, err
+		}
+	}
+
+	// Restoring the post does not pin it again; this also runs on a repeated delete in
+	// case the first one failed before the pin was removed.
+	_, err = database.DB.Exec(`DELETE FROM pinned_posts WHERE post_id = ?`, postID)
+	if err != nil {
+		return models.DeletePostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to unpin post due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	var deletedAt, purgeAt time.Time
+	err = database.DB.QueryRow(`
+		SELECT deleted_at, DATE_ADD(deleted_at, INTERVAL ? DAY)
+		FROM posts
+		WHERE post_id = ?
+	`, trashRetentionDays, postID).Scan(&deletedAt, &purgeAt)
+	if err != nil {
+		return models.DeletePostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get deleted post due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	return models.DeletePostResponse{
+		Success:   true,
+		Message:   "Successfully moved post to trash.",
+		PostID:    postID,
+		DeletedAt: &deletedAt,
+		PurgeAt:   &purgeAt,
+	}, nil
+}
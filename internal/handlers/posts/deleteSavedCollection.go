@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func DeleteSavedCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	collectionIDString := r.URL.Query().Get("id")
+	if collectionIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	collectionID, err := strconv.ParseInt(collectionIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse collectionIDString (string) to collectionID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := deleteSavedCollection(userID, collectionID)
+	if err != nil {
+		log.Println("Failed to delete collection due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to delete collection (%v).", err), statusForSavedError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "delete_saved_collection", "saved_collection", &collectionID, map[string]interface{}{
+		"posts_moved": response.PostsMoved,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// deleteSavedCollection removes one of the user's collections. The posts in it stay
+// saved, just no longer filed in a collection.
+func deleteSavedCollection(userID, collectionID int64) (models.DeleteSavedCollectionResponse, error) {
+	if err := checkCollection(userID, collectionID); err != nil {
+		return models.DeleteSavedCollectionResponse{Success: false, Message: err.Error()}, err
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.DeleteSavedCollectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.Exec(`UPDATE saved_posts SET collection_id = NULL WHERE collection_id = ?`, collectionID)
+	if err != nil {
+		tx.Rollback()
+		return models.DeleteSavedCollectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to move saved posts: %v", err),
+		}, err
+	}
+	postsMoved, _ := result.RowsAffected()
+
+	if _, err := tx.Exec(`DELETE FROM saved_collections WHERE collection_id = ?`, collectionID); err != nil {
+		tx.Rollback()
+		return models.DeleteSavedCollectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to delete collection: %v", err),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.DeleteSavedCollectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.DeleteSavedCollectionResponse{
+		Success:    true,
+		Message:    "Successfully deleted collection.",
+		PostsMoved: postsMoved,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func DeleteSavedPostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	postIDString := r.URL.Query().Get("id")
+	if postIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	postID, err := strconv.ParseInt(postIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse postIDString (string) to postID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := deleteSavedPost(userID, postID)
+	if err != nil {
+		log.Println("Failed to unsave post due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to unsave post (%v).", err), statusForSavedError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "unsave_post", "post", &postID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// deleteSavedPost removes a post from the user's saved posts, whichever collection
+// it was filed in. It works even when the post is no longer visible to the user.
+func deleteSavedPost(userID, postID int64) (models.DeleteSavedPostResponse, error) {
+	result, err := database.DB.Exec(`DELETE FROM saved_posts WHERE user_id = ? AND post_id = ?`, userID, postID)
+	if err != nil {
+		return models.DeleteSavedPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to unsave post: %v", err),
+			PostID:  postID,
+		}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return models.DeleteSavedPostResponse{Success: false, Message: errPostNotSaved.Error(), PostID: postID}, errPostNotSaved
+	}
+
+	return models.DeleteSavedPostResponse{
+		Success: true,
+		Message: "Successfully unsaved post.",
+		PostID:  postID,
+	}, nil
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/notifications"
+	"VoizyServer/internal/uploads"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+const (
+	postStatusPublished = "published"
+	postStatusDraft     = "draft"
+	postStatusScheduled = "scheduled"
+)
+
+var (
+	errPostPublished    = errors.New("post is already published")
+	errPublishAtInPast  = errors.New("publishAt must be in the future")
+	errInvalidPublishAt = errors.New("publishAt must be an RFC 3339 timestamp")
+	errDraftCannotShare = errors.New("shares cannot be saved as drafts")
+)
+
+// checkDraft fails unless postID is a draft or scheduled post written by userID.
+// Drafts in the trash are treated as missing.
+func checkDraft(postID, userID int64) error {
+	var (
+		authorID int64
+		status   string
+	)
+	err := database.DB.QueryRow(`SELECT user_id, status FROM posts WHERE post_id = ? AND deleted_at IS NULL`, postID).Scan(&authorID, &status)
+	if err == sql.ErrNoRows {
+		return errPostNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get draft: %w", err)
+	}
+	if authorID != userID {
+		return errPostForbidden
+	}
+	if status == postStatusPublished {
+		return errPostPublished
+	}
+
+	return nil
+}
+
+func statusForDraftError(err error) int {
+	switch {
+	case errors.Is(err, errPostNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errPostForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, errPostPublished):
+		return http.StatusConflict
+	case errors.Is(err, errPublishAtInPast), errors.Is(err, errInvalidPublishAt), errors.Is(err, errDraftCannotShare):
+		return http.StatusBadRequest
+	}
+	return uploads.HTTPStatus(err)
+}
+
+// publishPost moves a draft or scheduled post into the feeds, dated from now, and
+// restarts a poll's clock. The update is conditional, so when the author and the
+// scheduler (or schedulers on several API nodes) race, only the caller that actually
+// published the post announces it. It reports whether this call published it.
+func publishPost(postID int64) (bool, error) {
+	result, err := database.DB.Exec(`
+		UPDATE posts
+		SET status = 'published',
+			created_at = NOW(),
+			poll_end_datetime = IF(is_poll = 1, CASE poll_duration_type
+				WHEN 'hours' THEN DATE_ADD(NOW(), INTERVAL COALESCE(poll_duration_length, 1) HOUR)
+				WHEN 'weeks' THEN DATE_ADD(NOW(), INTERVAL COALESCE(poll_duration_length, 1) WEEK)
+				ELSE DATE_ADD(NOW(), INTERVAL COALESCE(poll_duration_length, 1) DAY)
+			END, poll_end_datetime)
+		WHERE post_id = ? AND status IN ('draft', 'scheduled') AND deleted_at IS NULL
+	`, postID)
+	if err != nil {
+		return false, fmt.Errorf("failed to publish post %d: %w", postID, err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	p := publishedPost{postID: postID}
+	var (
+		groupID        sql.NullInt64
+		originalPostID sql.NullInt64
+	)
+	err = database.DB.QueryRow(`
+		SELECT user_id, to_user_id, group_id, original_post_id
+		FROM posts
+		WHERE post_id = ?
+	`, postID).Scan(&p.userID, &p.toUserID, &groupID, &originalPostID)
+	if err != nil {
+		return true, fmt.Errorf("failed to load published post %d: %w", postID, err)
+	}
+	p.groupID = util.SqlNullInt64ToPtr(groupID)
+	p.originalPostID = util.SqlNullInt64ToPtr(originalPostID)
+
+	announcePost(p)
+
+	mentionedUserIDs, err := getMentionedUserIDs(postID)
+	if err != nil {
+		return true, err
+	}
+	notifyMentioned(mentionedUserIDs, p.userID, postID, nil)
+
+	return true, nil
+}
+
+// publishedPost is what announcePost needs to know about a post that just went live.
+type publishedPost struct {
+	postID         int64
+	userID         int64
+	toUserID       int64
+	groupID        *int64
+	originalPostID *int64
+}
+
+// announcePost fires the side effects of a post going live: the create_post event,
+// a notification for the owner of the wall it was posted on and, for shares, the
+// share_post event.
+func announcePost(p publishedPost) {
+	var metadata map[string]interface{}
+	if p.groupID != nil {
+		metadata = map[string]interface{}{
+			"group_id": *p.groupID,
+		}
+	}
+	if err := util.TrackEvent(p.userID, "create_post", "post", &p.postID, metadata); err != nil {
+		log.Println("Failed to track create_post due to the following error: ", err)
+	}
+	if p.toUserID > 0 && p.toUserID != p.userID {
+		notifications.Send(notifications.Notification{
+			RecipientID: p.toUserID,
+			ActorID:     p.userID,
+			Type:        notifications.TypeWallPost,
+			ObjectType:  "post",
+			ObjectID:    p.postID,
+		})
+	}
+	if p.originalPostID != nil {
+		err := util.TrackEvent(p.userID, "share_post", "post", p.originalPostID, map[string]interface{}{
+			"shared_post_id": p.postID,
+		})
+		if err != nil {
+			log.Println("Failed to track share_post due to the following error: ", err)
+		}
+	}
+}
+
+// getMentionedUserIDs returns the users mentioned in a post's own text.
+func getMentionedUserIDs(postID int64) ([]int64, error) {
+	rows, err := database.DB.Query(`
+		SELECT mentioned_user_id
+		FROM mentions
+		WHERE post_id = ? AND comment_id IS NULL
+	`, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mentions of post %d: %w", postID, err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		userIDs = append(userIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return userIDs, nil
+}
@@ -1,8 +1,15 @@
 package handlers
 
 import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
 	"VoizyServer/internal/util"
 	"database/sql"
 	"encoding/json"
@@ -18,16 +25,18 @@ func GetFriendFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userIDStr := r.URL.Query().Get("id")
-	limitStr := r.URL.Query().Get("limit")
-
-	userID, err := strconv.ParseInt(userIDStr, 10, 64)
-	if err != nil {
-		log.Println("Failed to convert userIDString (string) to userID (in64): ", err)
-		http.Error(w, "Failed to convert param 'id'.", http.StatusInternalServerError)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if userIDString := r.URL.Query().Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
 		return
 	}
 
+	limitStr := r.URL.Query().Get("limit")
+
 	limit, err := strconv.ParseInt(limitStr, 10, 64)
 	if err != nil {
 		log.Println("Failed to convert limitString (string) to limit (int64): ", err)
@@ -48,6 +57,8 @@ func GetFriendFeed(w http.ResponseWriter, r *http.Request) {
 
 func getFriendFeed(userID, limit, page int64) (models.GetFriendFeedResponse, error) {
 	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("p.user_id", userID)
+	visible, visibleArgs := audience.Visible("p", userID)
 
 	query := `
 		SELECT
@@ -67,6 +78,7 @@ func getFriendFeed(userID, limit, page int64) (models.GetFriendFeedResponse, err
 			p.poll_question,
 			p.poll_duration_type,
 			p.poll_duration_length,
+			p.edited_at,
 			u.username,
 			up.first_name,
 			up.last_name,
@@ -90,10 +102,19 @@ func getFriendFeed(userID, limit, page int64) (models.GetFriendFeedResponse, err
 		LEFT JOIN user_profiles up ON p.user_id = up.user_id
 		LEFT JOIN user_images ui ON p.user_id = ui.user_id
 		LEFT JOIN post_reactions pr_user ON p.post_id = pr_user.post_id AND pr_user.user_id = ?
+		WHERE p.group_id IS NULL
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible + `
 		ORDER BY p.created_at DESC
 		LIMIT ? OFFSET ?
 	`
-	rows, err := database.DB.Query(query, userID, userID, userID, limit, offset)
+	args := []interface{}{userID, userID, userID}
+	args = append(args, blockArgs...)
+	args = append(args, visibleArgs...)
+	args = append(args, limit, offset)
+	rows, err := database.DB.Query(query, args...)
 	if err != nil {
 		return models.GetFriendFeedResponse{}, fmt.Errorf("failed to execute query for friend posts: %v", err)
 	}
@@ -114,6 +135,7 @@ func getFriendFeed(userID, limit, page int64) (models.GetFriendFeedResponse, err
 			pollQuestion       sql.NullString
 			pollDurationType   sql.NullString
 			pollDurationLength sql.NullInt64
+			editedAt           sql.NullTime
 			username           sql.NullString
 			firstName          sql.NullString
 			lastName           sql.NullString
@@ -142,6 +164,7 @@ func getFriendFeed(userID, limit, page int64) (models.GetFriendFeedResponse, err
 			&pollQuestion,
 			&pollDurationType,
 			&pollDurationLength,
+			&editedAt,
 			&username,
 			&firstName,
 			&lastName,
@@ -167,6 +190,8 @@ func getFriendFeed(userID, limit, page int64) (models.GetFriendFeedResponse, err
 		p.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
 		p.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
 		p.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
+		p.Edited = editedAt.Valid
+		p.EditedAt = util.SqlNullTimeToPtr(editedAt)
 		p.Username = util.SqlNullStringToPtr(username)
 		p.FirstName = util.SqlNullStringToPtr(firstName)
 		p.LastName = util.SqlNullStringToPtr(lastName)
@@ -182,6 +207,45 @@ func getFriendFeed(userID, limit, page int64) (models.GetFriendFeedResponse, err
 		return models.GetFriendFeedResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
 	}
 
+	postIDs := make([]int64, len(friendPosts))
+	for i, p := range friendPosts {
+		postIDs[i] = p.PostID
+	}
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return models.GetFriendFeedResponse{}, err
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.GetFriendFeedResponse{}, err
+	}
+	texts := make(map[int64]string, len(friendPosts))
+	for _, p := range friendPosts {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.GetFriendFeedResponse{}, err
+	}
+	profilePics := make([]string, 0, len(friendPosts))
+	for _, p := range friendPosts {
+		if p.ProfilePicURL != nil {
+			profilePics = append(profilePics, *p.ProfilePicURL)
+		}
+	}
+	profilePicImages, err := imaging.ForURLs(profilePics)
+	if err != nil {
+		return models.GetFriendFeedResponse{}, err
+	}
+	for i := range friendPosts {
+		friendPosts[i].ReactionCounts = reactionCounts[friendPosts[i].PostID]
+		friendPosts[i].OriginalPost = originals[friendPosts[i].PostID]
+		friendPosts[i].LinkPreviews = linkPreviews[friendPosts[i].PostID]
+		friendPosts[i].ProfilePicVariants = imaging.Variants(profilePicImages, friendPosts[i].ProfilePicURL)
+	}
+
 	return models.GetFriendFeedResponse{
 		FriendPosts: friendPosts,
 		Limit:       limit,
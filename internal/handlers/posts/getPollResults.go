@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func GetPollResultsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	postIDString := r.URL.Query().Get("id")
+	if postIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	postID, err := strconv.ParseInt(postIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse postIDString (string) to postID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := checkPostVisible(postID, userID); err != nil {
+		log.Println("Failed to get poll results due to the following error: ", err)
+		http.Error(w, "Failed to get poll results.", statusForPostAccessError(err))
+		return
+	}
+
+	response, err := getPollResults(postID, userID)
+	if err != nil {
+		log.Println("Failed to get poll results due to the following error: ", err)
+		http.Error(w, "Failed to get poll results.", statusForPollError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func getPollResults(postID, userID int64) (models.GetPollResultsResponse, error) {
+	var (
+		isPoll          bool
+		pollQuestion    sql.NullString
+		pollEndDatetime sql.NullTime
+		isClosed        bool
+	)
+	pollQuery := `
+		SELECT
+			is_poll,
+			poll_question,
+			poll_end_datetime,
+			(poll_closed = 1 OR (poll_end_datetime IS NOT NULL AND poll_end_datetime <= NOW())) AS is_closed
+		FROM posts
+		WHERE post_id = ?
+	`
+	err := database.DB.QueryRow(pollQuery, postID).Scan(&isPoll, &pollQuestion, &pollEndDatetime, &isClosed)
+	if err == sql.ErrNoRows || (err == nil && !isPoll) {
+		return models.GetPollResultsResponse{}, errPollNotFound
+	}
+	if err != nil {
+		return models.GetPollResultsResponse{}, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	optionsQuery := `
+		SELECT
+			po.poll_option_id,
+			po.option_text,
+			COUNT(pv.poll_vote_id) AS vote_count
+		FROM poll_options po
+		LEFT JOIN poll_votes pv ON pv.poll_option_id = po.poll_option_id
+		WHERE po.post_id = ?
+		GROUP BY po.poll_option_id, po.option_text
+		ORDER BY po.poll_option_id ASC
+	`
+	rows, err := database.DB.Query(optionsQuery, postID)
+	if err != nil {
+		return models.GetPollResultsResponse{}, fmt.Errorf("failed to select poll options: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		options    []models.PollOptionResult
+		totalVotes int64
+	)
+	for rows.Next() {
+		var o models.PollOptionResult
+		if err := rows.Scan(&o.PollOptionID, &o.OptionText, &o.VoteCount); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		totalVotes += o.VoteCount
+		options = append(options, o)
+	}
+	if err := rows.Err(); err != nil {
+		return models.GetPollResultsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	if totalVotes > 0 {
+		for i := range options {
+			options[i].Percentage = math.Round(float64(options[i].VoteCount)/float64(totalVotes)*10000) / 100
+		}
+	}
+
+	var userPollOptionID sql.NullInt64
+	userVoteQuery := `
+		SELECT poll_option_id
+		FROM poll_votes
+		WHERE post_id = ? AND user_id = ?
+	`
+	err = database.DB.QueryRow(userVoteQuery, postID, userID).Scan(&userPollOptionID)
+	if err != nil && err != sql.ErrNoRows {
+		return models.GetPollResultsResponse{}, fmt.Errorf("failed to get user vote: %w", err)
+	}
+
+	return models.GetPollResultsResponse{
+		PostID:           postID,
+		PollQuestion:     util.SqlNullStringToPtr(pollQuestion),
+		PollEndDatetime:  util.SqlNullTimeToPtr(pollEndDatetime),
+		IsClosed:         isClosed,
+		TotalVotes:       totalVotes,
+		UserPollOptionID: util.SqlNullInt64ToPtr(userPollOptionID),
+		Options:          options,
+	}, nil
+}
@@ -1,8 +1,15 @@
 package handlers
 
 import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
 	"VoizyServer/internal/util"
 	"database/sql"
 	"encoding/json"
@@ -21,7 +28,16 @@ func GetPopularPosts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userIDStr := r.URL.Query().Get("id")
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if userIDString := r.URL.Query().Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
+		return
+	}
+
 	limitStr := r.URL.Query().Get("limit")
 	daysStr := r.URL.Query().Get("days")
 
@@ -32,13 +48,6 @@ func GetPopularPosts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID, err := strconv.ParseInt(userIDStr, 10, 64)
-	if err != nil {
-		log.Println("Failed to convert userIdStr (string) to userID (int64): ", err)
-		http.Error(w, "Failed to convert param 'id'.", http.StatusInternalServerError)
-		return
-	}
-
 	limit, err := strconv.ParseInt(limitStr, 10, 64)
 	if err != nil {
 		log.Println("Failed to convert limitString (string) to limit (int64): ", err)
@@ -121,6 +130,10 @@ func getPopularPostsInfo(popularPosts []int64, userID, limit, days, page int64)
 		placeholders[i] = "?"
 		args[i+1] = id
 	}
+	notBlocked, blockArgs := blocks.Exclude("p.user_id", userID)
+	visible, visibleArgs := audience.Visible("p", userID)
+	args = append(args, blockArgs...)
+	args = append(args, visibleArgs...)
 	args = append(args, limit, offset)
 
 	query := `
@@ -141,6 +154,7 @@ func getPopularPostsInfo(popularPosts []int64, userID, limit, days, page int64)
 			p.poll_question,
 			p.poll_duration_type,
 			p.poll_duration_length,
+			p.edited_at,
 			u.username,
 			up.first_name,
 			up.last_name,
@@ -156,6 +170,11 @@ func getPopularPostsInfo(popularPosts []int64, userID, limit, days, page int64)
 		LEFT JOIN user_images ui ON p.user_id = ui.user_id
 		LEFT JOIN post_reactions pr_user ON p.post_id = pr_user.post_id AND pr_user.user_id = ?
 		WHERE p.post_id IN (` + strings.Join(placeholders, ",") + `)
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND p.group_id IS NULL
+			AND ` + notBlocked + `
+			AND ` + visible + `
 		ORDER BY views DESC
 		LIMIT ? OFFSET ?
 	`
@@ -181,6 +200,7 @@ func getPopularPostsInfo(popularPosts []int64, userID, limit, days, page int64)
 			pollQuestion       sql.NullString
 			pollDurationType   sql.NullString
 			pollDurationLength sql.NullInt64
+			editedAt           sql.NullTime
 			username           sql.NullString
 			firstName          sql.NullString
 			lastName           sql.NullString
@@ -209,6 +229,7 @@ func getPopularPostsInfo(popularPosts []int64, userID, limit, days, page int64)
 			&pollQuestion,
 			&pollDurationType,
 			&pollDurationLength,
+			&editedAt,
 			&username,
 			&firstName,
 			&lastName,
@@ -234,6 +255,8 @@ func getPopularPostsInfo(popularPosts []int64, userID, limit, days, page int64)
 		p.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
 		p.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
 		p.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
+		p.Edited = editedAt.Valid
+		p.EditedAt = util.SqlNullTimeToPtr(editedAt)
 		p.Username = util.SqlNullStringToPtr(username)
 		p.FirstName = util.SqlNullStringToPtr(firstName)
 		p.LastName = util.SqlNullStringToPtr(lastName)
@@ -250,6 +273,45 @@ func getPopularPostsInfo(popularPosts []int64, userID, limit, days, page int64)
 		return models.GetPopularPostsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
 	}
 
+	postIDs := make([]int64, len(popularPostsList))
+	for i, p := range popularPostsList {
+		postIDs[i] = p.PostID
+	}
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return models.GetPopularPostsResponse{}, err
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.GetPopularPostsResponse{}, err
+	}
+	texts := make(map[int64]string, len(popularPostsList))
+	for _, p := range popularPostsList {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.GetPopularPostsResponse{}, err
+	}
+	profilePics := make([]string, 0, len(popularPostsList))
+	for _, p := range popularPostsList {
+		if p.ProfilePicURL != nil {
+			profilePics = append(profilePics, *p.ProfilePicURL)
+		}
+	}
+	profilePicImages, err := imaging.ForURLs(profilePics)
+	if err != nil {
+		return models.GetPopularPostsResponse{}, err
+	}
+	for i := range popularPostsList {
+		popularPostsList[i].ReactionCounts = reactionCounts[popularPostsList[i].PostID]
+		popularPostsList[i].OriginalPost = originals[popularPostsList[i].PostID]
+		popularPostsList[i].LinkPreviews = linkPreviews[popularPostsList[i].PostID]
+		popularPostsList[i].ProfilePicVariants = imaging.Variants(profilePicImages, popularPostsList[i].ProfilePicURL)
+	}
+
 	return models.GetPopularPostsResponse{
 		PopularPosts: popularPostsList,
 		Limit:        limit,
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
 	"encoding/json"
 	"fmt"
@@ -28,7 +30,18 @@ func GetPostDetailsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := getPostDetails(postID)
+	viewerID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if err := checkPostVisible(postID, viewerID); err != nil {
+		log.Println("Failed to get post details due to the following error: ", err)
+		http.Error(w, "Failed to get post details.", statusForPostAccessError(err))
+		return
+	}
+
+	response, err := getPostDetails(postID, viewerID)
 	if err != nil {
 		log.Println("Failed to get post details due to the following error: ", err)
 		http.Error(w, "Failed to get post details.", http.StatusInternalServerError)
@@ -39,14 +52,15 @@ func GetPostDetailsHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-func getPostDetails(postID int64) (models.GetPostDetailsResponse, error) {
+func getPostDetails(postID, viewerID int64) (models.GetPostDetailsResponse, error) {
 	var reactions []models.Reaction
+	notBlocked, blockArgs := blocks.Exclude("post_reactions.user_id", viewerID)
 	queryReactions := `
 		SELECT post_reaction_id, post_id, user_id, reaction_type, reacted_at
 		FROM post_reactions
 		WHERE post_id = ?
-	`
-	rows, err := database.DB.Query(queryReactions, postID)
+			AND ` + notBlocked
+	rows, err := database.DB.Query(queryReactions, append([]interface{}{postID}, blockArgs...)...)
 	if err != nil {
 		return models.GetPostDetailsResponse{}, fmt.Errorf("failed to get post reactions: %w", err)
 	}
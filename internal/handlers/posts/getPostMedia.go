@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -28,6 +31,17 @@ func GetPostMediaHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	viewerID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if err := checkPostVisible(postID, viewerID); err != nil {
+		log.Println("Failed to get post media due to the following error: ", err)
+		http.Error(w, "Failed to get post media.", statusForPostAccessError(err))
+		return
+	}
+
 	response, err := getPostMedia(postID)
 	if err != nil {
 		log.Println("Failed to get post media due to the following error: ", err)
@@ -40,58 +54,60 @@ func GetPostMediaHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func getPostMedia(postID int64) (models.GetMediaResponse, error) {
-	var images []string
-	queryImages := `
-		SELECT media_url
+	rows, err := database.DB.Query(`
+		SELECT media_url, media_type, duration_ms
 		FROM post_media
 		WHERE post_id = ?
-		AND media_type = 'image'
-	`
-	rows, err := database.DB.Query(queryImages, postID)
+		ORDER BY media_id ASC
+	`, postID)
 	if err != nil {
 		return models.GetMediaResponse{}, err
 	}
+	defer rows.Close()
+
+	images, videos, audio := []string{}, []string{}, []string{}
+	media := []models.PostMediaItem{}
 	for rows.Next() {
-		var i string
-		err := rows.Scan(&i)
+		var (
+			item       models.PostMediaItem
+			durationMS sql.NullInt64
+		)
+		err := rows.Scan(&item.URL, &item.MediaType, &durationMS)
 		if err != nil {
 			log.Println("Scan rows error: ", err)
 			continue
 		}
-		images = append(images, i)
+		if durationMS.Valid {
+			item.DurationMS = &durationMS.Int64
+		}
+		switch item.MediaType {
+		case "image":
+			images = append(images, item.URL)
+		case "video":
+			videos = append(videos, item.URL)
+		case "audio":
+			audio = append(audio, item.URL)
+		}
+		media = append(media, item)
 	}
 	if err = rows.Err(); err != nil {
 		return models.GetMediaResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
 	}
 
-	var videos []string
-	queryVideos := `
-		SELECT media_url
-		FROM post_media
-		WHERE post_id = ?
-		AND media_type = 'video'
-	`
-	rows, err = database.DB.Query(queryVideos, postID)
+	processed, err := imaging.ForURLs(images)
 	if err != nil {
 		return models.GetMediaResponse{}, err
 	}
-	for rows.Next() {
-		var v string
-		err := rows.Scan(&v)
-		if err != nil {
-			log.Println("Scan rows error: ", err)
-			continue
-		}
-		videos = append(videos, v)
-	}
-	if err = rows.Err(); err != nil {
-		return models.GetMediaResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	imageSources := make([]models.Image, 0, len(images))
+	for _, i := range images {
+		imageSources = append(imageSources, processed[i])
 	}
 
-	// Returning an empty array for videos for now, as I have not implemented that aspect yet and there won't be any videos
-	videos = []string{}
 	return models.GetMediaResponse{
-		Images: images,
-		Videos: videos,
+		Images:       images,
+		ImageSources: imageSources,
+		Videos:       videos,
+		Audio:        audio,
+		Media:        media,
 	}, nil
 }
@@ -1,8 +1,15 @@
 package handlers
 
 import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
 	"VoizyServer/internal/util"
 	"database/sql"
 	"encoding/json"
@@ -21,24 +28,26 @@ func GetRecommendedFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userIDStr := r.URL.Query().Get("id")
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if userIDString := r.URL.Query().Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
+		return
+	}
+
 	limitStr := r.URL.Query().Get("limit")
 	excludeSeenStr := r.URL.Query().Get("excludeSeen")
 
-	recommendedPostsResponse, err := fetchRecommendations(userIDStr, limitStr, excludeSeenStr)
+	recommendedPostsResponse, err := fetchRecommendations(strconv.FormatInt(userID, 10), limitStr, excludeSeenStr)
 	if err != nil {
 		log.Println("Failed to fetch recommended posts due to the following error: ", err)
 		http.Error(w, "Failed to fetch recommended posts", http.StatusInternalServerError)
 		return
 	}
 
-	userID, err := strconv.ParseInt(userIDStr, 10, 64)
-	if err != nil {
-		log.Println("Failed to convert userIDString (string) to userID (in64): ", err)
-		http.Error(w, "Failed to convert param 'id'.", http.StatusInternalServerError)
-		return
-	}
-
 	limit, err := strconv.ParseInt(limitStr, 10, 64)
 	if err != nil {
 		log.Println("Failed to convert limitString (string) to limit (int64): ", err)
@@ -116,41 +125,22 @@ func getPostInfo(recommendedPosts []models.ScoredPost, userID, limit, page int64
 		placeholders[i] = "?"
 		args[i+1] = id.PostID
 	}
+	notBlocked, blockArgs := blocks.Exclude("p.user_id", userID)
+	visible, visibleArgs := audience.Visible("p", userID)
+	args = append(args, blockArgs...)
+	args = append(args, visibleArgs...)
 	args = append(args, limit, offset)
 
 	query := `
-		SELECT
-			p.post_id,
-			p.user_id,
-			p.to_user_id,
-			p.original_post_id,
-			p.impressions,
-			(SELECT COUNT(*) FROM post_views pv WHERE pv.post_id = p.post_id) AS views,
-			p.content_text,
-			p.created_at,
-			p.updated_at,
-			p.location_name,
-			p.location_lat,
-			p.location_lng,
-			p.is_poll,
-			p.poll_question,
-			p.poll_duration_type,
-			p.poll_duration_length,
-			u.username,
-			up.first_name,
-			up.last_name,
-			up.preferred_name,
-			ui.image_url AS profile_pic_url,
-			pr_user.reaction_type AS user_reaction,
-			(SELECT COUNT(*) FROM post_reactions pr WHERE pr.post_id = p.post_id) AS total_reactions,
-			(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.post_id) AS total_comments,
-			(SELECT COUNT(*) FROM post_shares ps WHERE ps.post_id = p.post_id) AS total_post_shares
+		SELECT ` + recommendedFeedPostColumns + `
 		FROM posts p
-		LEFT JOIN users u ON p.user_id = u.user_id
-		LEFT JOIN user_profiles up ON p.user_id = up.user_id
-		LEFT JOIN user_images ui ON p.user_id = ui.user_id
-		LEFT JOIN post_reactions pr_user ON p.post_id = pr_user.post_id AND pr_user.user_id = ?
+		` + recommendedFeedPostJoins + `
 		WHERE p.post_id IN (` + strings.Join(placeholders, ",") + `)
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND p.group_id IS NULL
+			AND ` + notBlocked + `
+			AND ` + visible + `
 		ORDER BY p.created_at DESC
 		LIMIT ? OFFSET ?
 	`
@@ -163,81 +153,11 @@ func getPostInfo(recommendedPosts []models.ScoredPost, userID, limit, page int64
 
 	var recommendedFeedPostsList []models.RecommendedFeedPost
 	for rows.Next() {
-		var p models.RecommendedFeedPost
-		var (
-			originalPostID     sql.NullInt64
-			contentText        sql.NullString
-			createdAt          sql.NullTime
-			updatedAt          sql.NullTime
-			locationName       sql.NullString
-			locationLat        sql.NullFloat64
-			locationLong       sql.NullFloat64
-			isPoll             sql.NullBool
-			pollQuestion       sql.NullString
-			pollDurationType   sql.NullString
-			pollDurationLength sql.NullInt64
-			username           sql.NullString
-			firstName          sql.NullString
-			lastName           sql.NullString
-			preferredName      sql.NullString
-			userReaction       sql.NullString
-			profilePicURL      sql.NullString
-			totalReactions     int64
-			totalComments      int64
-			totalPostShares    int64
-		)
-
-		err := rows.Scan(
-			&p.PostID,
-			&p.UserID,
-			&p.ToUserID,
-			&originalPostID,
-			&p.Impressions,
-			&p.Views,
-			&contentText,
-			&createdAt,
-			&updatedAt,
-			&locationName,
-			&locationLat,
-			&locationLong,
-			&isPoll,
-			&pollQuestion,
-			&pollDurationType,
-			&pollDurationLength,
-			&username,
-			&firstName,
-			&lastName,
-			&preferredName,
-			&profilePicURL,
-			&userReaction,
-			&totalReactions,
-			&totalComments,
-			&totalPostShares,
-		)
+		p, err := scanRecommendedFeedPost(rows)
 		if err != nil {
 			log.Println("Scan rows error: ", err)
 			continue
 		}
-		p.OriginalPostID = util.SqlNullInt64ToPtr(originalPostID)
-		p.ContentText = util.SqlNullStringToPtr(contentText)
-		p.CreatedAt = util.SqlNullTimeToPtr(createdAt)
-		p.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
-		p.LocationName = util.SqlNullStringToPtr(locationName)
-		p.LocationLat = util.SqlNullFloat64ToPtr(locationLat)
-		p.LocationLong = util.SqlNullFloat64ToPtr(locationLong)
-		p.IsPoll = util.SqlNullBoolToPtr(isPoll)
-		p.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
-		p.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
-		p.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
-		p.Username = util.SqlNullStringToPtr(username)
-		p.FirstName = util.SqlNullStringToPtr(firstName)
-		p.LastName = util.SqlNullStringToPtr(lastName)
-		p.PreferredName = util.SqlNullStringToPtr(preferredName)
-		p.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
-		p.UserReaction = util.SqlNullStringToPtr(userReaction)
-		p.TotalReactions = totalReactions
-		p.TotalComments = totalComments
-		p.TotalPostShares = totalPostShares
 		recommendedFeedPostsList = append(recommendedFeedPostsList, p)
 	}
 
@@ -245,9 +165,167 @@ func getPostInfo(recommendedPosts []models.ScoredPost, userID, limit, page int64
 		return models.GetRecommendedFeedResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
 	}
 
+	postIDs := make([]int64, len(recommendedFeedPostsList))
+	for i, p := range recommendedFeedPostsList {
+		postIDs[i] = p.PostID
+	}
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return models.GetRecommendedFeedResponse{}, err
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.GetRecommendedFeedResponse{}, err
+	}
+	texts := make(map[int64]string, len(recommendedFeedPostsList))
+	for _, p := range recommendedFeedPostsList {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.GetRecommendedFeedResponse{}, err
+	}
+	profilePics := make([]string, 0, len(recommendedFeedPostsList))
+	for _, p := range recommendedFeedPostsList {
+		if p.ProfilePicURL != nil {
+			profilePics = append(profilePics, *p.ProfilePicURL)
+		}
+	}
+	profilePicImages, err := imaging.ForURLs(profilePics)
+	if err != nil {
+		return models.GetRecommendedFeedResponse{}, err
+	}
+	for i := range recommendedFeedPostsList {
+		recommendedFeedPostsList[i].ReactionCounts = reactionCounts[recommendedFeedPostsList[i].PostID]
+		recommendedFeedPostsList[i].OriginalPost = originals[recommendedFeedPostsList[i].PostID]
+		recommendedFeedPostsList[i].LinkPreviews = linkPreviews[recommendedFeedPostsList[i].PostID]
+		recommendedFeedPostsList[i].ProfilePicVariants = imaging.Variants(profilePicImages, recommendedFeedPostsList[i].ProfilePicURL)
+	}
+
 	return models.GetRecommendedFeedResponse{
 		RecommendedFeedPosts: recommendedFeedPostsList,
 		Limit:                limit,
 		Page:                 page,
 	}, err
 }
+
+// recommendedFeedPostColumns selects what a RecommendedFeedPost is built from for
+// a post aliased p. It goes with recommendedFeedPostJoins, whose one placeholder is
+// the viewer's user id, and is read back with scanRecommendedFeedPost.
+const recommendedFeedPostColumns = `
+			p.post_id,
+			p.user_id,
+			p.to_user_id,
+			p.original_post_id,
+			p.impressions,
+			(SELECT COUNT(*) FROM post_views pv WHERE pv.post_id = p.post_id) AS views,
+			p.content_text,
+			p.created_at,
+			p.updated_at,
+			p.location_name,
+			p.location_lat,
+			p.location_lng,
+			p.is_poll,
+			p.poll_question,
+			p.poll_duration_type,
+			p.poll_duration_length,
+			p.edited_at,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_pic_url,
+			pr_user.reaction_type AS user_reaction,
+			(SELECT COUNT(*) FROM post_reactions pr WHERE pr.post_id = p.post_id) AS total_reactions,
+			(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.post_id) AS total_comments,
+			(SELECT COUNT(*) FROM post_shares ps WHERE ps.post_id = p.post_id) AS total_post_shares`
+
+const recommendedFeedPostJoins = `
+		LEFT JOIN users u ON p.user_id = u.user_id
+		LEFT JOIN user_profiles up ON p.user_id = up.user_id
+		LEFT JOIN user_images ui ON p.user_id = ui.user_id
+		LEFT JOIN post_reactions pr_user ON p.post_id = pr_user.post_id AND pr_user.user_id = ?`
+
+func scanRecommendedFeedPost(rows *sql.Rows) (models.RecommendedFeedPost, error) {
+	var p models.RecommendedFeedPost
+	var (
+		originalPostID     sql.NullInt64
+		contentText        sql.NullString
+		createdAt          sql.NullTime
+		updatedAt          sql.NullTime
+		locationName       sql.NullString
+		locationLat        sql.NullFloat64
+		locationLong       sql.NullFloat64
+		isPoll             sql.NullBool
+		pollQuestion       sql.NullString
+		pollDurationType   sql.NullString
+		pollDurationLength sql.NullInt64
+		editedAt           sql.NullTime
+		username           sql.NullString
+		firstName          sql.NullString
+		lastName           sql.NullString
+		preferredName      sql.NullString
+		userReaction       sql.NullString
+		profilePicURL      sql.NullString
+		totalReactions     int64
+		totalComments      int64
+		totalPostShares    int64
+	)
+
+	err := rows.Scan(
+		&p.PostID,
+		&p.UserID,
+		&p.ToUserID,
+		&originalPostID,
+		&p.Impressions,
+		&p.Views,
+		&contentText,
+		&createdAt,
+		&updatedAt,
+		&locationName,
+		&locationLat,
+		&locationLong,
+		&isPoll,
+		&pollQuestion,
+		&pollDurationType,
+		&pollDurationLength,
+		&editedAt,
+		&username,
+		&firstName,
+		&lastName,
+		&preferredName,
+		&profilePicURL,
+		&userReaction,
+		&totalReactions,
+		&totalComments,
+		&totalPostShares,
+	)
+	if err != nil {
+		return models.RecommendedFeedPost{}, err
+	}
+	p.OriginalPostID = util.SqlNullInt64ToPtr(originalPostID)
+	p.ContentText = util.SqlNullStringToPtr(contentText)
+	p.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+	p.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+	p.LocationName = util.SqlNullStringToPtr(locationName)
+	p.LocationLat = util.SqlNullFloat64ToPtr(locationLat)
+	p.LocationLong = util.SqlNullFloat64ToPtr(locationLong)
+	p.IsPoll = util.SqlNullBoolToPtr(isPoll)
+	p.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
+	p.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
+	p.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
+	p.Edited = editedAt.Valid
+	p.EditedAt = util.SqlNullTimeToPtr(editedAt)
+	p.Username = util.SqlNullStringToPtr(username)
+	p.FirstName = util.SqlNullStringToPtr(firstName)
+	p.LastName = util.SqlNullStringToPtr(lastName)
+	p.PreferredName = util.SqlNullStringToPtr(preferredName)
+	p.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+	p.UserReaction = util.SqlNullStringToPtr(userReaction)
+	p.TotalReactions = totalReactions
+	p.TotalComments = totalComments
+	p.TotalPostShares = totalPostShares
+	return p, nil
+}
@@ -44,7 +44,7 @@ func getTotalPosts(userID int64) (models.GetTotalPostsResponse, error) {
 	query := `
 		SELECT COUNT(*)
 		FROM posts
-		WHERE user_id = ?
+		WHERE user_id = ? AND deleted_at IS NULL AND status = 'published'
 	`
 	row := database.DB.QueryRow(query, userID)
 	err := row.Scan(
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func GetTotalSharesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	postIDString := r.URL.Query().Get("id")
+	if postIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	postID, err := strconv.ParseInt(postIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to convert postIDString (string) to postID (int64): ", err)
+		http.Error(w, "Failed to convert param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	viewerID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if err := checkPostVisible(postID, viewerID); err != nil {
+		log.Println("Failed to get total shares due to the following error: ", err)
+		http.Error(w, "Failed to get total shares.", statusForPostAccessError(err))
+		return
+	}
+
+	totalShares, err := countPostShares(postID, viewerID)
+	if err != nil {
+		log.Println("Failed to get total shares due to the following error: ", err)
+		http.Error(w, "Failed to get total shares.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.GetTotalSharesResponse{TotalShares: totalShares})
+}
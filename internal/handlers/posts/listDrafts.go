@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func ListDraftsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := r.URL.Query().Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := listDrafts(userID, limit, page)
+	if err != nil {
+		log.Println("Failed to list drafts due to the following error: ", err)
+		http.Error(w, "Failed to list drafts.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// listDrafts lists the user's drafts and scheduled posts, the next to be published
+// first and unscheduled drafts after them, most recently edited first.
+func listDrafts(userID, limit, page int64) (models.ListDraftsResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalDrafts int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM posts
+		WHERE user_id = ? AND status IN ('draft', 'scheduled') AND deleted_at IS NULL
+	`
+	if err := database.DB.QueryRow(countQuery, userID).Scan(&totalDrafts); err != nil {
+		return models.ListDraftsResponse{}, fmt.Errorf("failed to get totalDrafts: %w", err)
+	}
+
+	query := `
+		SELECT
+			post_id,
+			to_user_id,
+			group_id,
+			audience,
+			audience_list_id,
+			content_text,
+			location_name,
+			location_lat,
+			location_lng,
+			is_poll,
+			poll_question,
+			poll_duration_type,
+			poll_duration_length,
+			status,
+			publish_at,
+			created_at,
+			updated_at
+		FROM posts
+		WHERE user_id = ? AND status IN ('draft', 'scheduled') AND deleted_at IS NULL
+		ORDER BY publish_at IS NULL, publish_at ASC, updated_at DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(query, userID, limit, offset)
+	if err != nil {
+		return models.ListDraftsResponse{}, fmt.Errorf("failed to select drafts: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		drafts  []models.DraftPost
+		postIDs []int64
+	)
+	for rows.Next() {
+		var d models.DraftPost
+		var (
+			groupID            sql.NullInt64
+			audienceListID     sql.NullInt64
+			contentText        sql.NullString
+			locationName       sql.NullString
+			locationLat        sql.NullFloat64
+			locationLong       sql.NullFloat64
+			isPoll             sql.NullBool
+			pollQuestion       sql.NullString
+			pollDurationType   sql.NullString
+			pollDurationLength sql.NullInt64
+			publishAt          sql.NullTime
+			createdAt          sql.NullTime
+			updatedAt          sql.NullTime
+		)
+		err := rows.Scan(
+			&d.PostID,
+			&d.ToUserID,
+			&groupID,
+			&d.Audience,
+			&audienceListID,
+			&contentText,
+			&locationName,
+			&locationLat,
+			&locationLong,
+			&isPoll,
+			&pollQuestion,
+			&pollDurationType,
+			&pollDurationLength,
+			&d.Status,
+			&publishAt,
+			&createdAt,
+			&updatedAt,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		d.GroupID = util.SqlNullInt64ToPtr(groupID)
+		d.AudienceListID = util.SqlNullInt64ToPtr(audienceListID)
+		d.ContentText = util.SqlNullStringToPtr(contentText)
+		d.LocationName = util.SqlNullStringToPtr(locationName)
+		d.LocationLat = util.SqlNullFloat64ToPtr(locationLat)
+		d.LocationLong = util.SqlNullFloat64ToPtr(locationLong)
+		d.IsPoll = util.SqlNullBoolToPtr(isPoll)
+		d.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
+		d.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
+		d.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
+		d.PublishAt = util.SqlNullTimeToPtr(publishAt)
+		d.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		d.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+		drafts = append(drafts, d)
+		postIDs = append(postIDs, d.PostID)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListDraftsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	images, hashtags, err := loadDraftAttachments(postIDs)
+	if err != nil {
+		return models.ListDraftsResponse{}, err
+	}
+	for i := range drafts {
+		drafts[i].Images = images[drafts[i].PostID]
+		drafts[i].Hashtags = hashtags[drafts[i].PostID]
+	}
+
+	totalPages := int64(math.Ceil(float64(totalDrafts) / float64(limit)))
+	return models.ListDraftsResponse{
+		Drafts:      drafts,
+		Limit:       limit,
+		Page:        page,
+		TotalDrafts: totalDrafts,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+// loadDraftAttachments returns the image URLs and hashtags of a page of drafts, keyed
+// by post id, so the client can reopen a draft in the editor as it was saved.
+func loadDraftAttachments(postIDs []int64) (map[int64][]string, map[int64][]string, error) {
+	images := make(map[int64][]string)
+	hashtags := make(map[int64][]string)
+	if len(postIDs) == 0 {
+		return images, hashtags, nil
+	}
+
+	placeholders := make([]string, len(postIDs))
+	args := make([]interface{}, len(postIDs))
+	for i, id := range postIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	in := strings.Join(placeholders, ",")
+
+	rows, err := database.DB.Query(`
+		SELECT post_id, media_url
+		FROM post_media
+		WHERE post_id IN (`+in+`) AND media_type = 'image'
+		ORDER BY media_id ASC
+	`, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get draft media: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			postID int64
+			url    string
+		)
+		if err := rows.Scan(&postID, &url); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan draft media: %w", err)
+		}
+		images[postID] = append(images[postID], url)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate over draft media: %w", err)
+	}
+
+	tagRows, err := database.DB.Query(`
+		SELECT ph.post_id, h.tag
+		FROM post_hashtags ph
+		JOIN hashtags h ON h.hashtag_id = ph.hashtag_id
+		WHERE ph.post_id IN (`+in+`)
+	`, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get draft hashtags: %w", err)
+	}
+	defer tagRows.Close()
+	for tagRows.Next() {
+		var (
+			postID int64
+			tag    string
+		)
+		if err := tagRows.Scan(&postID, &tag); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan draft hashtag: %w", err)
+		}
+		hashtags[postID] = append(hashtags[postID], tag)
+	}
+	if err := tagRows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate over draft hashtags: %w", err)
+	}
+
+	return images, hashtags, nil
+}
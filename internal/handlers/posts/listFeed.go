@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/shares"
 	"VoizyServer/internal/util"
 	"encoding/json"
 	"log"
@@ -17,15 +22,13 @@ func ListFeedHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userIDString := r.URL.Query().Get("id")
-	if userIDString == "" {
-		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
 		return
 	}
-	userID, err := strconv.ParseInt(userIDString, 10, 64)
-	if err != nil {
-		log.Println("Failed to parse userIDString (string) to userID (int64) due to the following error: ", err)
-		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+	if userIDString := r.URL.Query().Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
 		return
 	}
 
@@ -53,7 +56,7 @@ func ListFeedHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := listFeed(limit, page)
+	response, err := listFeed(userID, limit, page)
 	if err != nil {
 		log.Println("Failed to list feed due to the following error: ", err)
 		http.Error(w, "Failed to list feed.", http.StatusInternalServerError)
@@ -66,16 +69,20 @@ func ListFeedHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-func listFeed(limit, page int64) (models.ListFeedResponse, error) {
+func listFeed(userID, limit, page int64) (models.ListFeedResponse, error) {
 	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("posts.user_id", userID)
+	visible, visibleArgs := audience.Visible("posts", userID)
+	filterArgs := append(blockArgs, visibleArgs...)
 
 	var totalPosts int64
 	countQuery := `
 		SELECT COUNT(*)
 		FROM posts
-		WHERE to_user_id = -1
-	`
-	err := database.DB.QueryRow(countQuery).Scan(&totalPosts)
+		WHERE to_user_id = -1 AND group_id IS NULL AND deleted_at IS NULL AND status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible
+	err := database.DB.QueryRow(countQuery, filterArgs...).Scan(&totalPosts)
 	if err != nil {
 		return models.ListFeedResponse{}, err
 	}
@@ -97,13 +104,16 @@ func listFeed(limit, page int64) (models.ListFeedResponse, error) {
 			is_poll,
 			poll_question,
 			poll_duration_type,
-			poll_duration_length
+			poll_duration_length,
+			edited_at
 		FROM posts
-		WHERE to_user_id = -1
+		WHERE to_user_id = -1 AND group_id IS NULL AND deleted_at IS NULL AND status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible + `
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
 	`
-	rows, err := database.DB.Query(query, limit, offset)
+	rows, err := database.DB.Query(query, append(filterArgs, limit, offset)...)
 	if err != nil {
 		return models.ListFeedResponse{}, err
 	}
@@ -129,6 +139,7 @@ func listFeed(limit, page int64) (models.ListFeedResponse, error) {
 			&p.PollQuestion,
 			&p.PollDurationType,
 			&p.PollDurationLength,
+			&p.EditedAt,
 		)
 		if err != nil {
 			log.Println("Scan rows error: ", err)
@@ -150,12 +161,37 @@ func listFeed(limit, page int64) (models.ListFeedResponse, error) {
 			PollQuestion:       util.SqlNullStringToPtr(p.PollQuestion),
 			PollDurationType:   util.SqlNullStringToPtr(p.PollDurationType),
 			PollDurationLength: util.SqlNullInt64ToPtr(p.PollDurationLength),
+			Edited:             p.EditedAt.Valid,
+			EditedAt:           util.SqlNullTimeToPtr(p.EditedAt),
 		})
 	}
 	if err := rows.Err(); err != nil {
 		return models.ListFeedResponse{}, err
 	}
 
+	postIDs := make([]int64, len(posts))
+	for i, p := range posts {
+		postIDs[i] = p.PostID
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.ListFeedResponse{}, err
+	}
+	texts := make(map[int64]string, len(posts))
+	for _, p := range posts {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.ListFeedResponse{}, err
+	}
+	for i := range posts {
+		posts[i].OriginalPost = originals[posts[i].PostID]
+		posts[i].LinkPreviews = linkPreviews[posts[i].PostID]
+	}
+
 	totalPages := int64(math.Ceil(float64(totalPosts) / float64(limit)))
 
 	return models.ListFeedResponse{
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	models "VoizyServer/internal/models/middleware"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestFeedsRefuseSpoofedViewer makes sure a feed cannot be read as someone else by
+// naming them in the id param: the viewer, whose blocks and audiences filter the
+// feed, is always the authenticated user.
+func TestFeedsRefuseSpoofedViewer(t *testing.T) {
+	handlers := map[string]http.HandlerFunc{
+		"ListFeedHandler":            ListFeedHandler,
+		"GetFriendFeed":              GetFriendFeed,
+		"GetRecommendedFeed":         GetRecommendedFeed,
+		"GetPopularPosts":            GetPopularPosts,
+		"ListRecommendedFeedHandler": ListRecommendedFeedHandler,
+	}
+	for name, handler := range handlers {
+		r := httptest.NewRequest(http.MethodGet, "/?id=2&limit=10&page=1&days=7", nil)
+		r = r.WithContext(context.WithValue(r.Context(), models.UserIDContextKey, int64(1)))
+		w := httptest.NewRecorder()
+		handler(w, r)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s with a spoofed id answered %d, want %d", name, w.Code, http.StatusForbidden)
+		}
+
+		r = httptest.NewRequest(http.MethodGet, "/?id=2&limit=10&page=1&days=7", nil)
+		w = httptest.NewRecorder()
+		handler(w, r)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s without an authenticated user answered %d, want %d", name, w.Code, http.StatusUnauthorized)
+		}
+	}
+}
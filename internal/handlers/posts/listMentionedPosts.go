@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListMentionedPostsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := r.URL.Query().Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := listMentionedPosts(userID, limit, page)
+	if err != nil {
+		log.Println("Failed to list mentioned posts due to the following error: ", err)
+		http.Error(w, "Failed to list mentioned posts.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// listMentionedPosts lists the posts whose text mentions userID, newest first.
+// Posts in groups are only listed for members of the group.
+func listMentionedPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
+	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("p.user_id", userID)
+	visible, visibleArgs := audience.Visible("p", userID)
+
+	visibility := `
+		EXISTS (
+			SELECT 1 FROM mentions m
+			WHERE m.post_id = p.post_id AND m.comment_id IS NULL AND m.mentioned_user_id = ?
+		)
+		AND (
+			p.group_id IS NULL
+			OR EXISTS (SELECT 1 FROM group_members gm WHERE gm.group_id = p.group_id AND gm.user_id = ?)
+		)
+		AND p.deleted_at IS NULL
+		AND p.status = 'published'
+		AND ` + notBlocked + `
+		AND ` + visible
+
+	var totalPosts int64
+	countQuery := `SELECT COUNT(*) FROM posts p WHERE ` + visibility
+	countArgs := append([]interface{}{userID, userID}, blockArgs...)
+	countArgs = append(countArgs, visibleArgs...)
+	if err := database.DB.QueryRow(countQuery, countArgs...).Scan(&totalPosts); err != nil {
+		return models.ListPostsResponse{}, fmt.Errorf("failed to get totalPosts: %w", err)
+	}
+
+	selectQuery := `
+		SELECT
+			p.post_id,
+			p.user_id,
+			p.to_user_id,
+			p.original_post_id,
+			p.impressions,
+			p.views,
+			p.content_text,
+			p.created_at,
+			p.updated_at,
+			p.location_name,
+			p.location_lat,
+			p.location_lng,
+			p.is_poll,
+			p.poll_question,
+			p.poll_duration_type,
+			p.poll_duration_length,
+			p.edited_at,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			pr_user.reaction_type AS user_reaction,
+			(SELECT COUNT(*) FROM post_reactions pr WHERE pr.post_id = p.post_id) AS total_reactions,
+			(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.post_id) AS total_comments,
+			(SELECT COUNT(*) FROM post_shares ps WHERE ps.post_id = p.post_id) AS total_post_shares
+		FROM posts p
+		LEFT JOIN users u
+			ON u.user_id = p.user_id
+		LEFT JOIN user_profiles up
+			ON up.user_id = p.user_id
+		LEFT JOIN post_reactions pr_user
+			ON pr_user.post_id = p.post_id AND pr_user.user_id = ?
+		WHERE ` + visibility + `
+		ORDER BY p.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+	args := []interface{}{userID, userID, userID}
+	args = append(args, blockArgs...)
+	args = append(args, visibleArgs...)
+	args = append(args, limit, offset)
+	rows, err := database.DB.Query(selectQuery, args...)
+	if err != nil {
+		return models.ListPostsResponse{}, fmt.Errorf("failed to select posts: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		posts   []models.ListPost
+		postIDs []int64
+	)
+	for rows.Next() {
+		var p models.ListPost
+		var (
+			originalPostID     sql.NullInt64
+			contentText        sql.NullString
+			createdAt          sql.NullTime
+			updatedAt          sql.NullTime
+			locationName       sql.NullString
+			locationLat        sql.NullFloat64
+			locationLong       sql.NullFloat64
+			isPoll             sql.NullBool
+			pollQuestion       sql.NullString
+			pollDurationType   sql.NullString
+			pollDurationLength sql.NullInt64
+			editedAt           sql.NullTime
+			username           sql.NullString
+			firstName          sql.NullString
+			lastName           sql.NullString
+			preferredName      sql.NullString
+			userReaction       sql.NullString
+		)
+
+		err := rows.Scan(
+			&p.PostID,
+			&p.UserID,
+			&p.ToUserID,
+			&originalPostID,
+			&p.Impressions,
+			&p.Views,
+			&contentText,
+			&createdAt,
+			&updatedAt,
+			&locationName,
+			&locationLat,
+			&locationLong,
+			&isPoll,
+			&pollQuestion,
+			&pollDurationType,
+			&pollDurationLength,
+			&editedAt,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&userReaction,
+			&p.TotalReactions,
+			&p.TotalComments,
+			&p.TotalPostShares,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		p.OriginalPostID = util.SqlNullInt64ToPtr(originalPostID)
+		p.ContentText = util.SqlNullStringToPtr(contentText)
+		p.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		p.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+		p.LocationName = util.SqlNullStringToPtr(locationName)
+		p.LocationLat = util.SqlNullFloat64ToPtr(locationLat)
+		p.LocationLong = util.SqlNullFloat64ToPtr(locationLong)
+		p.IsPoll = util.SqlNullBoolToPtr(isPoll)
+		p.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
+		p.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
+		p.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
+		p.Edited = editedAt.Valid
+		p.EditedAt = util.SqlNullTimeToPtr(editedAt)
+		p.Username = util.SqlNullStringToPtr(username)
+		p.FirstName = util.SqlNullStringToPtr(firstName)
+		p.LastName = util.SqlNullStringToPtr(lastName)
+		p.PreferredName = util.SqlNullStringToPtr(preferredName)
+		p.UserReaction = util.SqlNullStringToPtr(userReaction)
+		posts = append(posts, p)
+		postIDs = append(postIDs, p.PostID)
+	}
+	if err = rows.Err(); err != nil {
+		return models.ListPostsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return models.ListPostsResponse{}, err
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.ListPostsResponse{}, err
+	}
+	texts := make(map[int64]string, len(posts))
+	for _, p := range posts {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.ListPostsResponse{}, err
+	}
+	for i := range posts {
+		posts[i].ReactionCounts = reactionCounts[posts[i].PostID]
+		posts[i].OriginalPost = originals[posts[i].PostID]
+		posts[i].LinkPreviews = linkPreviews[posts[i].PostID]
+	}
+
+	mentions, err := loadMentionSpans(postIDs, false)
+	if err != nil {
+		return models.ListPostsResponse{}, err
+	}
+	for i := range posts {
+		posts[i].Mentions = mentions[posts[i].PostID]
+	}
+
+	totalPages := int64(math.Ceil(float64(totalPosts) / float64(limit)))
+	return models.ListPostsResponse{
+		Posts:      posts,
+		Limit:      limit,
+		Page:       page,
+		TotalPosts: totalPosts,
+		TotalPages: totalPages,
+	}, nil
+}
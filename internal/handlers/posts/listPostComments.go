@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
 	"VoizyServer/internal/util"
 	"database/sql"
 	"encoding/json"
@@ -56,7 +59,31 @@ func ListPostCommentsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := listPostComments(postID, limit, page)
+	// parentID lists the replies to one comment, so clients can load a thread's
+	// children on demand; without it the top-level comments are listed.
+	var parentID *int64
+	if parentIDString := r.URL.Query().Get("parentID"); parentIDString != "" {
+		id, err := strconv.ParseInt(parentIDString, 10, 64)
+		if err != nil {
+			log.Println("Failed to parse parentIDString (string) to parentID (int64) due to the following error: ", err)
+			http.Error(w, "Failed to parse param 'parentID'.", http.StatusInternalServerError)
+			return
+		}
+		parentID = &id
+	}
+
+	viewerID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if err := checkPostVisible(postID, viewerID); err != nil {
+		log.Println("Failed to list post comments due to the following error: ", err)
+		http.Error(w, "Failed to list post comments.", statusForPostAccessError(err))
+		return
+	}
+
+	response, err := listPostComments(postID, parentID, viewerID, limit, page)
 	if err != nil {
 		log.Println("Failed to list post comments due to the following error: ", err)
 		http.Error(w, "Failed to list post comments.", http.StatusInternalServerError)
@@ -67,28 +94,54 @@ func ListPostCommentsHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-func listPostComments(postID, limit, page int64) (models.ListCommentsResponse, error) {
+// listPostComments lists one level of a post's comment threads: the top-level
+// comments, newest first, or the replies to parentID in the order they were left.
+func listPostComments(postID int64, parentID *int64, viewerID, limit, page int64) (models.ListCommentsResponse, error) {
 	offset := (page - 1) * limit
 
+	parentFilter := "IS NULL"
+	order := "DESC"
+	parentArgs := []interface{}{}
+	if parentID != nil {
+		parentFilter = "= ?"
+		order = "ASC"
+		parentArgs = append(parentArgs, *parentID)
+	}
+
 	var totalComments int64
+	countNotBlocked, countBlockArgs := blocks.Exclude("comments.user_id", viewerID)
 	countQuery := `
 		SELECT COUNT(*)
 		FROM comments
 		WHERE post_id = ?
-	`
-	err := database.DB.QueryRow(countQuery, postID).Scan(&totalComments)
+			AND parent_comment_id ` + parentFilter + `
+			AND ` + countNotBlocked
+	countArgs := append([]interface{}{postID}, parentArgs...)
+	countArgs = append(countArgs, countBlockArgs...)
+	err := database.DB.QueryRow(countQuery, countArgs...).Scan(&totalComments)
 	if err != nil {
 		return models.ListCommentsResponse{}, fmt.Errorf("failed to get totalComments: %w", err)
 	}
 
+	replyNotBlocked, replyBlockArgs := blocks.Exclude("r.user_id", viewerID)
+	notBlocked, blockArgs := blocks.Exclude("c.user_id", viewerID)
 	selectQuery := `
 		SELECT
 			c.comment_id,
 			c.post_id,
 			c.user_id,
+			c.parent_comment_id,
+			c.depth,
 			c.content_text,
 			c.created_at,
 			c.updated_at,
+			c.edited_at,
+			(
+				SELECT COUNT(*)
+				FROM comments r
+				WHERE r.parent_comment_id = c.comment_id
+					AND ` + replyNotBlocked + `
+			) AS reply_count,
 			u.username,
 			up.first_name,
 			up.last_name,
@@ -107,12 +160,19 @@ func listPostComments(postID, limit, page int64) (models.ListCommentsResponse, e
 		LEFT JOIN comment_reactions cr
 			ON c.comment_id = cr.comment_id
 		WHERE c.post_id = ?
-		GROUP BY c.comment_id, c.post_id, c.user_id, c.content_text, c.created_at, c.updated_at,
-						 u.username, up.first_name, up.last_name, up.preferred_name, ui.image_url
-		ORDER BY c.created_at DESC
+			AND c.parent_comment_id ` + parentFilter + `
+			AND ` + notBlocked + `
+		GROUP BY c.comment_id, c.post_id, c.user_id, c.parent_comment_id, c.depth, c.content_text, c.created_at, c.updated_at,
+						 c.edited_at, u.username, up.first_name, up.last_name, up.preferred_name, ui.image_url
+		ORDER BY c.created_at ` + order + `
 		LIMIT ? OFFSET ?
 	`
-	rows, err := database.DB.Query(selectQuery, postID, limit, offset)
+	args := append([]interface{}{}, replyBlockArgs...)
+	args = append(args, postID)
+	args = append(args, parentArgs...)
+	args = append(args, blockArgs...)
+	args = append(args, limit, offset)
+	rows, err := database.DB.Query(selectQuery, args...)
 	if err != nil {
 		return models.ListCommentsResponse{}, err
 	}
@@ -120,14 +180,20 @@ func listPostComments(postID, limit, page int64) (models.ListCommentsResponse, e
 	for rows.Next() {
 		var c models.ListComment
 		var username, firstName, lastName, preferredName, profilePicURL, reactions sql.NullString
+		var parentCommentID sql.NullInt64
+		var editedAt sql.NullTime
 		var reactionCount int64
 		err := rows.Scan(
 			&c.CommentID,
 			&c.PostID,
 			&c.UserID,
+			&parentCommentID,
+			&c.Depth,
 			&c.ContentText,
 			&c.CreatedAt,
 			&c.UpdatedAt,
+			&editedAt,
+			&c.ReplyCount,
 			&username,
 			&firstName,
 			&lastName,
@@ -140,6 +206,9 @@ func listPostComments(postID, limit, page int64) (models.ListCommentsResponse, e
 			log.Println("Scan rows error: ", err)
 			continue
 		}
+		c.ParentCommentID = util.SqlNullInt64ToPtr(parentCommentID)
+		c.EditedAt = util.SqlNullTimeToPtr(editedAt)
+		c.Edited = editedAt.Valid
 		c.Username = util.SqlNullStringToPtr(username)
 		c.FirstName = util.SqlNullStringToPtr(firstName)
 		c.LastName = util.SqlNullStringToPtr(lastName)
@@ -157,6 +226,23 @@ func listPostComments(postID, limit, page int64) (models.ListCommentsResponse, e
 	if err = rows.Err(); err != nil {
 		return models.ListCommentsResponse{}, err
 	}
+
+	commentIDs := make([]int64, len(comments))
+	for i, c := range comments {
+		commentIDs[i] = c.CommentID
+	}
+	mentions, err := loadMentionSpans(commentIDs, true)
+	if err != nil {
+		return models.ListCommentsResponse{}, err
+	}
+	reactionCounts, err := reactions.CommentCounts(commentIDs)
+	if err != nil {
+		return models.ListCommentsResponse{}, err
+	}
+	for i := range comments {
+		comments[i].Mentions = mentions[comments[i].CommentID]
+		comments[i].ReactionCounts = reactionCounts[comments[i].CommentID]
+	}
 	totalPages := int64(math.Ceil(float64(totalComments) / float64(limit)))
 
 	return models.ListCommentsResponse{
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	"VoizyServer/internal/reactions"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func ListPostReactionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	postIDString := r.URL.Query().Get("id")
+	if postIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	postID, err := strconv.ParseInt(postIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse postIDString (string) to postID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := r.URL.Query().Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	reactionType := r.URL.Query().Get("type")
+	if reactionType != "" && !reactions.Valid(reactionType) {
+		http.Error(w, "Invalid param 'type'.", http.StatusBadRequest)
+		return
+	}
+
+	viewerID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if err := checkPostVisible(postID, viewerID); err != nil {
+		log.Println("Failed to list post reactions due to the following error: ", err)
+		http.Error(w, "Failed to list post reactions.", statusForPostAccessError(err))
+		return
+	}
+
+	response, err := listReactions(postReactionTarget, postID, viewerID, reactionType, limit, page)
+	if err != nil {
+		log.Println("Failed to list post reactions due to the following error: ", err)
+		http.Error(w, "Failed to list post reactions.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
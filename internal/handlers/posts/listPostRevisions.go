@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func ListPostRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	postIDString := r.URL.Query().Get("id")
+	if postIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	postID, err := strconv.ParseInt(postIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse postIDString (string) to postID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := r.URL.Query().Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	viewerID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if err := checkPostVisible(postID, viewerID); err != nil {
+		log.Println("Failed to list post revisions due to the following error: ", err)
+		http.Error(w, "Failed to list post revisions.", statusForPostAccessError(err))
+		return
+	}
+
+	response, err := listPostRevisions(postID, limit, page)
+	if err != nil {
+		log.Println("Failed to list post revisions due to the following error: ", err)
+		http.Error(w, "Failed to list post revisions.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// listPostRevisions lists a post's revisions, newest first. A post that was never
+// edited has none.
+func listPostRevisions(postID, limit, page int64) (models.ListPostRevisionsResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalRevisions int64
+	err := database.DB.QueryRow(`SELECT COUNT(*) FROM post_revisions WHERE post_id = ?`, postID).Scan(&totalRevisions)
+	if err != nil {
+		return models.ListPostRevisionsResponse{}, fmt.Errorf("failed to get totalRevisions: %w", err)
+	}
+
+	query := `
+		SELECT
+			revision_id,
+			revision_number,
+			editor_id,
+			content_text,
+			location_name,
+			location_lat,
+			location_lng,
+			created_at
+		FROM post_revisions
+		WHERE post_id = ?
+		ORDER BY revision_number DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(query, postID, limit, offset)
+	if err != nil {
+		return models.ListPostRevisionsResponse{}, fmt.Errorf("failed to select revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		revisions   []models.PostRevision
+		revisionIDs []int64
+	)
+	for rows.Next() {
+		var rev models.PostRevision
+		var (
+			revisionID   int64
+			contentText  sql.NullString
+			locationName sql.NullString
+			locationLat  sql.NullFloat64
+			locationLong sql.NullFloat64
+		)
+		err := rows.Scan(
+			&revisionID,
+			&rev.RevisionNumber,
+			&rev.EditorID,
+			&contentText,
+			&locationName,
+			&locationLat,
+			&locationLong,
+			&rev.CreatedAt,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		rev.ContentText = util.SqlNullStringToPtr(contentText)
+		rev.LocationName = util.SqlNullStringToPtr(locationName)
+		rev.LocationLat = util.SqlNullFloat64ToPtr(locationLat)
+		rev.LocationLong = util.SqlNullFloat64ToPtr(locationLong)
+		revisions = append(revisions, rev)
+		revisionIDs = append(revisionIDs, revisionID)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListPostRevisionsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	if len(revisionIDs) > 0 {
+		placeholders := make([]string, len(revisionIDs))
+		args := make([]interface{}, len(revisionIDs))
+		index := make(map[int64]int, len(revisionIDs))
+		for i, id := range revisionIDs {
+			placeholders[i] = "?"
+			args[i] = id
+			index[id] = i
+		}
+
+		mediaRows, err := database.DB.Query(`
+			SELECT revision_id, media_url, media_type
+			FROM post_revision_media
+			WHERE revision_id IN (`+strings.Join(placeholders, ",")+`)
+			ORDER BY revision_media_id ASC
+		`, args...)
+		if err != nil {
+			return models.ListPostRevisionsResponse{}, fmt.Errorf("failed to get revision media: %w", err)
+		}
+		defer mediaRows.Close()
+
+		for mediaRows.Next() {
+			var (
+				revisionID int64
+				mediaURL   string
+				mediaType  string
+			)
+			if err := mediaRows.Scan(&revisionID, &mediaURL, &mediaType); err != nil {
+				return models.ListPostRevisionsResponse{}, fmt.Errorf("failed to scan revision media: %w", err)
+			}
+			rev := &revisions[index[revisionID]]
+			switch mediaType {
+			case "video":
+				rev.Videos = append(rev.Videos, mediaURL)
+			case "audio":
+				rev.Audio = append(rev.Audio, mediaURL)
+			default:
+				rev.Images = append(rev.Images, mediaURL)
+			}
+		}
+		if err := mediaRows.Err(); err != nil {
+			return models.ListPostRevisionsResponse{}, fmt.Errorf("failed to iterate over revision media: %w", err)
+		}
+	}
+
+	totalPages := int64(math.Ceil(float64(totalRevisions) / float64(limit)))
+	return models.ListPostRevisionsResponse{
+		PostID:         postID,
+		Revisions:      revisions,
+		Limit:          limit,
+		Page:           page,
+		TotalRevisions: totalRevisions,
+		TotalPages:     totalPages,
+	}, nil
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListPostSharesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	postIDString := r.URL.Query().Get("id")
+	if postIDString == "" {
+		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+		return
+	}
+	postID, err := strconv.ParseInt(postIDString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse postIDString (string) to postID (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+		return
+	}
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := r.URL.Query().Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	viewerID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if err := checkPostVisible(postID, viewerID); err != nil {
+		log.Println("Failed to list post shares due to the following error: ", err)
+		http.Error(w, "Failed to list post shares.", statusForPostAccessError(err))
+		return
+	}
+
+	response, err := listPostShares(postID, viewerID, limit, page)
+	if err != nil {
+		log.Println("Failed to list post shares due to the following error: ", err)
+		http.Error(w, "Failed to list post shares.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// postSharesFilter keeps the shares of postID whose share post viewerID can see:
+// published, not deleted, in the viewer's audience and not by a blocked user. The
+// shares listing and the share count use it, so the two always agree.
+func postSharesFilter(postID, viewerID int64) (string, []interface{}) {
+	notBlocked, blockArgs := blocks.Exclude("s.user_id", viewerID)
+	visible, visibleArgs := audience.Visible("sp", viewerID)
+
+	clause := `
+		s.post_id = ?
+			AND sp.deleted_at IS NULL
+			AND sp.status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible
+	args := []interface{}{postID}
+	args = append(args, blockArgs...)
+	args = append(args, visibleArgs...)
+	return clause, args
+}
+
+// countPostShares counts the shares of postID that viewerID can see.
+func countPostShares(postID, viewerID int64) (int64, error) {
+	filter, filterArgs := postSharesFilter(postID, viewerID)
+
+	var totalShares int64
+	err := database.DB.QueryRow(`
+		SELECT COUNT(*)
+		FROM post_shares s
+		JOIN posts sp ON sp.post_id = s.share_post_id
+		WHERE `+filter, filterArgs...).Scan(&totalShares)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count shares: %w", err)
+	}
+
+	return totalShares, nil
+}
+
+// listPostShares lists who shared a post, most recent first, along with what they
+// wrote when quoting it.
+func listPostShares(postID, viewerID, limit, page int64) (models.ListPostSharesResponse, error) {
+	offset := (page - 1) * limit
+
+	totalShares, err := countPostShares(postID, viewerID)
+	if err != nil {
+		return models.ListPostSharesResponse{}, err
+	}
+
+	filter, filterArgs := postSharesFilter(postID, viewerID)
+	query := `
+		SELECT
+			s.share_id,
+			s.share_post_id,
+			s.user_id,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_picture,
+			sp.content_text,
+			s.shared_at
+		FROM post_shares s
+		JOIN posts sp
+			ON sp.post_id = s.share_post_id
+		LEFT JOIN users u
+			ON u.user_id = s.user_id
+		LEFT JOIN user_profiles up
+			ON up.user_id = s.user_id
+		LEFT JOIN user_images ui
+			ON ui.user_id = s.user_id
+			AND ui.is_profile_pic = 1
+		WHERE ` + filter + `
+		ORDER BY s.shared_at DESC, s.share_id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(query, append(filterArgs, limit, offset)...)
+	if err != nil {
+		return models.ListPostSharesResponse{}, fmt.Errorf("failed to select shares: %w", err)
+	}
+	defer rows.Close()
+
+	postShares := []models.PostShare{}
+	for rows.Next() {
+		var s models.PostShare
+		var username, firstName, lastName, preferredName, profilePicURL, contentText sql.NullString
+		err := rows.Scan(
+			&s.ShareID,
+			&s.SharePostID,
+			&s.UserID,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&contentText,
+			&s.SharedAt,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		s.Username = util.SqlNullStringToPtr(username)
+		s.FirstName = util.SqlNullStringToPtr(firstName)
+		s.LastName = util.SqlNullStringToPtr(lastName)
+		s.PreferredName = util.SqlNullStringToPtr(preferredName)
+		s.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+		s.ContentText = util.SqlNullStringToPtr(contentText)
+		postShares = append(postShares, s)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListPostSharesResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalShares) / float64(limit)))
+	return models.ListPostSharesResponse{
+		Shares:      postShares,
+		Limit:       limit,
+		Page:        page,
+		TotalShares: totalShares,
+		TotalPages:  totalPages,
+	}, nil
+}
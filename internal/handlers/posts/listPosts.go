@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
 	"VoizyServer/internal/util"
 	"database/sql"
 	"encoding/json"
@@ -55,7 +61,18 @@ func ListPostsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := listPosts(userID, limit, page)
+	viewerID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if err := blocks.Check(viewerID, userID); err != nil {
+		log.Println("Failed to list posts due to the following error: ", err)
+		http.Error(w, "Failed to list posts.", blocks.HTTPStatus(err))
+		return
+	}
+
+	response, err := listPosts(userID, viewerID, limit, page)
 	if err != nil {
 		log.Println("Failed to list posts due to the following error: ", err)
 		http.Error(w, "Failed to list posts.", http.StatusInternalServerError)
@@ -66,16 +83,29 @@ func ListPostsHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
+// listPosts lists the posts on userID's profile, newest first after the posts they
+// have pinned, which come first in the order they arranged them.
+func listPosts(userID, viewerID, limit, page int64) (models.ListPostsResponse, error) {
 	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("p.user_id", viewerID)
+	visible, visibleArgs := audience.Visible("p", viewerID)
 
+	// The count has to filter exactly like selectQuery below, or the page count
+	// disagrees with the pages that can actually be fetched.
 	var totalPosts int64
 	countQuery := `
 		SELECT COUNT(*)
-		FROM posts
-		WHERE user_id = ?
-	`
-	err := database.DB.QueryRow(countQuery, userID).Scan(&totalPosts)
+		FROM posts p
+		WHERE (p.user_id = ? OR p.to_user_id = ?)
+			AND p.group_id IS NULL
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible
+	countArgs := []interface{}{userID, userID}
+	countArgs = append(countArgs, blockArgs...)
+	countArgs = append(countArgs, visibleArgs...)
+	err := database.DB.QueryRow(countQuery, countArgs...).Scan(&totalPosts)
 	if err != nil {
 		return models.ListPostsResponse{}, fmt.Errorf("failed to get totalPosts: %w", err)
 	}
@@ -98,6 +128,7 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 			p.poll_question,
 			p.poll_duration_type,
 			p.poll_duration_length,
+			p.edited_at,
 			u.username,
 			up.first_name,
 			up.last_name,
@@ -105,7 +136,8 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 			pr_user.reaction_type AS user_reaction,
 			(SELECT COUNT(*) FROM post_reactions pr WHERE pr.post_id = p.post_id) AS total_reactions,
 			(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.post_id) AS total_comments,
-			(SELECT COUNT(*) FROM post_shares ps WHERE ps.post_id = p.post_id) AS total_post_shares
+			(SELECT COUNT(*) FROM post_shares ps WHERE ps.post_id = p.post_id) AS total_post_shares,
+			pin.post_id IS NOT NULL AS pinned
 		FROM posts p
 		LEFT JOIN users u
 			ON u.user_id = p.user_id
@@ -113,11 +145,22 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 			ON up.user_id = p.user_id
 		LEFT JOIN post_reactions pr_user
 			ON pr_user.post_id = p.post_id AND pr_user.user_id = ?
+		LEFT JOIN pinned_posts pin
+			ON pin.post_id = p.post_id AND pin.user_id = ?
 		WHERE (p.user_id = ? OR p.to_user_id = ?)
-		ORDER BY p.created_at DESC
+			AND p.group_id IS NULL
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible + `
+		ORDER BY pin.position IS NULL, pin.position, p.created_at DESC
 		LIMIT ? OFFSET ?
 	`
-	rows, err := database.DB.Query(selectQuery, userID, userID, userID, limit, offset)
+	args := []interface{}{userID, userID, userID, userID}
+	args = append(args, blockArgs...)
+	args = append(args, visibleArgs...)
+	args = append(args, limit, offset)
+	rows, err := database.DB.Query(selectQuery, args...)
 	if err != nil {
 		return models.ListPostsResponse{}, fmt.Errorf("failed to select posts: %w", err)
 	}
@@ -138,6 +181,7 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 			pollQuestion       sql.NullString
 			pollDurationType   sql.NullString
 			pollDurationLength sql.NullInt64
+			editedAt           sql.NullTime
 			username           sql.NullString
 			firstName          sql.NullString
 			lastName           sql.NullString
@@ -146,6 +190,7 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 			totalReactions     int64
 			totalComments      int64
 			totalPostShares    int64
+			pinned             bool
 		)
 
 		err := rows.Scan(
@@ -165,6 +210,7 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 			&pollQuestion,
 			&pollDurationType,
 			&pollDurationLength,
+			&editedAt,
 			&username,
 			&firstName,
 			&lastName,
@@ -173,6 +219,7 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 			&totalReactions,
 			&totalComments,
 			&totalPostShares,
+			&pinned,
 		)
 		if err != nil {
 			log.Println("Scan rows error: ", err)
@@ -189,6 +236,8 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 		p.PollQuestion = util.SqlNullStringToPtr(pollQuestion)
 		p.PollDurationType = util.SqlNullStringToPtr(pollDurationType)
 		p.PollDurationLength = util.SqlNullInt64ToPtr(pollDurationLength)
+		p.Edited = editedAt.Valid
+		p.EditedAt = util.SqlNullTimeToPtr(editedAt)
 		p.Username = util.SqlNullStringToPtr(username)
 		p.FirstName = util.SqlNullStringToPtr(firstName)
 		p.LastName = util.SqlNullStringToPtr(lastName)
@@ -197,6 +246,7 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 		p.TotalReactions = totalReactions
 		p.TotalComments = totalComments
 		p.TotalPostShares = totalPostShares
+		p.Pinned = pinned
 		listPosts = append(listPosts, p)
 	}
 
@@ -204,6 +254,34 @@ func listPosts(userID, limit, page int64) (models.ListPostsResponse, error) {
 		return models.ListPostsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
 	}
 
+	postIDs := make([]int64, len(listPosts))
+	for i, p := range listPosts {
+		postIDs[i] = p.PostID
+	}
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return models.ListPostsResponse{}, err
+	}
+	originals, err := shares.Originals(postIDs, viewerID)
+	if err != nil {
+		return models.ListPostsResponse{}, err
+	}
+	texts := make(map[int64]string, len(listPosts))
+	for _, p := range listPosts {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.ListPostsResponse{}, err
+	}
+	for i := range listPosts {
+		listPosts[i].ReactionCounts = reactionCounts[listPosts[i].PostID]
+		listPosts[i].OriginalPost = originals[listPosts[i].PostID]
+		listPosts[i].LinkPreviews = linkPreviews[listPosts[i].PostID]
+	}
+
 	totalPages := int64(math.Ceil(float64(totalPosts) / float64(limit)))
 	return models.ListPostsResponse{
 		Posts:      listPosts,
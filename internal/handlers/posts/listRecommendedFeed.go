@@ -1,9 +1,15 @@
 package handlers
 
 import (
+	"VoizyServer/internal/audience"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
 	"VoizyServer/internal/util"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -21,15 +27,13 @@ func ListRecommendedFeedHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userIDString := r.URL.Query().Get("id")
-	if userIDString == "" {
-		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
 		return
 	}
-	userID, err := strconv.ParseInt(userIDString, 10, 64)
-	if err != nil {
-		log.Println("Failed to convert userIDString (string) to userID (int64): ", err)
-		http.Error(w, "Failed to convert param 'id'.", http.StatusInternalServerError)
+	if userIDString := r.URL.Query().Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
 		return
 	}
 
@@ -77,7 +81,7 @@ func listRecommendedFeed(userID, limit, page int64) (models.ListRecommendedFeedR
 	}
 	friendIDs = append(friendIDs, userID)
 
-	posts, err := fetchCandidatePosts(friendIDs)
+	posts, err := fetchCandidatePosts(userID, friendIDs)
 	if err != nil {
 		return models.ListRecommendedFeedResponse{}, err
 	}
@@ -112,6 +116,27 @@ func listRecommendedFeed(userID, limit, page int64) (models.ListRecommendedFeedR
 		posts = posts[:limit]
 	}
 
+	postIDs := make([]int64, len(posts))
+	for i, p := range posts {
+		postIDs[i] = p.PostID
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.ListRecommendedFeedResponse{}, err
+	}
+	texts := make(map[int64]string, len(posts))
+	for _, p := range posts {
+		texts[p.PostID] = p.ContentText
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.ListRecommendedFeedResponse{}, err
+	}
+	for i := range posts {
+		posts[i].OriginalPost = originals[posts[i].PostID]
+		posts[i].LinkPreviews = linkPreviews[posts[i].PostID]
+	}
+
 	return models.ListRecommendedFeedResponse{
 		Posts:      posts,
 		Limit:      limit,
@@ -149,23 +174,28 @@ func fetchFriendsIDs(userID int64) ([]int64, error) {
 	return friendIDs, nil
 }
 
-func fetchCandidatePosts(friendsIDs []int64) ([]models.RecommendedPost, error) {
+func fetchCandidatePosts(userID int64, friendsIDs []int64) ([]models.RecommendedPost, error) {
 	if len(friendsIDs) == 0 {
 		return []models.RecommendedPost{}, nil
 	}
 
 	inClause := buildInClause(len(friendsIDs))
+	visible, visibleArgs := audience.Visible("p", userID)
 	query := fmt.Sprintf(`
-		SELECT p.post_id, p.user_id, p.content_text, p.created_at, p.views, p.impressions
+		SELECT p.post_id, p.user_id, p.content_text, p.created_at, p.edited_at, p.views, p.impressions
 		FROM posts p
 		WHERE p.created_at >= (NOW() - INTERVAL 7 DAY)
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
 			AND p.user_id IN (%s)
-	`, inClause)
+			AND %s
+	`, inClause, visible)
 
 	args := make([]interface{}, len(friendsIDs))
 	for i, fID := range friendsIDs {
 		args[i] = fID
 	}
+	args = append(args, visibleArgs...)
 
 	rows, err := database.DB.Query(query, args...)
 	if err != nil {
@@ -176,9 +206,12 @@ func fetchCandidatePosts(friendsIDs []int64) ([]models.RecommendedPost, error) {
 	var posts []models.RecommendedPost
 	for rows.Next() {
 		var p models.RecommendedPost
-		if err := rows.Scan(&p.PostID, &p.UserID, &p.ContentText, &p.CreatedAt, &p.Views, &p.Impressions); err != nil {
+		var editedAt sql.NullTime
+		if err := rows.Scan(&p.PostID, &p.UserID, &p.ContentText, &p.CreatedAt, &editedAt, &p.Views, &p.Impressions); err != nil {
 			return nil, err
 		}
+		p.Edited = editedAt.Valid
+		p.EditedAt = util.SqlNullTimeToPtr(editedAt)
 		posts = append(posts, p)
 	}
 	if err := rows.Err(); err != nil {
@@ -231,6 +264,14 @@ func fillReactionAndCommentCounts(posts []models.RecommendedPost) error {
 		return err
 	}
 
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return err
+	}
+	for i := range posts {
+		posts[i].ReactionCounts = reactionCounts[posts[i].PostID]
+	}
+
 	queryComments := fmt.Sprintf(`
 		SELECT post_id, COUNT(*) as cnt
 		FROM comments
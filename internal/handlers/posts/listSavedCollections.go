@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func ListSavedCollectionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	response, err := listSavedCollections(userID)
+	if err != nil {
+		log.Println("Failed to list collections due to the following error: ", err)
+		http.Error(w, "Failed to list collections.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// listSavedCollections lists the user's collections in the order they arranged them.
+func listSavedCollections(userID int64) (models.ListSavedCollectionsResponse, error) {
+	rows, err := database.DB.Query(`
+		SELECT
+			sc.collection_id,
+			sc.name,
+			sc.position,
+			(SELECT COUNT(*) FROM saved_posts s WHERE s.collection_id = sc.collection_id) AS total_posts,
+			sc.created_at,
+			sc.updated_at
+		FROM saved_collections sc
+		WHERE sc.user_id = ?
+		ORDER BY sc.position ASC, sc.collection_id ASC
+	`, userID)
+	if err != nil {
+		return models.ListSavedCollectionsResponse{}, fmt.Errorf("failed to select collections: %w", err)
+	}
+	defer rows.Close()
+
+	collections := []models.SavedCollection{}
+	for rows.Next() {
+		var c models.SavedCollection
+		var createdAt, updatedAt sql.NullTime
+		if err := rows.Scan(&c.CollectionID, &c.Name, &c.Position, &c.TotalPosts, &createdAt, &updatedAt); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		c.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		c.UpdatedAt = util.SqlNullTimeToPtr(updatedAt)
+		collections = append(collections, c)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListSavedCollectionsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return models.ListSavedCollectionsResponse{Collections: collections}, nil
+}
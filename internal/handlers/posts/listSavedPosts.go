@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/shares"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListSavedPostsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := r.URL.Query().Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	var collectionID *int64
+	if collectionIDString := r.URL.Query().Get("collectionID"); collectionIDString != "" {
+		id, err := strconv.ParseInt(collectionIDString, 10, 64)
+		if err != nil {
+			log.Println("Failed to parse collectionIDString (string) to collectionID (int64) due to the following error: ", err)
+			http.Error(w, "Failed to parse param 'collectionID'.", http.StatusInternalServerError)
+			return
+		}
+		collectionID = &id
+	}
+
+	response, err := listSavedPosts(userID, collectionID, limit, page)
+	if err != nil {
+		log.Println("Failed to list saved posts due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to list saved posts (%v).", err), statusForSavedError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// listSavedPosts lists the user's saved posts, most recently saved first, either all
+// of them or those in one collection. Saved posts the user can no longer see, because
+// they were deleted, their audience changed or their author is now blocked, are left
+// out but stay saved in case they come back.
+func listSavedPosts(userID int64, collectionID *int64, limit, page int64) (models.ListSavedPostsResponse, error) {
+	if collectionID != nil {
+		if err := checkCollection(userID, *collectionID); err != nil {
+			return models.ListSavedPostsResponse{}, err
+		}
+	}
+
+	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("p.user_id", userID)
+	visible, visibleArgs := audience.Visible("p", userID)
+
+	filter := `
+		s.user_id = ?
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND ` + notBlocked + `
+			AND ` + visible
+	filterArgs := []interface{}{userID}
+	filterArgs = append(filterArgs, blockArgs...)
+	filterArgs = append(filterArgs, visibleArgs...)
+	if collectionID != nil {
+		filter += ` AND s.collection_id = ?`
+		filterArgs = append(filterArgs, *collectionID)
+	}
+
+	var totalPosts int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM saved_posts s
+		JOIN posts p ON p.post_id = s.post_id
+		WHERE ` + filter
+	if err := database.DB.QueryRow(countQuery, filterArgs...).Scan(&totalPosts); err != nil {
+		return models.ListSavedPostsResponse{}, fmt.Errorf("failed to get totalPosts: %w", err)
+	}
+
+	query := `
+		SELECT ` + recommendedFeedPostColumns + `
+		FROM saved_posts s
+		JOIN posts p ON p.post_id = s.post_id
+		` + recommendedFeedPostJoins + `
+		WHERE ` + filter + `
+		ORDER BY s.saved_at DESC, s.saved_post_id DESC
+		LIMIT ? OFFSET ?
+	`
+	args := []interface{}{userID}
+	args = append(args, filterArgs...)
+	args = append(args, limit, offset)
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return models.ListSavedPostsResponse{}, fmt.Errorf("failed to select saved posts: %w", err)
+	}
+	defer rows.Close()
+
+	var savedPosts []models.RecommendedFeedPost
+	for rows.Next() {
+		p, err := scanRecommendedFeedPost(rows)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		savedPosts = append(savedPosts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListSavedPostsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	postIDs := make([]int64, len(savedPosts))
+	for i, p := range savedPosts {
+		postIDs[i] = p.PostID
+	}
+	reactionCounts, err := reactions.PostCounts(postIDs)
+	if err != nil {
+		return models.ListSavedPostsResponse{}, err
+	}
+	originals, err := shares.Originals(postIDs, userID)
+	if err != nil {
+		return models.ListSavedPostsResponse{}, err
+	}
+	texts := make(map[int64]string, len(savedPosts))
+	for _, p := range savedPosts {
+		if p.ContentText != nil {
+			texts[p.PostID] = *p.ContentText
+		}
+	}
+	linkPreviews, err := linkpreview.ForTexts(texts)
+	if err != nil {
+		return models.ListSavedPostsResponse{}, err
+	}
+	for i := range savedPosts {
+		savedPosts[i].ReactionCounts = reactionCounts[savedPosts[i].PostID]
+		savedPosts[i].OriginalPost = originals[savedPosts[i].PostID]
+		savedPosts[i].LinkPreviews = linkPreviews[savedPosts[i].PostID]
+	}
+
+	totalPages := int64(math.Ceil(float64(totalPosts) / float64(limit)))
+	return models.ListSavedPostsResponse{
+		SavedPosts: savedPosts,
+		Limit:      limit,
+		Page:       page,
+		TotalPosts: totalPosts,
+		TotalPages: totalPages,
+	}, nil
+}
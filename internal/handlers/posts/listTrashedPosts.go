@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func ListTrashedPostsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	limitString := r.URL.Query().Get("limit")
+	if limitString == "" {
+		http.Error(w, "Missing required param 'limit'.", http.StatusBadRequest)
+		return
+	}
+	limit, err := strconv.ParseInt(limitString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse limitString (string) to limit (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'limit'.", http.StatusInternalServerError)
+		return
+	}
+
+	pageString := r.URL.Query().Get("page")
+	if pageString == "" {
+		http.Error(w, "Missing required param 'page'.", http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(pageString, 10, 64)
+	if err != nil {
+		log.Println("Failed to parse pageString (string) to page (int64) due to the following error: ", err)
+		http.Error(w, "Failed to parse param 'page'.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := listTrashedPosts(userID, limit, page)
+	if err != nil {
+		log.Println("Failed to list trashed posts due to the following error: ", err)
+		http.Error(w, "Failed to list trashed posts.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// listTrashedPosts lists the user's deleted posts that can still be restored, most
+// recently deleted first.
+func listTrashedPosts(userID, limit, page int64) (models.ListTrashedPostsResponse, error) {
+	offset := (page - 1) * limit
+
+	var totalPosts int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM posts
+		WHERE user_id = ?
+			AND deleted_at > DATE_SUB(NOW(), INTERVAL ? DAY)
+	`
+	err := database.DB.QueryRow(countQuery, userID, trashRetentionDays).Scan(&totalPosts)
+	if err != nil {
+		return models.ListTrashedPostsResponse{}, fmt.Errorf("failed to get totalPosts: %w", err)
+	}
+
+	query := `
+		SELECT
+			post_id,
+			to_user_id,
+			original_post_id,
+			group_id,
+			content_text,
+			is_poll,
+			created_at,
+			deleted_at,
+			DATE_ADD(deleted_at, INTERVAL ? DAY) AS purge_at
+		FROM posts
+		WHERE user_id = ?
+			AND deleted_at > DATE_SUB(NOW(), INTERVAL ? DAY)
+		ORDER BY deleted_at DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(query, trashRetentionDays, userID, trashRetentionDays, limit, offset)
+	if err != nil {
+		return models.ListTrashedPostsResponse{}, fmt.Errorf("failed to select trashed posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []models.TrashedPost
+	for rows.Next() {
+		var p models.TrashedPost
+		var (
+			originalPostID sql.NullInt64
+			groupID        sql.NullInt64
+			contentText    sql.NullString
+			isPoll         sql.NullBool
+			createdAt      sql.NullTime
+		)
+		err := rows.Scan(
+			&p.PostID,
+			&p.ToUserID,
+			&originalPostID,
+			&groupID,
+			&contentText,
+			&isPoll,
+			&createdAt,
+			&p.DeletedAt,
+			&p.PurgeAt,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		p.OriginalPostID = util.SqlNullInt64ToPtr(originalPostID)
+		p.GroupID = util.SqlNullInt64ToPtr(groupID)
+		p.ContentText = util.SqlNullStringToPtr(contentText)
+		p.IsPoll = util.SqlNullBoolToPtr(isPoll)
+		p.CreatedAt = util.SqlNullTimeToPtr(createdAt)
+		posts = append(posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListTrashedPostsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalPosts) / float64(limit)))
+	return models.ListTrashedPostsResponse{
+		Posts:      posts,
+		Limit:      limit,
+		Page:       page,
+		TotalPosts: totalPosts,
+		TotalPages: totalPages,
+	}, nil
+}
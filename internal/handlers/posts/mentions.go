@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/notifications"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// mentionPattern matches @username where the '@' starts the text or follows a
+// character that cannot be part of a word or email address, so "a@b.com" is not
+// a mention.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])(@([\p{L}\p{N}_.]{1,50}))`)
+
+type mentionMatch struct {
+	username string
+	start    int
+	end      int
+}
+
+// parseMentions finds every @username in text with rune offsets. A trailing '.'
+// is treated as punctuation rather than part of the username.
+func parseMentions(text string) []mentionMatch {
+	var matches []mentionMatch
+	for _, loc := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, nameStart, nameEnd := loc[2], loc[4], loc[5]
+		username := strings.TrimRight(text[nameStart:nameEnd], ".")
+		if username == "" {
+			continue
+		}
+		end := nameStart + len(username)
+		matches = append(matches, mentionMatch{
+			username: username,
+			start:    utf8.RuneCountInString(text[:start]),
+			end:      utf8.RuneCountInString(text[:end]),
+		})
+	}
+	return matches
+}
+
+// saveMentions replaces the stored mentions of a post, or of a comment when
+// commentID is set, with the ones in text. Usernames that do not exist and users
+// in a block with the author are skipped. It returns the spans that resolved and
+// the users who were not mentioned in the previous version, who should be notified.
+func saveMentions(tx *sql.Tx, postID int64, commentID *int64, authorID int64, text string) ([]models.MentionSpan, []int64, error) {
+	objectFilter := "post_id = ? AND comment_id IS NULL"
+	objectArgs := []interface{}{postID}
+	if commentID != nil {
+		objectFilter = "comment_id = ?"
+		objectArgs = []interface{}{*commentID}
+	}
+
+	previous := make(map[int64]bool)
+	rows, err := tx.Query(`SELECT mentioned_user_id FROM mentions WHERE `+objectFilter, objectArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get previous mentions: %w", err)
+	}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("failed to scan previous mention: %w", err)
+		}
+		previous[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate over previous mentions: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM mentions WHERE `+objectFilter, objectArgs...); err != nil {
+		return nil, nil, fmt.Errorf("failed to delete previous mentions: %w", err)
+	}
+
+	matches := parseMentions(text)
+	if len(matches) == 0 {
+		return []models.MentionSpan{}, nil, nil
+	}
+
+	users, err := resolveMentionedUsers(tx, matches, authorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spans := []models.MentionSpan{}
+	var newlyMentioned []int64
+	notified := make(map[int64]bool)
+	for _, m := range matches {
+		user, ok := users[strings.ToLower(m.username)]
+		if !ok {
+			continue
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO mentions (post_id, comment_id, mentioned_user_id, mentioner_id, start_offset, end_offset)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, postID, commentID, user.UserID, authorID, m.start, m.end)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to insert mention: %w", err)
+		}
+
+		spans = append(spans, models.MentionSpan{
+			UserID:   user.UserID,
+			Username: user.Username,
+			Start:    m.start,
+			End:      m.end,
+		})
+		if !previous[user.UserID] && !notified[user.UserID] && user.UserID != authorID {
+			notified[user.UserID] = true
+			newlyMentioned = append(newlyMentioned, user.UserID)
+		}
+	}
+
+	return spans, newlyMentioned, nil
+}
+
+// resolveMentionedUsers looks the matched usernames up, keyed by lowercase
+// username, leaving out anyone in a block with authorID.
+func resolveMentionedUsers(tx *sql.Tx, matches []mentionMatch, authorID int64) (map[string]models.MentionSpan, error) {
+	seen := make(map[string]bool)
+	placeholders := []string{}
+	args := []interface{}{}
+	for _, m := range matches {
+		key := strings.ToLower(m.username)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		placeholders = append(placeholders, "?")
+		args = append(args, m.username)
+	}
+
+	rows, err := tx.Query(`SELECT user_id, username FROM users WHERE username IN (`+strings.Join(placeholders, ",")+`)`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mentioned users: %w", err)
+	}
+	var found []models.MentionSpan
+	for rows.Next() {
+		var u models.MentionSpan
+		if err := rows.Scan(&u.UserID, &u.Username); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan mentioned user: %w", err)
+		}
+		found = append(found, u)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over mentioned users: %w", err)
+	}
+
+	users := make(map[string]models.MentionSpan, len(found))
+	for _, u := range found {
+		blocked, err := blocks.IsBlocked(authorID, u.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !blocked {
+			users[strings.ToLower(u.Username)] = u
+		}
+	}
+
+	return users, nil
+}
+
+// notifyMentioned tells each newly mentioned user about the post or comment, as
+// long as they are in the post's audience. Nobody hears about mentions in a draft
+// until it is published.
+func notifyMentioned(userIDs []int64, authorID, postID int64, commentID *int64) {
+	if len(userIDs) == 0 {
+		return
+	}
+	if _, err := getPostAuthorID(postID); err != nil {
+		if err != errPostNotFound {
+			log.Println("Failed to notify mentioned users due to the following error: ", err)
+		}
+		return
+	}
+
+	n := notifications.Notification{
+		ActorID:    authorID,
+		Type:       notifications.TypePostMention,
+		ObjectType: "post",
+		ObjectID:   postID,
+	}
+	if commentID != nil {
+		n.Type = notifications.TypeCommentMention
+		n.ObjectType = "comment"
+		n.ObjectID = *commentID
+	}
+
+	for _, userID := range userIDs {
+		visible, err := audience.CanView(postID, userID)
+		if err != nil {
+			log.Println("Failed to check post audience due to the following error: ", err)
+			continue
+		}
+		if !visible {
+			continue
+		}
+		n.RecipientID = userID
+		notifications.Send(n)
+	}
+}
+
+// loadMentionSpans returns the stored spans for a page of posts or comments, keyed
+// by post id, or by comment id when forComments is set.
+func loadMentionSpans(ids []int64, forComments bool) (map[int64][]models.MentionSpan, error) {
+	spans := make(map[int64][]models.MentionSpan)
+	if len(ids) == 0 {
+		return spans, nil
+	}
+
+	keyColumn, objectFilter := "m.post_id", "m.comment_id IS NULL"
+	if forComments {
+		keyColumn, objectFilter = "m.comment_id", "m.comment_id IS NOT NULL"
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := `
+		SELECT ` + keyColumn + `, m.mentioned_user_id, u.username, m.start_offset, m.end_offset
+		FROM mentions m
+		JOIN users u ON u.user_id = m.mentioned_user_id
+		WHERE ` + keyColumn + ` IN (` + strings.Join(placeholders, ",") + `)
+			AND ` + objectFilter + `
+		ORDER BY m.start_offset ASC
+	`
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mentions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id   int64
+			span models.MentionSpan
+		)
+		if err := rows.Scan(&id, &span.UserID, &span.Username, &span.Start, &span.End); err != nil {
+			return nil, fmt.Errorf("failed to scan mention: %w", err)
+		}
+		spans[id] = append(spans[id], span)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over mentions: %w", err)
+	}
+
+	return spans, nil
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"VoizyServer/internal/notifications"
+	"log"
+)
+
+// notifyPostAuthor tells the author of postID about activity by actorID on it.
+func notifyPostAuthor(postID, actorID int64, notificationType string) {
+	authorID, err := getPostAuthorID(postID)
+	if err != nil {
+		log.Println("Failed to notify post author due to the following error: ", err)
+		return
+	}
+
+	notifications.Send(notifications.Notification{
+		RecipientID: authorID,
+		ActorID:     actorID,
+		Type:        notificationType,
+		ObjectType:  "post",
+		ObjectID:    postID,
+	})
+}
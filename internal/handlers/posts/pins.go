@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxPinnedPosts is how many posts a user can pin to the top of their profile.
+const maxPinnedPosts = 3
+
+var (
+	errTooManyPins       = errors.New("user already has the maximum number of pinned posts")
+	errPostNotPinnable   = errors.New("only published profile posts can be pinned")
+	errPostNotPinned     = errors.New("post is not pinned")
+	errInvalidPinOrder   = errors.New("postIDs must list each of the user's pinned posts exactly once")
+	errInvalidAudience   = errors.New("invalid audience")
+	errAudienceNeedsList = errors.New("a custom audience needs an audienceListID")
+)
+
+// unpinPost removes any pin on the post and reports whether there was one. It is
+// called whenever who can see a post changes, so a pin never outlives the audience
+// it was made for.
+func unpinPost(tx *sql.Tx, postID int64) (bool, error) {
+	result, err := tx.Exec(`DELETE FROM pinned_posts WHERE post_id = ?`, postID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpin post: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func statusForPinError(err error) int {
+	switch {
+	case errors.Is(err, errPostNotPinned):
+		return http.StatusNotFound
+	case errors.Is(err, errPostForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, errTooManyPins), errors.Is(err, errPostNotPinnable):
+		return http.StatusConflict
+	case errors.Is(err, errInvalidPinOrder), errors.Is(err, errInvalidAudience), errors.Is(err, errAudienceNeedsList):
+		return http.StatusBadRequest
+	}
+	return statusForPostAccessError(err)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/util"
+	"fmt"
+	"log"
+	"time"
+)
+
+// StartPollCloser periodically closes polls whose poll_end_datetime has passed.
+// It blocks, so it should be started in its own goroutine.
+func StartPollCloser(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := closeExpiredPolls(); err != nil {
+			log.Println("Failed to close expired polls due to the following error: ", err)
+		}
+		<-ticker.C
+	}
+}
+
+type expiredPoll struct {
+	postID int64
+	userID int64
+}
+
+func closeExpiredPolls() error {
+	// Polls created before poll_end_datetime was computed get it derived from created_at.
+	_, err := database.DB.Exec(`
+		UPDATE posts
+		SET poll_end_datetime = CASE poll_duration_type
+			WHEN 'hours' THEN DATE_ADD(created_at, INTERVAL COALESCE(poll_duration_length, 1) HOUR)
+			WHEN 'weeks' THEN DATE_ADD(created_at, INTERVAL COALESCE(poll_duration_length, 1) WEEK)
+			ELSE DATE_ADD(created_at, INTERVAL COALESCE(poll_duration_length, 1) DAY)
+		END,
+		updated_at = updated_at
+		WHERE is_poll = 1 AND poll_end_datetime IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill poll_end_datetime: %w", err)
+	}
+
+	rows, err := database.DB.Query(`
+		SELECT post_id, user_id
+		FROM posts
+		WHERE is_poll = 1 AND poll_closed = 0 AND poll_end_datetime <= NOW()
+			AND status = 'published'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to select expired polls: %w", err)
+	}
+	defer rows.Close()
+
+	var polls []expiredPoll
+	for rows.Next() {
+		var p expiredPoll
+		if err := rows.Scan(&p.postID, &p.userID); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		polls = append(polls, p)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	for _, p := range polls {
+		if err := closePoll(p); err != nil {
+			log.Println("Failed to close poll due to the following error: ", err)
+		}
+	}
+
+	return nil
+}
+
+// closePoll flips poll_closed with a conditional update, so when several API
+// nodes run the closer only the one that actually closed the poll emits the event.
+func closePoll(p expiredPoll) error {
+	result, err := database.DB.Exec(`
+		UPDATE posts
+		SET poll_closed = 1, updated_at = updated_at
+		WHERE post_id = ? AND poll_closed = 0
+	`, p.postID)
+	if err != nil {
+		return fmt.Errorf("failed to close poll %d: %w", p.postID, err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	var totalVotes int64
+	err = database.DB.QueryRow(`SELECT COUNT(*) FROM poll_votes WHERE post_id = ?`, p.postID).Scan(&totalVotes)
+	if err != nil {
+		return fmt.Errorf("failed to count votes for poll %d: %w", p.postID, err)
+	}
+
+	postID := p.postID
+	return util.TrackEvent(p.userID, "poll_closed", "post", &postID, map[string]interface{}{
+		"total_votes": totalVotes,
+	})
+}
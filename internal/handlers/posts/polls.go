@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	errPollNotFound       = errors.New("poll not found")
+	errPollClosed         = errors.New("poll is closed")
+	errPollOptionNotFound = errors.New("poll option does not belong to this poll")
+	errPollVoteNotFound   = errors.New("user has not voted in this poll")
+)
+
+// pollDurationHours converts the poll duration the client picked into hours so that
+// poll_end_datetime can be computed by MySQL relative to its own NOW().
+func pollDurationHours(durationType string, durationLength int64) (int64, error) {
+	if durationLength <= 0 {
+		return 0, fmt.Errorf("poll duration length must be positive, got %d", durationLength)
+	}
+	switch durationType {
+	case "hours":
+		return durationLength, nil
+	case "days":
+		return durationLength * 24, nil
+	case "weeks":
+		return durationLength * 24 * 7, nil
+	default:
+		return 0, fmt.Errorf("invalid poll duration type %q", durationType)
+	}
+}
+
+// lockOpenPoll locks the poll's post row for the rest of the transaction and
+// fails if the post is not a poll, or the poll has closed or run past its end.
+func lockOpenPoll(tx *sql.Tx, postID int64) error {
+	var isPoll, isClosed bool
+	query := `
+		SELECT
+			is_poll,
+			(poll_closed = 1 OR (poll_end_datetime IS NOT NULL AND poll_end_datetime <= NOW())) AS is_closed
+		FROM posts
+		WHERE post_id = ?
+		FOR UPDATE
+	`
+	err := tx.QueryRow(query, postID).Scan(&isPoll, &isClosed)
+	if err == sql.ErrNoRows {
+		return errPollNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get poll: %w", err)
+	}
+	if !isPoll {
+		return errPollNotFound
+	}
+	if isClosed {
+		return errPollClosed
+	}
+
+	return nil
+}
+
+func adjustPollOptionVoteCount(tx *sql.Tx, pollOptionID int64, delta int) error {
+	_, err := tx.Exec(`
+		UPDATE poll_options
+		SET vote_count = GREATEST(COALESCE(vote_count, 0) + ?, 0)
+		WHERE poll_option_id = ?
+	`, delta, pollOptionID)
+	if err != nil {
+		return fmt.Errorf("failed to update poll option vote count: %w", err)
+	}
+
+	return nil
+}
+
+func statusForPollError(err error) int {
+	switch {
+	case errors.Is(err, errPollNotFound), errors.Is(err, errPollVoteNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errPollOptionNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, errPollClosed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
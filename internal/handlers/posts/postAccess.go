@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	errPostNotFound    = errors.New("post not found")
+	errCommentNotFound = errors.New("comment not found")
+)
+
+func getPostAuthorID(postID int64) (int64, error) {
+	var authorID int64
+	err := database.DB.QueryRow(`SELECT user_id FROM posts WHERE post_id = ? AND deleted_at IS NULL AND status = 'published'`, postID).Scan(&authorID)
+	if err == sql.ErrNoRows {
+		return 0, errPostNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get post author: %w", err)
+	}
+
+	return authorID, nil
+}
+
+// checkPostVisible fails with blocks.ErrBlocked when userID and the post's author
+// have blocked each other, and with audience.ErrNotVisible when userID is not in the
+// post's audience, so neither can read or interact with the post.
+func checkPostVisible(postID, userID int64) error {
+	authorID, err := getPostAuthorID(postID)
+	if err != nil {
+		return err
+	}
+	if err := blocks.Check(userID, authorID); err != nil {
+		return err
+	}
+	return audience.Check(postID, userID)
+}
+
+// checkCommentVisible fails with blocks.ErrBlocked when userID is blocked with
+// either the comment's author or the author of the post it was left on, and with
+// audience.ErrNotVisible when userID is not in the post's audience.
+func checkCommentVisible(commentID, userID int64) error {
+	var postID, commentAuthorID, postAuthorID int64
+	err := database.DB.QueryRow(`
+		SELECT p.post_id, c.user_id, p.user_id
+		FROM comments c
+		JOIN posts p ON p.post_id = c.post_id
+		WHERE c.comment_id = ? AND p.deleted_at IS NULL AND p.status = 'published'
+	`, commentID).Scan(&postID, &commentAuthorID, &postAuthorID)
+	if err == sql.ErrNoRows {
+		return errCommentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get comment author: %w", err)
+	}
+
+	if err := blocks.Check(userID, commentAuthorID); err != nil {
+		return err
+	}
+	if err := blocks.Check(userID, postAuthorID); err != nil {
+		return err
+	}
+	return audience.Check(postID, userID)
+}
+
+func statusForPostAccessError(err error) int {
+	if errors.Is(err, errPostNotFound) || errors.Is(err, errCommentNotFound) ||
+		errors.Is(err, audience.ErrNotVisible) || errors.Is(err, audience.ErrListNotFound) {
+		return http.StatusNotFound
+	}
+	return blocks.HTTPStatus(err)
+}
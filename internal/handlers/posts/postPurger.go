@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/storage"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// purgeBatchSize caps how many posts one purge pass removes, so a backlog of
+// expired posts is worked off over several passes instead of one long one.
+const purgeBatchSize = 100
+
+// purgeObjectTimeout bounds the storage calls made to remove one media object, so
+// an unresponsive store cannot stall the purger.
+const purgeObjectTimeout = 30 * time.Second
+
+// StartPostPurger periodically hard-deletes posts that have been in the trash for
+// longer than trashRetentionDays. It blocks, so it should be started in its own
+// goroutine.
+func StartPostPurger(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := purgeExpiredPosts(context.Background()); err != nil {
+			log.Println("Failed to purge expired posts due to the following error: ", err)
+		}
+		<-ticker.C
+	}
+}
+
+func purgeExpiredPosts(ctx context.Context) error {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT post_id
+		FROM posts
+		WHERE deleted_at <= DATE_SUB(NOW(), INTERVAL ? DAY)
+		ORDER BY deleted_at ASC
+		LIMIT ?
+	`, trashRetentionDays, purgeBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to select expired posts: %w", err)
+	}
+	defer rows.Close()
+
+	var postIDs []int64
+	for rows.Next() {
+		var postID int64
+		if err := rows.Scan(&postID); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		postIDs = append(postIDs, postID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	for _, postID := range postIDs {
+		if err := purgePost(ctx, postID); err != nil {
+			log.Println("Failed to purge post due to the following error: ", err)
+		}
+	}
+
+	return nil
+}
+
+// purgePost removes a trashed post's media from S3 and then the post with
+// everything hanging off it. Media is removed first so a failure leaves the post in
+// the trash to be retried on the next pass rather than orphaning the objects.
+func purgePost(ctx context.Context, postID int64) error {
+	mediaURLs, err := getPostMediaURLs(ctx, postID)
+	if err != nil {
+		return err
+	}
+	for _, mediaURL := range mediaURLs {
+		if err := deleteMediaObject(ctx, mediaURL); err != nil {
+			return fmt.Errorf("failed to delete media for post %d: %w", postID, err)
+		}
+	}
+
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// Notifications point at their object by id without a foreign key, so the ones
+	// about the post and its comments go first, while the comments still exist.
+	// Comments are deleted deepest first so replies never outlive their parent.
+	queries := []string{
+		`DELETE n FROM notifications n
+		JOIN comments c ON c.comment_id = n.object_id
+		WHERE n.object_type = 'comment' AND c.post_id = ?`,
+		`DELETE FROM notifications WHERE object_type = 'post' AND object_id = ?`,
+		`DELETE FROM comments WHERE post_id = ? ORDER BY depth DESC`,
+		`DELETE FROM post_reactions WHERE post_id = ?`,
+		`DELETE FROM post_shares WHERE post_id = ?`,
+		`DELETE FROM post_shares WHERE share_post_id = ?`,
+		`DELETE FROM saved_posts WHERE post_id = ?`,
+		`DELETE FROM pinned_posts WHERE post_id = ?`,
+		`DELETE FROM post_media WHERE post_id = ?`,
+		`DELETE rm FROM post_revision_media rm
+		JOIN post_revisions r ON r.revision_id = rm.revision_id
+		WHERE r.post_id = ?`,
+		`DELETE FROM post_revisions WHERE post_id = ?`,
+		`DELETE FROM posts WHERE post_id = ? AND deleted_at IS NOT NULL`,
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query, postID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to purge post %d: %w", postID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit purge of post %d: %w", postID, err)
+	}
+
+	log.Printf("Purged post %d and %d media objects", postID, len(mediaURLs))
+	return nil
+}
+
+// getPostMediaURLs returns the post's media along with media that only earlier
+// revisions still point at.
+func getPostMediaURLs(ctx context.Context, postID int64) ([]string, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT media_url FROM post_media WHERE post_id = ?
+		UNION
+		SELECT rm.media_url
+		FROM post_revision_media rm
+		JOIN post_revisions r ON r.revision_id = rm.revision_id
+		WHERE r.post_id = ?
+	`, postID, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post media: %w", err)
+	}
+	defer rows.Close()
+
+	var mediaURLs []string
+	for rows.Next() {
+		var mediaURL string
+		if err := rows.Scan(&mediaURL); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		mediaURLs = append(mediaURLs, mediaURL)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return mediaURLs, nil
+}
+
+// deleteMediaObject deletes the stored object behind a media URL handed out by the
+// presigned upload endpoints, giving up after purgeObjectTimeout. URLs pointing
+// anywhere else are left alone.
+func deleteMediaObject(ctx context.Context, mediaURL string) error {
+	key, ok := storage.DefaultStore.KeyFromURL(mediaURL)
+	if !ok {
+		log.Println("Skipping media outside storage: ", mediaURL)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, purgeObjectTimeout)
+	defer cancel()
+
+	if err := imaging.DeleteVariants(ctx, key); err != nil {
+		return err
+	}
+	if err := storage.DefaultStore.Delete(ctx, key); err != nil {
+		return err
+	}
+	_, err := database.DB.ExecContext(ctx, `DELETE FROM uploads WHERE object_key = ?`, key)
+	return err
+}
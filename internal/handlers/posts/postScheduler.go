@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"fmt"
+	"log"
+	"time"
+)
+
+// StartPostScheduler periodically publishes scheduled posts whose publish_at has
+// passed. It blocks, so it should be started in its own goroutine.
+func StartPostScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := publishDuePosts(); err != nil {
+			log.Println("Failed to publish scheduled posts due to the following error: ", err)
+		}
+		<-ticker.C
+	}
+}
+
+// publishDuePosts publishes every scheduled post that is due, including any that
+// fell due while the server was down. publishPost's conditional update keeps a post
+// from being announced twice.
+func publishDuePosts() error {
+	rows, err := database.DB.Query(`
+		SELECT post_id
+		FROM posts
+		WHERE status = 'scheduled' AND publish_at <= NOW() AND deleted_at IS NULL
+		ORDER BY publish_at ASC
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to select due posts: %w", err)
+	}
+	defer rows.Close()
+
+	var postIDs []int64
+	for rows.Next() {
+		var postID int64
+		if err := rows.Scan(&postID); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		postIDs = append(postIDs, postID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	for _, postID := range postIDs {
+		if _, err := publishPost(postID); err != nil {
+			log.Println("Failed to publish scheduled post due to the following error: ", err)
+		}
+	}
+
+	return nil
+}
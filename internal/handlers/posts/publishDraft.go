@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func PublishDraftHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PublishDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.PostID <= 0 {
+		http.Error(w, "Missing required field 'postID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := publishDraft(userID, req.PostID)
+	if err != nil {
+		log.Println("Failed to publish draft due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to publish draft (%v).", err), statusForDraftError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// publishDraft publishes one of the user's drafts or scheduled posts right away.
+// The create_post event is fired by publishPost, as it is for scheduled posts.
+func publishDraft(userID, postID int64) (models.PublishDraftResponse, error) {
+	if err := checkDraft(postID, userID); err != nil {
+		return models.PublishDraftResponse{Success: false, Message: err.Error(), PostID: postID}, err
+	}
+
+	published, err := publishPost(postID)
+	if err != nil {
+		return models.PublishDraftResponse{Success: false, Message: err.Error(), PostID: postID}, err
+	}
+	if !published {
+		return models.PublishDraftResponse{Success: false, Message: errPostPublished.Error(), PostID: postID}, errPostPublished
+	}
+
+	return models.PublishDraftResponse{
+		Success: true,
+		Message: "Successfully published the draft.",
+		PostID:  postID,
+	}, nil
+}
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
 	"VoizyServer/internal/util"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -23,6 +26,28 @@ func PutCommentReactionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if req.UserID != 0 && req.UserID != userID {
+		http.Error(w, "Field 'userID' does not match the authenticated user.", http.StatusForbidden)
+		return
+	}
+	req.UserID = userID
+
+	if !reactions.Valid(req.ReactionType) {
+		http.Error(w, fmt.Sprintf("Invalid reaction type '%s'.", req.ReactionType), http.StatusBadRequest)
+		return
+	}
+
+	if err := checkCommentVisible(req.CommentID, req.UserID); err != nil {
+		log.Println("Failed to check comment access due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to put reaction to comment (%v).", err), statusForPostAccessError(err))
+		return
+	}
+
 	response, err := putCommentReaction(req)
 	if err != nil {
 		log.Println("Failed to put reaction to comment due to the following error: ", err)
@@ -40,25 +65,75 @@ func PutCommentReactionHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// putCommentReaction leaves the user's reaction on a comment, mirroring post
+// reactions: reacting again with the same type removes the reaction and reacting
+// with another type replaces it.
 func putCommentReaction(req models.PutCommentReactionRequest) (models.PutCommentReactionResponse, error) {
-	query := `
-		INSERT INTO comment_reactions
-		(comment_id, user_id, reaction_type)
-		VALUES
-		(?, ?, ?)
-	`
-	result, err := database.DB.Exec(query, req.CommentID, req.UserID, req.ReactionType)
-	if err != nil {
+	var (
+		existingID   int64
+		existingType string
+	)
+
+	err := database.DB.QueryRow(`
+		SELECT comment_reaction_id, reaction_type
+		FROM comment_reactions
+		WHERE comment_id = ? AND user_id = ?
+	`, req.CommentID, req.UserID).Scan(&existingID, &existingType)
+	if err != nil && err != sql.ErrNoRows {
 		return models.PutCommentReactionResponse{
 			Success: false,
-			Message: fmt.Sprintf("Failed to execute query due to the following error: %v", err),
+			Message: fmt.Sprintf("error checking existing reaction: %v", err),
 		}, err
 	}
-	reactionID, _ := result.LastInsertId()
 
+	if err == sql.ErrNoRows {
+		result, err := database.DB.Exec(`
+			INSERT INTO comment_reactions (comment_id, user_id, reaction_type)
+			VALUES (?, ?, ?)
+		`, req.CommentID, req.UserID, req.ReactionType)
+		if err != nil {
+			return models.PutCommentReactionResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to execute query due to the following error: %v", err),
+			}, err
+		}
+		reactionID, _ := result.LastInsertId()
+		return models.PutCommentReactionResponse{
+			Success:           true,
+			Message:           "Reaction added",
+			CommentReactionID: reactionID,
+		}, nil
+	}
+
+	if existingType == req.ReactionType {
+		_, err := database.DB.Exec(`DELETE FROM comment_reactions WHERE comment_reaction_id = ?`, existingID)
+		if err != nil {
+			return models.PutCommentReactionResponse{
+				Success: false,
+				Message: fmt.Sprintf("error removing reaction: %v", err),
+			}, err
+		}
+		return models.PutCommentReactionResponse{
+			Success:           true,
+			Message:           "Reaction removed",
+			CommentReactionID: existingID,
+		}, nil
+	}
+
+	_, err = database.DB.Exec(`
+		UPDATE comment_reactions
+		SET reaction_type = ?
+		WHERE comment_reaction_id = ?
+	`, req.ReactionType, existingID)
+	if err != nil {
+		return models.PutCommentReactionResponse{
+			Success: false,
+			Message: fmt.Sprintf("error updating reaction: %v", err),
+		}, err
+	}
 	return models.PutCommentReactionResponse{
 		Success:           true,
-		Message:           "Successfully put reaction on post",
-		CommentReactionID: reactionID,
+		Message:           "Reaction updated",
+		CommentReactionID: existingID,
 	}, nil
 }
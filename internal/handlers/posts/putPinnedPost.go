@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func PutPinnedPostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PutPinnedPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.PostID <= 0 {
+		http.Error(w, "Missing required field 'postID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := putPinnedPost(userID, req.PostID)
+	if err != nil {
+		log.Println("Failed to pin post due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to pin post (%v).", err), statusForPinError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "pin_post", "post", &req.PostID, map[string]interface{}{
+		"position": response.Position,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// putPinnedPost pins one of the user's own published profile posts after their
+// existing pins. Pinning a post that is already pinned leaves it where it is.
+func putPinnedPost(userID, postID int64) (models.PutPinnedPostResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.PutPinnedPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+			PostID:  postID,
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// Locking the post keeps it from being deleted or moved out of the profile while
+	// the pin is added.
+	var (
+		authorID int64
+		groupID  sql.NullInt64
+		status   string
+	)
+	err = tx.QueryRow(`
+		SELECT user_id, group_id, status
+		FROM posts
+		WHERE post_id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`, postID).Scan(&authorID, &groupID, &status)
+	if err == sql.ErrNoRows {
+		err = errPostNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.PutPinnedPostResponse{Success: false, Message: err.Error(), PostID: postID}, err
+	}
+	if authorID != userID {
+		tx.Rollback()
+		return models.PutPinnedPostResponse{Success: false, Message: errPostForbidden.Error(), PostID: postID}, errPostForbidden
+	}
+	if groupID.Valid || status != postStatusPublished {
+		tx.Rollback()
+		return models.PutPinnedPostResponse{Success: false, Message: errPostNotPinnable.Error(), PostID: postID}, errPostNotPinnable
+	}
+
+	rows, err := tx.Query(`SELECT post_id, position FROM pinned_posts WHERE user_id = ? FOR UPDATE`, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.PutPinnedPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get pinned posts: %v", err),
+			PostID:  postID,
+		}, err
+	}
+	var (
+		pinned       int64
+		nextPosition int64
+		position     int64 = -1
+	)
+	for rows.Next() {
+		var pinnedPostID, pinnedPosition int64
+		if err := rows.Scan(&pinnedPostID, &pinnedPosition); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return models.PutPinnedPostResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to scan pinned post: %v", err),
+				PostID:  postID,
+			}, err
+		}
+		pinned++
+		if pinnedPosition >= nextPosition {
+			nextPosition = pinnedPosition + 1
+		}
+		if pinnedPostID == postID {
+			position = pinnedPosition
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return models.PutPinnedPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to iterate over pinned posts: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	if position < 0 {
+		if pinned >= maxPinnedPosts {
+			tx.Rollback()
+			return models.PutPinnedPostResponse{Success: false, Message: errTooManyPins.Error(), PostID: postID}, errTooManyPins
+		}
+		position = nextPosition
+		_, err := tx.Exec(`
+			INSERT INTO pinned_posts (user_id, post_id, position)
+			VALUES (?, ?, ?)
+		`, userID, postID, position)
+		if err != nil {
+			tx.Rollback()
+			return models.PutPinnedPostResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to pin post: %v", err),
+				PostID:  postID,
+			}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PutPinnedPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	return models.PutPinnedPostResponse{
+		Success:  true,
+		Message:  "Successfully pinned post.",
+		PostID:   postID,
+		Position: position,
+	}, nil
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func PutPollVoteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PutPollVoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.PostID <= 0 || req.PollOptionID <= 0 {
+		http.Error(w, "Missing required fields 'postID' and 'pollOptionID'.", http.StatusBadRequest)
+		return
+	}
+
+	if err := checkPostVisible(req.PostID, userID); err != nil {
+		log.Println("Failed to check post access due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to put poll vote (%v).", err), statusForPostAccessError(err))
+		return
+	}
+
+	response, err := putPollVote(userID, req)
+	if err != nil {
+		log.Println("Failed to put poll vote due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to put poll vote (%v).", err), statusForPollError(err))
+		return
+	}
+
+	eventType := "vote_poll"
+	if response.PreviousPollOptionID != nil {
+		eventType = "change_poll_vote"
+	}
+	go util.TrackEvent(userID, eventType, "post", &req.PostID, map[string]interface{}{
+		"poll_option_id":          req.PollOptionID,
+		"previous_poll_option_id": response.PreviousPollOptionID,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// putPollVote casts the user's vote, or moves an existing vote to another option.
+// poll_votes is unique on (post_id, user_id), so each user holds at most one vote per poll.
+func putPollVote(userID int64, req models.PutPollVoteRequest) (models.PutPollVoteResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.PutPollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := lockOpenPoll(tx, req.PostID); err != nil {
+		tx.Rollback()
+		return models.PutPollVoteResponse{Success: false, Message: err.Error()}, err
+	}
+
+	var optionPostID int64
+	err = tx.QueryRow(`SELECT post_id FROM poll_options WHERE poll_option_id = ?`, req.PollOptionID).Scan(&optionPostID)
+	if err == sql.ErrNoRows || (err == nil && optionPostID != req.PostID) {
+		tx.Rollback()
+		return models.PutPollVoteResponse{Success: false, Message: errPollOptionNotFound.Error()}, errPollOptionNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.PutPollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get poll option: %v", err),
+		}, err
+	}
+
+	var existingOptionID int64
+	err = tx.QueryRow(`
+		SELECT poll_option_id
+		FROM poll_votes
+		WHERE post_id = ? AND user_id = ?
+		FOR UPDATE
+	`, req.PostID, userID).Scan(&existingOptionID)
+	if err != nil && err != sql.ErrNoRows {
+		tx.Rollback()
+		return models.PutPollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to check existing vote: %v", err),
+		}, err
+	}
+
+	if err == sql.ErrNoRows {
+		_, err = tx.Exec(`
+			INSERT INTO poll_votes (post_id, poll_option_id, user_id)
+			VALUES (?, ?, ?)
+		`, req.PostID, req.PollOptionID, userID)
+		if err != nil {
+			tx.Rollback()
+			return models.PutPollVoteResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to insert poll vote: %v", err),
+			}, err
+		}
+		if err := adjustPollOptionVoteCount(tx, req.PollOptionID, 1); err != nil {
+			tx.Rollback()
+			return models.PutPollVoteResponse{Success: false, Message: err.Error()}, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return models.PutPollVoteResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+			}, err
+		}
+		return models.PutPollVoteResponse{
+			Success: true,
+			Message: "Vote added.",
+		}, nil
+	}
+
+	if existingOptionID == req.PollOptionID {
+		tx.Rollback()
+		return models.PutPollVoteResponse{
+			Success: true,
+			Message: "Vote unchanged.",
+		}, nil
+	}
+
+	_, err = tx.Exec(`
+		UPDATE poll_votes
+		SET poll_option_id = ?, voted_at = NOW()
+		WHERE post_id = ? AND user_id = ?
+	`, req.PollOptionID, req.PostID, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.PutPollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update poll vote: %v", err),
+		}, err
+	}
+	if err := adjustPollOptionVoteCount(tx, existingOptionID, -1); err != nil {
+		tx.Rollback()
+		return models.PutPollVoteResponse{Success: false, Message: err.Error()}, err
+	}
+	if err := adjustPollOptionVoteCount(tx, req.PollOptionID, 1); err != nil {
+		tx.Rollback()
+		return models.PutPollVoteResponse{Success: false, Message: err.Error()}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PutPollVoteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.PutPollVoteResponse{
+		Success:              true,
+		Message:              "Vote changed.",
+		PreviousPollOptionID: &existingOptionID,
+	}, nil
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/notifications"
 	"VoizyServer/internal/util"
 	"encoding/json"
 	"fmt"
@@ -23,30 +25,99 @@ func PutCommentHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := putComment(req)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if req.UserID != 0 && req.UserID != userID {
+		http.Error(w, "Field 'userID' does not match the authenticated user.", http.StatusForbidden)
+		return
+	}
+	req.UserID = userID
+
+	if err := checkPostVisible(req.PostID, req.UserID); err != nil {
+		log.Println("Failed to check post access due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to put comment on post (%v).", err), statusForPostAccessError(err))
+		return
+	}
+
+	var parent *commentParent
+	if req.ParentCommentID != nil {
+		if err := checkCommentVisible(*req.ParentCommentID, req.UserID); err != nil {
+			log.Println("Failed to check comment access due to the following error: ", err)
+			http.Error(w, fmt.Sprintf("Failed to put comment on post (%v).", err), statusForCommentError(err))
+			return
+		}
+		p, err := resolveCommentParent(req.PostID, *req.ParentCommentID)
+		if err != nil {
+			log.Println("Failed to resolve parent comment due to the following error: ", err)
+			http.Error(w, fmt.Sprintf("Failed to put comment on post (%v).", err), statusForCommentError(err))
+			return
+		}
+		parent = &p
+	}
+
+	response, err := putComment(req, parent)
 	if err != nil {
 		log.Println("Failed to put comment on post due to the following error: ", err)
 		http.Error(w, "Failed to put comment on post.", http.StatusInternalServerError)
 		return
 	}
 
+	go notifyPostAuthor(req.PostID, req.UserID, notifications.TypePostComment)
+	if parent != nil {
+		go notifications.Send(notifications.Notification{
+			RecipientID: parent.repliedToUserID,
+			ActorID:     req.UserID,
+			Type:        notifications.TypeCommentReply,
+			ObjectType:  "comment",
+			ObjectID:    *req.ParentCommentID,
+		})
+	}
 	go util.TrackEvent(req.UserID, "comment_on_post", "comment", &response.CommentID, map[string]interface{}{
-		"postID": req.PostID,
+		"postID":          req.PostID,
+		"parentCommentID": response.ParentCommentID,
 	})
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-func putComment(req models.PutCommentRequest) (models.PutCommentResponse, error) {
+func putComment(req models.PutCommentRequest, parent *commentParent) (models.PutCommentResponse, error) {
+	var (
+		parentCommentID *int64
+		depth           int
+	)
+	if parent != nil {
+		parentCommentID = &parent.commentID
+		depth = parent.depth
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.PutCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
 	query := `
 		INSERT INTO comments
-		(post_id, user_id, content_text)
+		(post_id, user_id, parent_comment_id, depth, content_text)
 		VALUES
-		(?, ?, ?)
+		(?, ?, ?, ?, ?)
 	`
-	result, err := database.DB.Exec(query, req.PostID, req.UserID, req.ContentText)
+	result, err := tx.Exec(query, req.PostID, req.UserID, parentCommentID, depth, req.ContentText)
 	if err != nil {
+		tx.Rollback()
 		return models.PutCommentResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to put commont on post due to the following error: %v", err),
@@ -54,9 +125,30 @@ func putComment(req models.PutCommentRequest) (models.PutCommentResponse, error)
 	}
 	commentID, _ := result.LastInsertId()
 
+	mentions, mentionedUserIDs, err := saveMentions(tx, req.PostID, &commentID, req.UserID, req.ContentText)
+	if err != nil {
+		tx.Rollback()
+		return models.PutCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to save mentions due to the following error: %v", err),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PutCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	go notifyMentioned(mentionedUserIDs, req.UserID, req.PostID, &commentID)
+
 	return models.PutCommentResponse{
-		Success:   true,
-		Message:   "Successfully put comment on post.",
-		CommentID: commentID,
+		Success:         true,
+		Message:         "Successfully put comment on post.",
+		CommentID:       commentID,
+		ParentCommentID: parentCommentID,
+		Depth:           depth,
+		Mentions:        mentions,
 	}, nil
 }
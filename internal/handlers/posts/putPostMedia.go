@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/uploads"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,6 +18,12 @@ func PutPostMediaHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
 	var request models.PutPostMediaRequest
 	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
@@ -22,10 +31,10 @@ func PutPostMediaHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := putPostMedia(request)
+	response, err := putPostMedia(userID, request)
 	if err != nil {
 		log.Println("Failed to put post media due to the following error: ", err)
-		http.Error(w, "Failed to put post media.", http.StatusInternalServerError)
+		http.Error(w, "Failed to put post media.", statusForPostMediaError(err))
 		return
 	}
 
@@ -33,20 +42,66 @@ func PutPostMediaHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-func putPostMedia(req models.PutPostMediaRequest) (models.PutPostMediaResponse, error) {
+// putPostMedia adds media to a post of userID's. On a published post this is an
+// edit like any other, so it is recorded as a new revision.
+func putPostMedia(userID int64, req models.PutPostMediaRequest) (models.PutPostMediaResponse, error) {
+	// Media has to be a confirmed upload of the post's author.
+	media, err := resolvePostMedia(userID, req.Images, nil)
+	if err != nil {
+		return models.PutPostMediaResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to put post media due to the following error: %v", err),
+		}, err
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.PutPostMediaResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to start transaction - %v", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	authorID, status, err := lockPostForEdit(tx, req.PostID)
+	if err == nil && authorID != userID {
+		err = errPostForbidden
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.PutPostMediaResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to put post media due to the following error: %v", err),
+		}, err
+	}
+	keepHistory := status == postStatusPublished
+	if keepHistory {
+		if err := ensureBaselineRevision(tx, req.PostID, authorID); err != nil {
+			tx.Rollback()
+			return models.PutPostMediaResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to save the original revision - %v", err),
+			}, err
+		}
+	}
+
 	query := `
 		INSERT INTO post_media
-		(post_id, media_url, media_type)
+		(post_id, media_url, media_type, duration_ms)
 		VALUES
-		(?, ?, ?)
+		(?, ?, ?, ?)
 	`
 
-	for _, image := range req.Images {
-		if image == "" {
-			continue
-		}
-		_, err := database.DB.Exec(query, req.PostID, image, "image")
+	for _, item := range media {
+		_, err := tx.Exec(query, req.PostID, item.URL, item.MediaType, item.DurationMS)
 		if err != nil {
+			tx.Rollback()
 			return models.PutPostMediaResponse{
 				Success: false,
 				Message: fmt.Sprintf("Failed to put post media due to the following error: %v", err),
@@ -54,9 +109,37 @@ func putPostMedia(req models.PutPostMediaRequest) (models.PutPostMediaResponse,
 		}
 	}
 
+	if keepHistory {
+		if _, err := recordRevision(tx, req.PostID, userID); err != nil {
+			tx.Rollback()
+			return models.PutPostMediaResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to save the revision due to the following error: %v", err),
+			}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PutPostMediaResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction - %v", err),
+		}, err
+	}
+
 	return models.PutPostMediaResponse{
-		Success:     true,
-		Message:     "Successfully put post media.",
-		PostID: req.PostID,
+		Success: true,
+		Message: "Successfully put post media.",
+		PostID:  req.PostID,
 	}, nil
 }
+
+// statusForPostMediaError maps the errors of putPostMedia to a response status.
+func statusForPostMediaError(err error) int {
+	switch {
+	case errors.Is(err, errPostForbidden):
+		return http.StatusForbidden
+	case uploads.HTTPStatus(err) != http.StatusInternalServerError:
+		return uploads.HTTPStatus(err)
+	}
+	return statusForPostAccessError(err)
+}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/notifications"
+	"VoizyServer/internal/reactions"
 	"VoizyServer/internal/util"
 	"database/sql"
 	"encoding/json"
@@ -24,6 +27,28 @@ func PutPostReactionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if req.UserID != 0 && req.UserID != userID {
+		http.Error(w, "Field 'userID' does not match the authenticated user.", http.StatusForbidden)
+		return
+	}
+	req.UserID = userID
+
+	if !reactions.Valid(req.ReactionType) {
+		http.Error(w, fmt.Sprintf("Invalid reaction type '%s'.", req.ReactionType), http.StatusBadRequest)
+		return
+	}
+
+	if err := checkPostVisible(req.PostID, req.UserID); err != nil {
+		log.Println("Failed to check post access due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to put reaction to post (%v).", err), statusForPostAccessError(err))
+		return
+	}
+
 	response, err := putPostReaction(req)
 	if err != nil {
 		log.Println("Failed to put reaction to post due to the following error: ", err)
@@ -31,6 +56,7 @@ func PutPostReactionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	go notifyPostAuthor(req.PostID, req.UserID, notifications.TypePostReaction)
 	go util.TrackEvent(req.UserID, "react_to_post", "post_reaction", &response.ReactionID, map[string]interface{}{
 		"reaction_type": req.ReactionType,
 		"post_id":       req.PostID,
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func PutSavedPostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PutSavedPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.PostID <= 0 {
+		http.Error(w, "Missing required field 'postID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := putSavedPost(userID, req)
+	if err != nil {
+		log.Println("Failed to save post due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to save post (%v).", err), statusForSavedError(err))
+		return
+	}
+
+	var metadata map[string]interface{}
+	if req.CollectionID != nil {
+		metadata = map[string]interface{}{
+			"collection_id": *req.CollectionID,
+		}
+	}
+	go util.TrackEvent(userID, "save_post", "post", &req.PostID, metadata)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// putSavedPost saves a post the user can see, or moves an already saved post to
+// another collection. A nil collectionID keeps the post in the user's saved posts
+// without filing it anywhere.
+func putSavedPost(userID int64, req models.PutSavedPostRequest) (models.PutSavedPostResponse, error) {
+	if err := checkPostVisible(req.PostID, userID); err != nil {
+		return models.PutSavedPostResponse{Success: false, Message: err.Error(), PostID: req.PostID}, err
+	}
+	if req.CollectionID != nil {
+		if err := checkCollection(userID, *req.CollectionID); err != nil {
+			return models.PutSavedPostResponse{Success: false, Message: err.Error(), PostID: req.PostID}, err
+		}
+	}
+
+	_, err := database.DB.Exec(`
+		INSERT INTO saved_posts (user_id, post_id, collection_id)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE collection_id = VALUES(collection_id)
+	`, userID, req.PostID, req.CollectionID)
+	if err != nil {
+		return models.PutSavedPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to save post: %v", err),
+			PostID:  req.PostID,
+		}, err
+	}
+
+	return models.PutSavedPostResponse{
+		Success:      true,
+		Message:      "Successfully saved post.",
+		PostID:       req.PostID,
+		CollectionID: req.CollectionID,
+	}, nil
+}
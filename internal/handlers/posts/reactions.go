@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/reactions"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+)
+
+// reactionTarget names the table holding one kind of reaction and the column that
+// points at what was reacted to. Both are interpolated into queries, so they are
+// only ever set from the constants below.
+type reactionTarget struct {
+	table     string
+	keyColumn string
+}
+
+var (
+	postReactionTarget    = reactionTarget{table: "post_reactions", keyColumn: "post_id"}
+	commentReactionTarget = reactionTarget{table: "comment_reactions", keyColumn: "comment_id"}
+)
+
+// listReactions lists who reacted to a post or comment, optionally only with one
+// reaction type. The viewer's friends come first, then everyone else, most recent
+// reaction first. Users the viewer has blocked, or who blocked the viewer, are left
+// out of both the listing and its per-type counts.
+func listReactions(target reactionTarget, objectID, viewerID int64, reactionType string, limit, page int64) (models.ListReactionsResponse, error) {
+	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("r.user_id", viewerID)
+
+	countQuery := `
+		SELECT r.reaction_type, COUNT(*)
+		FROM ` + target.table + ` r
+		WHERE r.` + target.keyColumn + ` = ?
+			AND ` + notBlocked + `
+		GROUP BY r.reaction_type
+	`
+	countRows, err := database.DB.Query(countQuery, append([]interface{}{objectID}, blockArgs...)...)
+	if err != nil {
+		return models.ListReactionsResponse{}, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	defer countRows.Close()
+
+	reactionCounts := reactions.EmptyCounts()
+	var totalReactions int64
+	for countRows.Next() {
+		var (
+			t     string
+			count int64
+		)
+		if err := countRows.Scan(&t, &count); err != nil {
+			return models.ListReactionsResponse{}, fmt.Errorf("failed to scan reaction count: %w", err)
+		}
+		reactionCounts[t] = count
+		if reactionType == "" || t == reactionType {
+			totalReactions += count
+		}
+	}
+	if err := countRows.Err(); err != nil {
+		return models.ListReactionsResponse{}, fmt.Errorf("failed to iterate over reaction counts: %w", err)
+	}
+
+	typeFilter := ""
+	args := []interface{}{viewerID, viewerID, objectID}
+	if reactionType != "" {
+		typeFilter = "AND r.reaction_type = ?"
+		args = append(args, reactionType)
+	}
+	args = append(args, blockArgs...)
+	args = append(args, limit, offset)
+
+	selectQuery := `
+		SELECT
+			r.user_id,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url AS profile_picture,
+			r.reaction_type,
+			r.reacted_at,
+			EXISTS (
+				SELECT 1
+				FROM friendships f
+				WHERE f.status = 'accepted'
+					AND f.user_low = LEAST(r.user_id, ?)
+					AND f.user_high = GREATEST(r.user_id, ?)
+			) AS is_friend
+		FROM ` + target.table + ` r
+		LEFT JOIN users u
+			ON u.user_id = r.user_id
+		LEFT JOIN user_profiles up
+			ON up.user_id = r.user_id
+		LEFT JOIN user_images ui
+			ON ui.user_id = r.user_id
+			AND ui.is_profile_pic = 1
+		WHERE r.` + target.keyColumn + ` = ?
+			` + typeFilter + `
+			AND ` + notBlocked + `
+		ORDER BY is_friend DESC, r.reacted_at DESC, r.user_id ASC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := database.DB.Query(selectQuery, args...)
+	if err != nil {
+		return models.ListReactionsResponse{}, fmt.Errorf("failed to select reactions: %w", err)
+	}
+	defer rows.Close()
+
+	reactors := []models.Reactor{}
+	for rows.Next() {
+		var reactor models.Reactor
+		var username, firstName, lastName, preferredName, profilePicURL sql.NullString
+		err := rows.Scan(
+			&reactor.UserID,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&reactor.ReactionType,
+			&reactor.ReactedAt,
+			&reactor.IsFriend,
+		)
+		if err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		reactor.Username = util.SqlNullStringToPtr(username)
+		reactor.FirstName = util.SqlNullStringToPtr(firstName)
+		reactor.LastName = util.SqlNullStringToPtr(lastName)
+		reactor.PreferredName = util.SqlNullStringToPtr(preferredName)
+		reactor.ProfilePicURL = util.SqlNullStringToPtr(profilePicURL)
+		reactors = append(reactors, reactor)
+	}
+	if err := rows.Err(); err != nil {
+		return models.ListReactionsResponse{}, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	totalPages := int64(math.Ceil(float64(totalReactions) / float64(limit)))
+	return models.ListReactionsResponse{
+		Reactions:      reactors,
+		ReactionCounts: reactionCounts,
+		Limit:          limit,
+		Page:           page,
+		TotalReactions: totalReactions,
+		TotalPages:     totalPages,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func ReorderPinnedPostsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ReorderPinnedPostsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := reorderPinnedPosts(userID, req.PostIDs)
+	if err != nil {
+		log.Println("Failed to reorder pinned posts due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to reorder pinned posts (%v).", err), statusForPinError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "reorder_pinned_posts", "", nil, map[string]interface{}{
+		"post_ids": req.PostIDs,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// reorderPinnedPosts puts the user's pinned posts in the order of postIDs, which
+// must name every one of them exactly once.
+func reorderPinnedPosts(userID int64, postIDs []int64) (models.ReorderPinnedPostsResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.ReorderPinnedPostsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	rows, err := tx.Query(`SELECT post_id FROM pinned_posts WHERE user_id = ? FOR UPDATE`, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.ReorderPinnedPostsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get pinned posts: %v", err),
+		}, err
+	}
+	pinned := make(map[int64]bool)
+	for rows.Next() {
+		var postID int64
+		if err := rows.Scan(&postID); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return models.ReorderPinnedPostsResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to scan pinned post: %v", err),
+			}, err
+		}
+		pinned[postID] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return models.ReorderPinnedPostsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to iterate over pinned posts: %v", err),
+		}, err
+	}
+
+	seen := make(map[int64]bool, len(postIDs))
+	for _, postID := range postIDs {
+		if !pinned[postID] || seen[postID] {
+			tx.Rollback()
+			return models.ReorderPinnedPostsResponse{Success: false, Message: errInvalidPinOrder.Error()}, errInvalidPinOrder
+		}
+		seen[postID] = true
+	}
+	if len(seen) != len(pinned) {
+		tx.Rollback()
+		return models.ReorderPinnedPostsResponse{Success: false, Message: errInvalidPinOrder.Error()}, errInvalidPinOrder
+	}
+
+	for position, postID := range postIDs {
+		_, err := tx.Exec(`UPDATE pinned_posts SET position = ? WHERE post_id = ?`, position, postID)
+		if err != nil {
+			tx.Rollback()
+			return models.ReorderPinnedPostsResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to update pinned post position: %v", err),
+			}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.ReorderPinnedPostsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.ReorderPinnedPostsResponse{
+		Success: true,
+		Message: "Successfully reordered pinned posts.",
+	}, nil
+}
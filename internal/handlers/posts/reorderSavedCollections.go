@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func ReorderSavedCollectionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ReorderSavedCollectionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := reorderSavedCollections(userID, req.CollectionIDs)
+	if err != nil {
+		log.Println("Failed to reorder collections due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to reorder collections (%v).", err), statusForSavedError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "reorder_saved_collections", "", nil, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// reorderSavedCollections puts the user's collections in the order of
+// collectionIDs, which must name every one of them exactly once.
+func reorderSavedCollections(userID int64, collectionIDs []int64) (models.ReorderSavedCollectionsResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.ReorderSavedCollectionsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	rows, err := tx.Query(`SELECT collection_id FROM saved_collections WHERE user_id = ? FOR UPDATE`, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.ReorderSavedCollectionsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get collections: %v", err),
+		}, err
+	}
+	owned := make(map[int64]bool)
+	for rows.Next() {
+		var collectionID int64
+		if err := rows.Scan(&collectionID); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return models.ReorderSavedCollectionsResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to scan collection: %v", err),
+			}, err
+		}
+		owned[collectionID] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return models.ReorderSavedCollectionsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to iterate over collections: %v", err),
+		}, err
+	}
+
+	seen := make(map[int64]bool, len(collectionIDs))
+	for _, collectionID := range collectionIDs {
+		if !owned[collectionID] || seen[collectionID] {
+			tx.Rollback()
+			return models.ReorderSavedCollectionsResponse{Success: false, Message: errInvalidCollectionOrder.Error()}, errInvalidCollectionOrder
+		}
+		seen[collectionID] = true
+	}
+	if len(seen) != len(owned) {
+		tx.Rollback()
+		return models.ReorderSavedCollectionsResponse{Success: false, Message: errInvalidCollectionOrder.Error()}, errInvalidCollectionOrder
+	}
+
+	for position, collectionID := range collectionIDs {
+		_, err := tx.Exec(`
+			UPDATE saved_collections
+			SET position = ?, updated_at = updated_at
+			WHERE collection_id = ?
+		`, position, collectionID)
+		if err != nil {
+			tx.Rollback()
+			return models.ReorderSavedCollectionsResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to update collection position: %v", err),
+			}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.ReorderSavedCollectionsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	return models.ReorderSavedCollectionsResponse{
+		Success: true,
+		Message: "Successfully reordered collections.",
+	}, nil
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func RestorePostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.RestorePostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.PostID <= 0 {
+		http.Error(w, "Missing required field 'postID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := restorePost(userID, req.PostID)
+	if err != nil {
+		log.Println("Failed to restore post due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to restore post (%v).", err), statusForTrashError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "restore_post", "post", &req.PostID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// restorePost takes a post back out of its author's trash, provided it was deleted
+// less than trashRetentionDays ago.
+func restorePost(userID, postID int64) (models.RestorePostResponse, error) {
+	authorID, deleted, err := getPostDeletionState(postID)
+	if err != nil {
+		return models.RestorePostResponse{Success: false, Message: err.Error(), PostID: postID}, err
+	}
+	if authorID != userID {
+		return models.RestorePostResponse{Success: false, Message: errPostForbidden.Error(), PostID: postID}, errPostForbidden
+	}
+	if !deleted {
+		return models.RestorePostResponse{Success: false, Message: errPostNotInTrash.Error(), PostID: postID}, errPostNotInTrash
+	}
+
+	result, err := database.DB.Exec(`
+		UPDATE posts
+		SET deleted_at = NULL, updated_at = updated_at
+		WHERE post_id = ?
+			AND deleted_at > DATE_SUB(NOW(), INTERVAL ? DAY)
+	`, postID, trashRetentionDays)
+	if err != nil {
+		return models.RestorePostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to restore post due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return models.RestorePostResponse{Success: false, Message: errRestoreExpired.Error(), PostID: postID}, errRestoreExpired
+	}
+
+	return models.RestorePostResponse{
+		Success: true,
+		Message: "Successfully restored post.",
+		PostID:  postID,
+	}, nil
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func RevertPostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.RevertPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.PostID <= 0 {
+		http.Error(w, "Missing required field 'postID'.", http.StatusBadRequest)
+		return
+	}
+	if req.RevisionNumber <= 0 {
+		http.Error(w, "Missing required field 'revisionNumber'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := revertPost(userID, req.PostID, req.RevisionNumber)
+	if err != nil {
+		log.Println("Failed to revert post due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to revert post (%v).", err), statusForRevisionError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "revert_post", "post", &req.PostID, map[string]interface{}{
+		"reverted_to":     req.RevisionNumber,
+		"revision_number": response.RevisionNumber,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// revertPost restores the text, location and media of an earlier revision. The
+// revert is an edit like any other: it is recorded as a new revision on top, so
+// history is never rewritten.
+func revertPost(userID, postID, revisionNumber int64) (models.RevertPostResponse, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.RevertPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to start transaction - %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	authorID, _, err := lockPostForEdit(tx, postID)
+	if err == nil && authorID != userID {
+		err = errPostForbidden
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.RevertPostResponse{Success: false, Message: err.Error(), PostID: postID}, err
+	}
+
+	var (
+		revisionID  int64
+		contentText sql.NullString
+	)
+	err = tx.QueryRow(`
+		SELECT revision_id, content_text
+		FROM post_revisions
+		WHERE post_id = ? AND revision_number = ?
+	`, postID, revisionNumber).Scan(&revisionID, &contentText)
+	if err == sql.ErrNoRows {
+		err = errRevisionNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.RevertPostResponse{Success: false, Message: err.Error(), PostID: postID}, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE posts p
+		JOIN post_revisions r ON r.revision_id = ?
+		SET p.content_text = r.content_text,
+			p.location_name = r.location_name,
+			p.location_lat = r.location_lat,
+			p.location_lng = r.location_lng
+		WHERE p.post_id = ?
+	`, revisionID, postID)
+	if err != nil {
+		tx.Rollback()
+		return models.RevertPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to restore revision due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM post_media WHERE post_id = ?", postID); err != nil {
+		tx.Rollback()
+		return models.RevertPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to delete media due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+	_, err = tx.Exec(`
+		INSERT INTO post_media (post_id, media_url, media_type, duration_ms)
+		SELECT ?, media_url, media_type, duration_ms
+		FROM post_revision_media
+		WHERE revision_id = ?
+		ORDER BY revision_media_id ASC
+	`, postID, revisionID)
+	if err != nil {
+		tx.Rollback()
+		return models.RevertPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to restore media due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	mentions, mentionedUserIDs, err := saveMentions(tx, postID, nil, userID, contentText.String)
+	if err != nil {
+		tx.Rollback()
+		return models.RevertPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to save mentions due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	newRevision, err := recordRevision(tx, postID, userID)
+	if err != nil {
+		tx.Rollback()
+		return models.RevertPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to save the revision due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.RevertPostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit the transaction due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	go notifyMentioned(mentionedUserIDs, userID, postID, nil)
+	go linkpreview.Refresh(linkpreview.ExtractURLs(contentText.String))
+
+	return models.RevertPostResponse{
+		Success:        true,
+		Message:        fmt.Sprintf("Successfully reverted the post to revision %d.", revisionNumber),
+		PostID:         postID,
+		RevisionNumber: newRevision,
+		Mentions:       mentions,
+	}, nil
+}
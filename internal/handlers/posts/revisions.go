@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"VoizyServer/internal/uploads"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var errRevisionNotFound = errors.New("revision not found")
+
+// lockPostForEdit locks a post row for the rest of tx, so concurrent edits of the
+// same post are numbered one after the other, and returns its author and status.
+func lockPostForEdit(tx *sql.Tx, postID int64) (authorID int64, status string, err error) {
+	err = tx.QueryRow(`
+		SELECT user_id, status
+		FROM posts
+		WHERE post_id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`, postID).Scan(&authorID, &status)
+	if err == sql.ErrNoRows {
+		return 0, "", errPostNotFound
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to lock post: %w", err)
+	}
+
+	return authorID, status, nil
+}
+
+// ensureBaselineRevision stores the post as it was before its first edit as
+// revision 1, credited to its author and dated from when the post was created, so
+// it can be reverted to.
+func ensureBaselineRevision(tx *sql.Tx, postID, authorID int64) error {
+	var revisions int64
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM post_revisions WHERE post_id = ?`, postID).Scan(&revisions); err != nil {
+		return fmt.Errorf("failed to count revisions: %w", err)
+	}
+	if revisions > 0 {
+		return nil
+	}
+
+	_, err := insertRevision(tx, postID, authorID, true)
+	return err
+}
+
+// recordRevision stores the post's current text, location and media as its next
+// revision, edited by editorID. Revisions are never updated afterwards.
+func recordRevision(tx *sql.Tx, postID, editorID int64) (int64, error) {
+	revisionNumber, err := insertRevision(tx, postID, editorID, false)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`UPDATE posts SET edited_at = NOW() WHERE post_id = ?`, postID); err != nil {
+		return 0, fmt.Errorf("failed to mark post as edited: %w", err)
+	}
+
+	return revisionNumber, nil
+}
+
+// insertRevision copies the post row and its media into the next revision, dated
+// now or, for the baseline, from when the post was created.
+func insertRevision(tx *sql.Tx, postID, editorID int64, baseline bool) (int64, error) {
+	var revisionNumber int64
+	err := tx.QueryRow(`SELECT COALESCE(MAX(revision_number), 0) + 1 FROM post_revisions WHERE post_id = ?`, postID).Scan(&revisionNumber)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next revision number: %w", err)
+	}
+
+	createdAt := "NOW()"
+	if baseline {
+		createdAt = "p.created_at"
+	}
+	result, err := tx.Exec(`
+		INSERT INTO post_revisions (
+			post_id, revision_number, editor_id, content_text, location_name, location_lat, location_lng, created_at
+		)
+		SELECT p.post_id, ?, ?, p.content_text, p.location_name, p.location_lat, p.location_lng, `+createdAt+`
+		FROM posts p
+		WHERE p.post_id = ?
+	`, revisionNumber, editorID, postID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert revision: %w", err)
+	}
+	revisionID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get revision id: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO post_revision_media (revision_id, media_url, media_type, duration_ms)
+		SELECT ?, media_url, media_type, duration_ms
+		FROM post_media
+		WHERE post_id = ?
+		ORDER BY media_id ASC
+	`, revisionID, postID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert revision media: %w", err)
+	}
+
+	return revisionNumber, nil
+}
+
+func statusForRevisionError(err error) int {
+	switch {
+	case errors.Is(err, errPostNotFound), errors.Is(err, errRevisionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errPostForbidden):
+		return http.StatusForbidden
+	case uploads.HTTPStatus(err) != http.StatusInternalServerError:
+		return uploads.HTTPStatus(err)
+	}
+	return statusForPostAccessError(err)
+}
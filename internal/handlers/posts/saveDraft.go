@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+func SaveDraftHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.SaveDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	req.UserID = userID
+
+	if req.OriginalPostID != nil {
+		http.Error(w, fmt.Sprintf("Failed to save draft (%v).", errDraftCannotShare), statusForDraftError(errDraftCannotShare))
+		return
+	}
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		http.Error(w, fmt.Sprintf("Failed to save draft (%v).", errPublishAtInPast), statusForDraftError(errPublishAtInPast))
+		return
+	}
+	if status, err := validatePostRequest(userID, &req.CreatePostRequest); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	response, err := saveDraft(req)
+	if err != nil {
+		log.Println("Failed to save draft due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to save draft (%v).", err), http.StatusInternalServerError)
+		return
+	}
+
+	go util.TrackEvent(userID, "save_draft", "post", &response.PostID, map[string]interface{}{
+		"status": response.Status,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// saveDraft stores the post unpublished: scheduled when it has a publishAt, a plain
+// draft otherwise.
+func saveDraft(req models.SaveDraftRequest) (models.SaveDraftResponse, error) {
+	status := postStatusDraft
+	if req.PublishAt != nil {
+		status = postStatusScheduled
+	}
+
+	created, err := createPost(req.CreatePostRequest, status, req.PublishAt)
+	if err != nil {
+		return models.SaveDraftResponse{Success: false, Message: created.Message}, err
+	}
+
+	return models.SaveDraftResponse{
+		Success:   true,
+		Message:   "Draft saved successfully",
+		PostID:    created.PostID,
+		Status:    status,
+		PublishAt: req.PublishAt,
+		Mentions:  created.Mentions,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxSavedCollectionNameLength = 100
+
+var (
+	errInvalidCollectionName  = errors.New("collection name must be between 1 and 100 characters")
+	errCollectionNameTaken    = errors.New("user already has a collection with this name")
+	errCollectionNotFound     = errors.New("collection not found")
+	errInvalidCollectionOrder = errors.New("collectionIDs must list each of the user's collections exactly once")
+	errPostNotSaved           = errors.New("post is not saved")
+)
+
+// normalizeCollectionName trims the name and checks it fits the saved_collections
+// column.
+func normalizeCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxSavedCollectionNameLength {
+		return "", errInvalidCollectionName
+	}
+	return name, nil
+}
+
+// checkCollectionNameFree returns errCollectionNameTaken when another of userID's
+// collections, other than exceptCollectionID, already has the name.
+func checkCollectionNameFree(userID int64, name string, exceptCollectionID int64) error {
+	var exists int
+	err := database.DB.QueryRow(`
+		SELECT 1
+		FROM saved_collections
+		WHERE user_id = ? AND name = ? AND collection_id <> ?
+		LIMIT 1
+	`, userID, name, exceptCollectionID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check collection name: %w", err)
+	}
+	return errCollectionNameTaken
+}
+
+// checkCollection fails with errCollectionNotFound unless collectionID is one of
+// userID's collections, so nobody can file posts into someone else's.
+func checkCollection(userID, collectionID int64) error {
+	var exists int
+	err := database.DB.QueryRow(`
+		SELECT 1
+		FROM saved_collections
+		WHERE collection_id = ? AND user_id = ?
+	`, collectionID, userID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return errCollectionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check collection: %w", err)
+	}
+	return nil
+}
+
+func statusForSavedError(err error) int {
+	switch {
+	case errors.Is(err, errCollectionNotFound), errors.Is(err, errPostNotSaved):
+		return http.StatusNotFound
+	case errors.Is(err, errInvalidCollectionName), errors.Is(err, errInvalidCollectionOrder):
+		return http.StatusBadRequest
+	case errors.Is(err, errCollectionNameTaken):
+		return http.StatusConflict
+	}
+	return statusForPostAccessError(err)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// trashRetentionDays is how long a deleted post stays in its author's trash, where
+// it can be restored, before the purger removes it for good.
+const trashRetentionDays = 30
+
+var (
+	errPostForbidden  = errors.New("user is not the author of this post")
+	errPostNotInTrash = errors.New("post is not in the trash")
+	errRestoreExpired = errors.New("post was deleted too long ago to be restored")
+)
+
+// getPostDeletionState returns a post's author and whether it is currently in the
+// trash, including posts that are hidden from every listing.
+func getPostDeletionState(postID int64) (authorID int64, deleted bool, err error) {
+	var deletedAt sql.NullTime
+	err = database.DB.QueryRow(`SELECT user_id, deleted_at FROM posts WHERE post_id = ?`, postID).Scan(&authorID, &deletedAt)
+	if err == sql.ErrNoRows {
+		return 0, false, errPostNotFound
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	return authorID, deletedAt.Valid, nil
+}
+
+func statusForTrashError(err error) int {
+	switch {
+	case errors.Is(err, errPostNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errPostForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, errPostNotInTrash), errors.Is(err, errRestoreExpired):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/linkpreview"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/posts"
 	"VoizyServer/internal/util"
 	"encoding/json"
@@ -10,7 +12,6 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 )
 
 func UpdatePostHandler(w http.ResponseWriter, r *http.Request) {
@@ -31,15 +32,14 @@ func UpdatePostHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to parse param 'post_id'.", http.StatusInternalServerError)
 		return
 	}
-	userIDString := q.Get("id")
-	if userIDString == "" {
-		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
 		return
 	}
-	userID, err := strconv.ParseInt(userIDString, 10, 64)
-	if err != nil {
-		log.Println("Failed to parse userIDString (string) to userID (int64) due to the following error: ", err)
-		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+	if userIDString := q.Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
 		return
 	}
 
@@ -53,7 +53,7 @@ func UpdatePostHandler(w http.ResponseWriter, r *http.Request) {
 	response, err := updatePost(postID, userID, req)
 	if err != nil {
 		log.Println("Failed to update post due to the following error: ", err)
-		http.Error(w, "Failed to update post.", http.StatusInternalServerError)
+		http.Error(w, "Failed to update post.", statusForRevisionError(err))
 		return
 	}
 
@@ -79,6 +79,29 @@ func updatePost(postID, userID int64, req map[string]interface{}) (models.Update
 		}
 	}()
 
+	// Published posts keep their history; drafts are only revised once they are out.
+	authorID, status, err := lockPostForEdit(tx, postID)
+	if err == nil && authorID != userID {
+		err = errPostForbidden
+	}
+	if err != nil {
+		tx.Rollback()
+		return models.UpdatePostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to lock post - %v", err),
+		}, err
+	}
+	keepHistory := status == postStatusPublished
+	if keepHistory {
+		if err := ensureBaselineRevision(tx, postID, authorID); err != nil {
+			tx.Rollback()
+			return models.UpdatePostResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to save the original revision - %v", err),
+			}, err
+		}
+	}
+
 	setClauses := []string{}
 	args := []interface{}{}
 
@@ -103,7 +126,7 @@ func updatePost(postID, userID int64, req map[string]interface{}) (models.Update
 
 	if imagesVal, ok := req["images"]; ok {
 		if imagesVal == nil {
-			_, err := tx.Exec("DELETE FROM post_media WHERE post_id = ?", postID)
+			_, err := tx.Exec("DELETE FROM post_media WHERE post_id = ? AND media_type = 'image'", postID)
 			if err != nil {
 				tx.Rollback()
 				return models.UpdatePostResponse{
@@ -120,8 +143,27 @@ func updatePost(postID, userID int64, req map[string]interface{}) (models.Update
 					Message: fmt.Sprintf("Failed to update images. 'images' must be an array of strings."),
 				}, fmt.Errorf("'images' must be an array of strings")
 			}
+			images := make([]string, 0, len(arr))
+			for _, imgVal := range arr {
+				imgStr, ok := imgVal.(string)
+				if !ok {
+					tx.Rollback()
+					return models.UpdatePostResponse{
+						Success: false,
+						Message: fmt.Sprintf("Failed to insert image. Image must be a string."),
+					}, fmt.Errorf("image must be a string")
+				}
+				images = append(images, imgStr)
+			}
+			if _, err := resolvePostMedia(userID, images, nil); err != nil {
+				tx.Rollback()
+				return models.UpdatePostResponse{
+					Success: false,
+					Message: fmt.Sprintf("Failed to update images due to the following error: %v", err),
+				}, err
+			}
 
-			_, err := tx.Exec("DELETE FROM post_media WHERE post_id = ?", postID)
+			_, err := tx.Exec("DELETE FROM post_media WHERE post_id = ? AND media_type = 'image'", postID)
 			if err != nil {
 				tx.Rollback()
 				return models.UpdatePostResponse{
@@ -141,17 +183,8 @@ func updatePost(postID, userID int64, req map[string]interface{}) (models.Update
 			}
 			defer stmt.Close()
 
-			for _, imgVal := range arr {
-				imgStr, ok := imgVal.(string)
-				if !ok {
-					tx.Rollback()
-					return models.UpdatePostResponse{
-						Success: false,
-						Message: fmt.Sprintf("Failed to insert image. Image must be a string."),
-					}, fmt.Errorf("image must be a string")
-				}
-
-				_, err := stmt.Exec(postID, imgStr, time.Now())
+			for _, imgStr := range images {
+				_, err := stmt.Exec(postID, imgStr)
 				if err != nil {
 					tx.Rollback()
 					return models.UpdatePostResponse{
@@ -200,6 +233,7 @@ func updatePost(postID, userID int64, req map[string]interface{}) (models.Update
 			selectTagSQL := `SELECT hashtag_id FROM hashtags WHERE tag = ?`
 			linkSQL := `INSERT INTO post_hashtags (post_id, hashtag_id) VALUES (?, ?)`
 
+			tags := make([]string, 0, len(arr))
 			for _, tVal := range arr {
 				tStr, ok := tVal.(string)
 				if !ok {
@@ -209,7 +243,10 @@ func updatePost(postID, userID int64, req map[string]interface{}) (models.Update
 						Message: fmt.Sprintf("'tags' must be an array of strings"),
 					}, fmt.Errorf("'tags' must be an array of strings")
 				}
-				cleanedTag := strings.TrimPrefix(tStr, "#")
+				tags = append(tags, tStr)
+			}
+
+			for _, cleanedTag := range util.NormalizeHashtags(tags) {
 
 				_, err := tx.Exec(insertTagSQL, cleanedTag)
 				if err != nil {
@@ -241,6 +278,38 @@ func updatePost(postID, userID int64, req map[string]interface{}) (models.Update
 		}
 	}
 
+	// Mentions and link previews follow content_text, so they are only redone when
+	// it changes.
+	var (
+		mentions         []models.MentionSpan
+		mentionedUserIDs []int64
+		linkURLs         []string
+	)
+	if contentVal, ok := req["contentText"]; ok {
+		contentText, _ := contentVal.(string)
+		linkURLs = linkpreview.ExtractURLs(contentText)
+		mentions, mentionedUserIDs, err = saveMentions(tx, postID, nil, userID, contentText)
+		if err != nil {
+			tx.Rollback()
+			return models.UpdatePostResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to save mentions due to the following error: %v", err),
+			}, err
+		}
+	}
+
+	var revisionNumber int64
+	if keepHistory {
+		revisionNumber, err = recordRevision(tx, postID, userID)
+		if err != nil {
+			tx.Rollback()
+			return models.UpdatePostResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to save the revision due to the following error: %v", err),
+			}, err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return models.UpdatePostResponse{
 			Success: false,
@@ -248,10 +317,15 @@ func updatePost(postID, userID int64, req map[string]interface{}) (models.Update
 		}, err
 	}
 
+	go notifyMentioned(mentionedUserIDs, userID, postID, nil)
+	go linkpreview.Refresh(linkURLs)
+
 	return models.UpdatePostResponse{
-		Success: true,
-		Message: "Successfully committed transaction and updated the post.",
-		PostID:  postID,
+		Success:        true,
+		Message:        "Successfully committed transaction and updated the post.",
+		PostID:         postID,
+		RevisionNumber: revisionNumber,
+		Mentions:       mentions,
 	}, nil
 }
 
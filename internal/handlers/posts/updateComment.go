@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func UpdateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.CommentID <= 0 || strings.TrimSpace(req.ContentText) == "" {
+		http.Error(w, "Missing required fields 'commentID' and 'contentText'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := updateComment(userID, req)
+	if err != nil {
+		log.Println("Failed to update comment due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to update comment (%v).", err), statusForCommentError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "update_comment", "comment", &req.CommentID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// updateComment replaces the text of a comment. Only its author may edit it, and
+// edited_at is set so clients can mark the comment as edited.
+func updateComment(userID int64, req models.UpdateCommentRequest) (models.UpdateCommentResponse, error) {
+	authorID, postID, err := getCommentAuthorAndPost(req.CommentID)
+	if err != nil {
+		return models.UpdateCommentResponse{Success: false, Message: err.Error()}, err
+	}
+	if authorID != userID {
+		return models.UpdateCommentResponse{Success: false, Message: errCommentForbidden.Error()}, errCommentForbidden
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.UpdateCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	query := `
+		UPDATE comments
+		SET content_text = ?, edited_at = NOW()
+		WHERE comment_id = ?
+	`
+	if _, err := tx.Exec(query, req.ContentText, req.CommentID); err != nil {
+		tx.Rollback()
+		return models.UpdateCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update comment due to the following error: %v", err),
+		}, err
+	}
+
+	var editedAt time.Time
+	if err := tx.QueryRow(`SELECT edited_at FROM comments WHERE comment_id = ?`, req.CommentID).Scan(&editedAt); err != nil {
+		tx.Rollback()
+		return models.UpdateCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get edited comment due to the following error: %v", err),
+		}, err
+	}
+
+	mentions, mentionedUserIDs, err := saveMentions(tx, postID, &req.CommentID, userID, req.ContentText)
+	if err != nil {
+		tx.Rollback()
+		return models.UpdateCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to save mentions due to the following error: %v", err),
+		}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.UpdateCommentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+		}, err
+	}
+
+	go notifyMentioned(mentionedUserIDs, userID, postID, &req.CommentID)
+
+	return models.UpdateCommentResponse{
+		Success:   true,
+		Message:   "Successfully updated comment.",
+		CommentID: req.CommentID,
+		Edited:    true,
+		EditedAt:  &editedAt,
+		Mentions:  mentions,
+	}, nil
+}
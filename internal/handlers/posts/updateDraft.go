@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+func UpdateDraftHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	postIDVal, _ := req["postID"].(float64)
+	postID := int64(postIDVal)
+	if postID <= 0 {
+		http.Error(w, "Missing required field 'postID'.", http.StatusBadRequest)
+		return
+	}
+	delete(req, "postID")
+
+	response, err := updateDraft(postID, userID, req)
+	if err != nil {
+		log.Println("Failed to update draft due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to update draft (%v).", err), statusForDraftError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "update_draft", "post", &postID, req)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// updateDraft edits an unpublished post. The content fields behave as they do in
+// updatePost; "publishAt" reschedules the post, and a null publishAt turns it back
+// into a plain draft.
+func updateDraft(postID, userID int64, req map[string]interface{}) (models.UpdateDraftResponse, error) {
+	if err := checkDraft(postID, userID); err != nil {
+		return models.UpdateDraftResponse{Success: false, Message: err.Error(), PostID: postID}, err
+	}
+
+	publishAtVal, reschedule := req["publishAt"]
+	delete(req, "publishAt")
+	var publishAt *time.Time
+	if reschedule && publishAtVal != nil {
+		publishAtString, ok := publishAtVal.(string)
+		if !ok {
+			return models.UpdateDraftResponse{Success: false, Message: errInvalidPublishAt.Error(), PostID: postID}, errInvalidPublishAt
+		}
+		t, err := time.Parse(time.RFC3339, publishAtString)
+		if err != nil {
+			return models.UpdateDraftResponse{Success: false, Message: errInvalidPublishAt.Error(), PostID: postID}, errInvalidPublishAt
+		}
+		if !t.After(time.Now()) {
+			return models.UpdateDraftResponse{Success: false, Message: errPublishAtInPast.Error(), PostID: postID}, errPublishAtInPast
+		}
+		publishAt = &t
+	}
+
+	updated, err := updatePost(postID, userID, req)
+	if err != nil {
+		return models.UpdateDraftResponse{Success: false, Message: updated.Message, PostID: postID}, err
+	}
+
+	if reschedule {
+		status := postStatusDraft
+		if publishAt != nil {
+			status = postStatusScheduled
+		}
+		// The scheduler may have published the post since checkDraft, so this only
+		// touches posts that are still unpublished. No affected rows can also mean
+		// nothing changed, which checkDraft tells apart.
+		result, err := database.DB.Exec(`
+			UPDATE posts
+			SET status = ?, publish_at = ?
+			WHERE post_id = ? AND status IN ('draft', 'scheduled')
+		`, status, publishAt, postID)
+		if err != nil {
+			return models.UpdateDraftResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to reschedule draft due to the following error: %v", err),
+				PostID:  postID,
+			}, err
+		}
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			if err := checkDraft(postID, userID); err != nil {
+				return models.UpdateDraftResponse{Success: false, Message: err.Error(), PostID: postID}, err
+			}
+		}
+	}
+
+	var (
+		status         string
+		currentPublish sql.NullTime
+	)
+	err = database.DB.QueryRow(`SELECT status, publish_at FROM posts WHERE post_id = ?`, postID).Scan(&status, &currentPublish)
+	if err != nil {
+		return models.UpdateDraftResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get draft due to the following error: %v", err),
+			PostID:  postID,
+		}, err
+	}
+
+	return models.UpdateDraftResponse{
+		Success:   true,
+		Message:   "Successfully updated the draft.",
+		PostID:    postID,
+		Status:    status,
+		PublishAt: util.SqlNullTimeToPtr(currentPublish),
+		Mentions:  updated.Mentions,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func UpdatePostAudienceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.UpdatePostAudienceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.PostID <= 0 {
+		http.Error(w, "Missing required field 'postID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := updatePostAudience(userID, req)
+	if err != nil {
+		log.Println("Failed to update post audience due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to update post audience (%v).", err), statusForPinError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "update_post_audience", "post", &req.PostID, map[string]interface{}{
+		"audience": response.Audience,
+		"unpinned": response.Unpinned,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// updatePostAudience changes who can see one of the user's posts. A post whose
+// audience actually changes loses its pin, since it no longer reaches the people it
+// was pinned for.
+func updatePostAudience(userID int64, req models.UpdatePostAudienceRequest) (models.UpdatePostAudienceResponse, error) {
+	if !audience.Valid(req.Audience) {
+		return models.UpdatePostAudienceResponse{Success: false, Message: errInvalidAudience.Error(), PostID: req.PostID}, errInvalidAudience
+	}
+	if req.Audience == audience.Custom {
+		if req.AudienceListID == nil {
+			return models.UpdatePostAudienceResponse{Success: false, Message: errAudienceNeedsList.Error(), PostID: req.PostID}, errAudienceNeedsList
+		}
+		if err := audience.CheckList(userID, *req.AudienceListID); err != nil {
+			return models.UpdatePostAudienceResponse{Success: false, Message: err.Error(), PostID: req.PostID}, err
+		}
+	} else {
+		req.AudienceListID = nil
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return models.UpdatePostAudienceResponse{
+			Success: false,
+			Message: fmt.Sprintf("Error beginning transaction (%v).", err),
+			PostID:  req.PostID,
+		}, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	authorID, _, err := lockPostForEdit(tx, req.PostID)
+	if err != nil {
+		tx.Rollback()
+		return models.UpdatePostAudienceResponse{Success: false, Message: err.Error(), PostID: req.PostID}, err
+	}
+	if authorID != userID {
+		tx.Rollback()
+		return models.UpdatePostAudienceResponse{Success: false, Message: errPostForbidden.Error(), PostID: req.PostID}, errPostForbidden
+	}
+
+	result, err := tx.Exec(`
+		UPDATE posts
+		SET audience = ?, audience_list_id = ?, updated_at = updated_at
+		WHERE post_id = ? AND NOT (audience = ? AND audience_list_id <=> ?)
+	`, req.Audience, req.AudienceListID, req.PostID, req.Audience, req.AudienceListID)
+	if err != nil {
+		tx.Rollback()
+		return models.UpdatePostAudienceResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update post audience: %v", err),
+			PostID:  req.PostID,
+		}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+
+	var unpinned bool
+	if rowsAffected > 0 {
+		unpinned, err = unpinPost(tx, req.PostID)
+		if err != nil {
+			tx.Rollback()
+			return models.UpdatePostAudienceResponse{Success: false, Message: err.Error(), PostID: req.PostID}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.UpdatePostAudienceResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to commit transaction: %v", err),
+			PostID:  req.PostID,
+		}, err
+	}
+
+	return models.UpdatePostAudienceResponse{
+		Success:        true,
+		Message:        "Successfully updated post audience.",
+		PostID:         req.PostID,
+		Audience:       req.Audience,
+		AudienceListID: req.AudienceListID,
+		Unpinned:       unpinned,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func UpdateSavedCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.UpdateSavedCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.CollectionID <= 0 {
+		http.Error(w, "Missing required field 'collectionID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := updateSavedCollection(userID, req)
+	if err != nil {
+		log.Println("Failed to update collection due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to update collection (%v).", err), statusForSavedError(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "update_saved_collection", "saved_collection", &req.CollectionID, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// updateSavedCollection renames one of the user's collections.
+func updateSavedCollection(userID int64, req models.UpdateSavedCollectionRequest) (models.UpdateSavedCollectionResponse, error) {
+	if err := checkCollection(userID, req.CollectionID); err != nil {
+		return models.UpdateSavedCollectionResponse{Success: false, Message: err.Error()}, err
+	}
+	name, err := normalizeCollectionName(req.Name)
+	if err != nil {
+		return models.UpdateSavedCollectionResponse{Success: false, Message: err.Error()}, err
+	}
+	if err := checkCollectionNameFree(userID, name, req.CollectionID); err != nil {
+		return models.UpdateSavedCollectionResponse{Success: false, Message: err.Error()}, err
+	}
+
+	_, err = database.DB.Exec(`UPDATE saved_collections SET name = ? WHERE collection_id = ?`, name, req.CollectionID)
+	if err != nil {
+		return models.UpdateSavedCollectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update collection: %v", err),
+		}, err
+	}
+
+	return models.UpdateSavedCollectionResponse{
+		Success: true,
+		Message: "Successfully updated collection.",
+	}, nil
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/uploads"
+	"VoizyServer/internal/uploads"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func AbortMultipartUploadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.AbortMultipartUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.FileName == "" || req.UploadID == "" {
+		http.Error(w, "Missing required fields 'fileName' and 'uploadID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := abortMultipartUpload(r.Context(), userID, req)
+	if err != nil {
+		log.Println("Failed to abort multipart upload due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to abort multipart upload (%v).", err), uploads.HTTPStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func abortMultipartUpload(ctx context.Context, userID int64, req models.AbortMultipartUploadRequest) (models.AbortMultipartUploadResponse, error) {
+	if err := uploads.AbortMultipart(ctx, userID, req.FileName, req.UploadID); err != nil {
+		return models.AbortMultipartUploadResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to abort multipart upload: %v", err),
+		}, err
+	}
+	return models.AbortMultipartUploadResponse{
+		Success: true,
+		Message: "Aborted multipart upload.",
+	}, nil
+}
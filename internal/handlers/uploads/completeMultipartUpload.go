@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/uploads"
+	"VoizyServer/internal/storage"
+	"VoizyServer/internal/uploads"
+	"VoizyServer/internal/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func CompleteMultipartUploadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CompleteMultipartUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.FileName == "" || req.UploadID == "" {
+		http.Error(w, "Missing required fields 'fileName' and 'uploadID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := completeMultipartUpload(r.Context(), userID, req)
+	if err != nil {
+		log.Println("Failed to complete multipart upload due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to complete multipart upload (%v).", err), uploads.HTTPStatus(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "complete_multipart_upload", "upload", nil, map[string]interface{}{
+		"content_type": response.Upload.ContentType,
+		"size":         response.Upload.Size,
+		"part_count":   len(req.Parts),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// completeMultipartUpload joins the parts and confirms the result, which is then
+// processed like any other confirmed upload.
+func completeMultipartUpload(ctx context.Context, userID int64, req models.CompleteMultipartUploadRequest) (models.CompleteMultipartUploadResponse, error) {
+	parts := make([]storage.CompletedPart, 0, len(req.Parts))
+	for _, part := range req.Parts {
+		parts = append(parts, storage.CompletedPart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+
+	upload, err := uploads.CompleteMultipart(ctx, userID, req.FileName, req.UploadID, parts)
+	if err != nil {
+		return models.CompleteMultipartUploadResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to complete multipart upload: %v", err),
+		}, err
+	}
+	go imaging.Process(upload.Key)
+
+	return models.CompleteMultipartUploadResponse{
+		Success: true,
+		Message: "Completed multipart upload.",
+		Upload: models.ConfirmedUpload{
+			FileName:    upload.Key,
+			FinalURL:    upload.FinalURL,
+			ContentType: upload.ContentType,
+			Size:        upload.Size,
+			DurationMS:  upload.DurationMS,
+			Codec:       upload.Codec,
+			Confirmed:   true,
+		},
+	}, nil
+}
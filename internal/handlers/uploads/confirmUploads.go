@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"VoizyServer/internal/imaging"
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/uploads"
+	"VoizyServer/internal/uploads"
+	"VoizyServer/internal/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func ConfirmUploadsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ConfirmUploadsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if len(req.FileNames) == 0 {
+		http.Error(w, "Missing required field 'fileNames'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := confirmUploads(r.Context(), userID, req)
+	if err != nil {
+		log.Println("Failed to confirm uploads due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to confirm uploads (%v).", err), http.StatusInternalServerError)
+		return
+	}
+
+	confirmed := 0
+	for _, u := range response.Uploads {
+		if u.Confirmed {
+			confirmed++
+		}
+	}
+	go util.TrackEvent(userID, "confirm_uploads", "upload", nil, map[string]interface{}{
+		"file_count":      len(req.FileNames),
+		"confirmed_count": confirmed,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// confirmUploads checks each uploaded file. Files that fail the checks are reported
+// with the reason, so one bad file does not hold up the rest of a batch.
+func confirmUploads(ctx context.Context, userID int64, req models.ConfirmUploadsRequest) (models.ConfirmUploadsResponse, error) {
+	results := make([]models.ConfirmedUpload, 0, len(req.FileNames))
+	for _, fileName := range req.FileNames {
+		upload, err := uploads.Confirm(ctx, userID, fileName)
+		if err != nil {
+			if uploads.HTTPStatus(err) == http.StatusInternalServerError {
+				return models.ConfirmUploadsResponse{
+					Success: false,
+					Message: fmt.Sprintf("Failed to confirm upload %s: %v", fileName, err),
+				}, err
+			}
+			results = append(results, models.ConfirmedUpload{FileName: fileName, Error: err.Error()})
+			continue
+		}
+		go imaging.Process(upload.Key)
+		results = append(results, models.ConfirmedUpload{
+			FileName:    upload.Key,
+			FinalURL:    upload.FinalURL,
+			ContentType: upload.ContentType,
+			Size:        upload.Size,
+			DurationMS:  upload.DurationMS,
+			Codec:       upload.Codec,
+			Confirmed:   true,
+		})
+	}
+
+	return models.ConfirmUploadsResponse{
+		Success: true,
+		Message: "Checked uploads.",
+		Uploads: results,
+	}, nil
+}
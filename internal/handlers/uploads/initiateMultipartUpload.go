@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/uploads"
+	"VoizyServer/internal/uploads"
+	"VoizyServer/internal/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func InitiateMultipartUploadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.InitiateMultipartUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.FileName == "" {
+		http.Error(w, "Missing required field 'fileName'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := initiateMultipartUpload(r.Context(), userID, req)
+	if err != nil {
+		log.Println("Failed to initiate multipart upload due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to initiate multipart upload (%v).", err), uploads.HTTPStatus(err))
+		return
+	}
+
+	go util.TrackEvent(userID, "initiate_multipart_upload", "upload", nil, map[string]interface{}{
+		"content_type": response.ContentType,
+		"size":         req.Size,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// initiateMultipartUpload starts a post media upload in parts. Like single uploads
+// it is kept under the uploader's and post's directory.
+func initiateMultipartUpload(ctx context.Context, userID int64, req models.InitiateMultipartUploadRequest) (models.InitiateMultipartUploadResponse, error) {
+	dir := fmt.Sprintf("%d/%d", userID, req.PostID)
+	contentType := uploads.ContentType(req.FileName, req.ContentType)
+	upload, err := uploads.InitiateMultipart(ctx, userID, uploads.PostMedia, dir, req.FileName, contentType, req.Size)
+	if err != nil {
+		return models.InitiateMultipartUploadResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to initiate multipart upload: %v", err),
+		}, err
+	}
+
+	return models.InitiateMultipartUploadResponse{
+		Success:     true,
+		Message:     "Initiated multipart upload.",
+		FileName:    upload.Key,
+		UploadID:    upload.UploadID,
+		FinalURL:    upload.FinalURL,
+		ContentType: upload.ContentType,
+		MaxSize:     upload.MaxSize,
+		PartSize:    upload.PartSize,
+	}, nil
+}
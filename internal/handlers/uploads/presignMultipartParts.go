@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/uploads"
+	"VoizyServer/internal/uploads"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+func PresignMultipartPartsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PresignMultipartPartsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+	if req.FileName == "" || req.UploadID == "" {
+		http.Error(w, "Missing required fields 'fileName' and 'uploadID'.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := presignMultipartParts(r.Context(), userID, req)
+	if err != nil {
+		log.Println("Failed to presign upload parts due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to presign upload parts (%v).", err), uploads.HTTPStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func presignMultipartParts(ctx context.Context, userID int64, req models.PresignMultipartPartsRequest) (models.PresignMultipartPartsResponse, error) {
+	parts, err := uploads.PresignParts(ctx, userID, req.FileName, req.UploadID, req.PartNumbers)
+	if err != nil {
+		return models.PresignMultipartPartsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to presign upload parts: %v", err),
+		}, err
+	}
+
+	results := make([]models.PresignedPart, 0, len(parts))
+	for _, part := range parts {
+		results = append(results, models.PresignedPart{
+			PartNumber:   part.PartNumber,
+			PresignedURL: part.PresignedURL,
+		})
+	}
+	return models.PresignMultipartPartsResponse{
+		Success: true,
+		Message: "Presigned upload parts.",
+		Parts:   results,
+	}, nil
+}
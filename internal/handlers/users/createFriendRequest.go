@@ -1,8 +1,9 @@
 package handlers
 
 import (
-	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/users"
+	"VoizyServer/internal/notifications"
 	"VoizyServer/internal/util"
 	"encoding/json"
 	"fmt"
@@ -23,14 +24,43 @@ func CreateFriendRequestHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if req.UserID != 0 && req.UserID != userID {
+		http.Error(w, "Field 'userID' does not match the authenticated user.", http.StatusForbidden)
+		return
+	}
+	req.UserID = userID
+
+	if req.FriendID <= 0 {
+		http.Error(w, "Missing required field 'friendID'.", http.StatusBadRequest)
+		return
+	}
+
 	response, err := createFriendRequest(req)
 	if err != nil {
 		log.Println("Failed to create friend request due to the following error: ", err)
-		http.Error(w, "Failed to create friend request.", http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Failed to create friend request (%v).", err), statusForFriendshipError(err))
 		return
 	}
 
-	go util.TrackEvent(req.UserID, "create_friend_request", "friendship", &response.FriendshipID, map[string]interface{}{
+	eventType := "create_friend_request"
+	notificationType := notifications.TypeFriendRequest
+	if response.Status == friendStatusAccepted {
+		eventType = "accept_friend_request"
+		notificationType = notifications.TypeFriendRequestAccepted
+	}
+	go notifications.Send(notifications.Notification{
+		RecipientID: req.FriendID,
+		ActorID:     req.UserID,
+		Type:        notificationType,
+		ObjectType:  "friendship",
+		ObjectID:    response.FriendshipID,
+	})
+	go util.TrackEvent(req.UserID, eventType, "friendship", &response.FriendshipID, map[string]interface{}{
 		"friendID": req.FriendID,
 	})
 
@@ -38,25 +68,26 @@ func CreateFriendRequestHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// createFriendRequest sends a request, or accepts the other user's pending request
+// when they had already asked, so the pair never ends up with two rows.
 func createFriendRequest(req models.CreateFriendRequestRequest) (models.CreateFriendRequestResponse, error) {
-	query := `
-		INSERT INTO friendships (
-			user_id,
-			friend_id
-		) VALUES (?, ?)
-	`
-	result, err := database.DB.Exec(query, req.UserID, req.FriendID)
+	f, err := applyFriendshipAction(req.UserID, req.FriendID, friendActionRequest)
 	if err != nil {
 		return models.CreateFriendRequestResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to create friend request due to the following error: %v", err),
 		}, err
 	}
-	friendshipID, _ := result.LastInsertId()
+
+	message := "Successfully created friend request."
+	if f.status == friendStatusAccepted {
+		message = "Friend request accepted; the other user had already sent one."
+	}
 
 	return models.CreateFriendRequestResponse{
 		Success:      true,
-		Message:      "Successfully created friend request.",
-		FriendshipID: friendshipID,
+		Message:      message,
+		FriendshipID: f.friendshipID,
+		Status:       f.status,
 	}, nil
 }
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"VoizyServer/internal/database"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	friendActionRequest  = "request"
+	friendActionAccept   = "accept"
+	friendActionDecline  = "decline"
+	friendActionCancel   = "cancel"
+	friendActionUnfriend = "unfriend"
+	friendActionBlock    = "block"
+	friendActionUnblock  = "unblock"
+
+	friendStatusIdle     = "idle"
+	friendStatusPending  = "pending"
+	friendStatusAccepted = "accepted"
+	friendStatusBlocked  = "blocked"
+
+	friendDirectionOutgoing = "outgoing"
+	friendDirectionIncoming = "incoming"
+)
+
+var (
+	errSelfFriendship          = errors.New("users cannot befriend themselves")
+	errFriendshipNotFound      = errors.New("no friendship exists between these users")
+	errInvalidFriendTransition = errors.New("friendship is not in a state that allows this action")
+	errFriendRequestExists     = errors.New("a friend request is already pending")
+	errAlreadyFriends          = errors.New("users are already friends")
+	errFriendshipBlocked       = errors.New("friendship is blocked")
+	errUnknownFriendAction     = errors.New("unknown friendship action")
+)
+
+// friendship is the single friendships row for an unordered pair of users. user_id
+// is whoever sent the pending request, or whoever blocked the other user.
+type friendship struct {
+	friendshipID int64
+	userID       int64
+	friendID     int64
+	status       string
+}
+
+// direction describes the row from viewerID's side: outgoing when the viewer sent
+// the request or placed the block, incoming otherwise.
+func (f *friendship) direction(viewerID int64) string {
+	if f.userID == viewerID {
+		return friendDirectionOutgoing
+	}
+	return friendDirectionIncoming
+}
+
+type friendshipQuerier interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// findFriendship returns the row for the pair or nil. Pass a *sql.Tx with lock set
+// to hold the row (or the gap where it would be) until the transaction ends.
+func findFriendship(q friendshipQuerier, userID, otherUserID int64, lock bool) (*friendship, error) {
+	query := `
+		SELECT friendship_id, user_id, friend_id, status
+		FROM friendships
+		WHERE user_low = LEAST(?, ?) AND user_high = GREATEST(?, ?)
+	`
+	if lock {
+		query += " FOR UPDATE"
+	}
+
+	var f friendship
+	err := q.QueryRow(query, userID, otherUserID, userID, otherUserID).Scan(
+		&f.friendshipID,
+		&f.userID,
+		&f.friendID,
+		&f.status,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get friendship: %w", err)
+	}
+
+	return &f, nil
+}
+
+// applyFriendshipAction runs one transition of the friendship state machine for
+// actorID against otherUserID and returns the resulting row, or nil when the
+// pair is back to idle.
+//
+//	idle     --request--> pending (outgoing)
+//	pending  --request--> accepted, when the other user had already asked
+//	pending  --accept/decline--> accepted / idle, by the recipient
+//	pending  --cancel--> idle, by the sender
+//	accepted --unfriend--> idle, by either user
+//	any      --block--> blocked (outgoing)
+//	blocked  --unblock--> idle, by the user who blocked
+func applyFriendshipAction(actorID, otherUserID int64, action string) (*friendship, error) {
+	if actorID == otherUserID {
+		return nil, errSelfFriendship
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	current, err := findFriendship(tx, actorID, otherUserID, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	next, err := nextFriendship(tx, current, actorID, otherUserID, action)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Friend lists only hold friends, so a pair that stops being friends drops off
+	// each other's lists too.
+	if action == friendActionUnfriend || action == friendActionBlock {
+		if err := removeFromFriendLists(tx, actorID, otherUserID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return next, nil
+}
+
+func nextFriendship(tx *sql.Tx, current *friendship, actorID, otherUserID int64, action string) (*friendship, error) {
+	switch action {
+	case friendActionRequest:
+		if current == nil {
+			return insertFriendship(tx, actorID, otherUserID, friendStatusPending)
+		}
+		switch {
+		case current.status == friendStatusBlocked:
+			return nil, errFriendshipBlocked
+		case current.status == friendStatusAccepted:
+			return nil, errAlreadyFriends
+		case current.direction(actorID) == friendDirectionOutgoing:
+			return nil, errFriendRequestExists
+		default:
+			return setFriendshipStatus(tx, current, friendStatusAccepted)
+		}
+
+	case friendActionAccept, friendActionDecline:
+		if current == nil {
+			return nil, errFriendshipNotFound
+		}
+		if current.status != friendStatusPending || current.direction(actorID) != friendDirectionIncoming {
+			return nil, errInvalidFriendTransition
+		}
+		if action == friendActionAccept {
+			return setFriendshipStatus(tx, current, friendStatusAccepted)
+		}
+		return nil, deleteFriendship(tx, current)
+
+	case friendActionCancel:
+		if current == nil {
+			return nil, errFriendshipNotFound
+		}
+		if current.status != friendStatusPending || current.direction(actorID) != friendDirectionOutgoing {
+			return nil, errInvalidFriendTransition
+		}
+		return nil, deleteFriendship(tx, current)
+
+	case friendActionUnfriend:
+		if current == nil {
+			return nil, errFriendshipNotFound
+		}
+		if current.status != friendStatusAccepted {
+			return nil, errInvalidFriendTransition
+		}
+		return nil, deleteFriendship(tx, current)
+
+	case friendActionBlock:
+		if current == nil {
+			return insertFriendship(tx, actorID, otherUserID, friendStatusBlocked)
+		}
+		// Only one row exists per pair, so a block that is already in place,
+		// from either side, is left as it is.
+		if current.status == friendStatusBlocked {
+			return current, nil
+		}
+		_, err := tx.Exec(`
+			UPDATE friendships
+			SET user_id = ?, friend_id = ?, status = 'blocked'
+			WHERE friendship_id = ?
+		`, actorID, otherUserID, current.friendshipID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to block user: %w", err)
+		}
+		return &friendship{
+			friendshipID: current.friendshipID,
+			userID:       actorID,
+			friendID:     otherUserID,
+			status:       friendStatusBlocked,
+		}, nil
+
+	case friendActionUnblock:
+		if current == nil {
+			return nil, errFriendshipNotFound
+		}
+		if current.status != friendStatusBlocked || current.direction(actorID) != friendDirectionOutgoing {
+			return nil, errInvalidFriendTransition
+		}
+		return nil, deleteFriendship(tx, current)
+
+	default:
+		return nil, errUnknownFriendAction
+	}
+}
+
+func insertFriendship(tx *sql.Tx, userID, friendID int64, status string) (*friendship, error) {
+	result, err := tx.Exec(`
+		INSERT INTO friendships (user_id, friend_id, status)
+		VALUES (?, ?, ?)
+	`, userID, friendID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert friendship: %w", err)
+	}
+	friendshipID, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get friendship id: %w", err)
+	}
+
+	return &friendship{
+		friendshipID: friendshipID,
+		userID:       userID,
+		friendID:     friendID,
+		status:       status,
+	}, nil
+}
+
+func setFriendshipStatus(tx *sql.Tx, f *friendship, status string) (*friendship, error) {
+	_, err := tx.Exec(`UPDATE friendships SET status = ? WHERE friendship_id = ?`, status, f.friendshipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update friendship status: %w", err)
+	}
+
+	updated := *f
+	updated.status = status
+	return &updated, nil
+}
+
+func deleteFriendship(tx *sql.Tx, f *friendship) error {
+	if _, err := tx.Exec(`DELETE FROM friendships WHERE friendship_id = ?`, f.friendshipID); err != nil {
+		return fmt.Errorf("failed to delete friendship: %w", err)
+	}
+	return nil
+}
+
+func removeFromFriendLists(tx *sql.Tx, userID, otherUserID int64) error {
+	_, err := tx.Exec(`
+		DELETE flm FROM friend_list_members flm
+		JOIN friend_lists fl ON fl.list_id = flm.list_id
+		WHERE (fl.user_id = ? AND flm.member_id = ?)
+			OR (fl.user_id = ? AND flm.member_id = ?)
+	`, userID, otherUserID, otherUserID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove users from friend lists: %w", err)
+	}
+	return nil
+}
+
+func statusForFriendshipError(err error) int {
+	switch {
+	case errors.Is(err, errSelfFriendship), errors.Is(err, errUnknownFriendAction):
+		return http.StatusBadRequest
+	case errors.Is(err, errFriendshipNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errFriendshipBlocked):
+		return http.StatusForbidden
+	case errors.Is(err, errInvalidFriendTransition), errors.Is(err, errFriendRequestExists), errors.Is(err, errAlreadyFriends):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
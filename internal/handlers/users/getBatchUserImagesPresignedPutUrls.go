@@ -1,16 +1,14 @@
 package handlers
 
 import (
-	aws "VoizyServer/internal/aws"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/users"
+	"VoizyServer/internal/uploads"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
-	"time"
-
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
 func GetBatchUserImagesPresignedPutUrlsHandler(w http.ResponseWriter, r *http.Request) {
@@ -19,14 +17,22 @@ func GetBatchUserImagesPresignedPutUrlsHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
 	var req models.GetBatchUserImagesPresignedPutUrlsRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		http.Error(w, "Invalid request body.", http.StatusBadRequest)
 		return
 	}
+	// Uploads belong to whoever is signed in, whatever userID the body names.
+	req.UserID = userID
 
-	response, err := getBatchUserImagesPresignedPutUrls(req)
+	response, err := getBatchUserImagesPresignedPutUrls(r.Context(), req)
 	if err != nil {
 		log.Println("Failed to get presigned URLs due to the following error: ", err)
 		http.Error(w, "Failed to get presigned URLs.", http.StatusInternalServerError)
@@ -37,37 +43,42 @@ func GetBatchUserImagesPresignedPutUrlsHandler(w http.ResponseWriter, r *http.Re
 	json.NewEncoder(w).Encode(response)
 }
 
-func getBatchUserImagesPresignedPutUrls(req models.GetBatchUserImagesPresignedPutUrlsRequest) (models.GetBatchUserImagesPresignedPutUrlsResponse, error) {
+func getBatchUserImagesPresignedPutUrls(ctx context.Context, req models.GetBatchUserImagesPresignedPutUrlsRequest) (models.GetBatchUserImagesPresignedPutUrlsResponse, error) {
 	var response models.GetBatchUserImagesPresignedPutUrlsResponse
-	presignClient := s3.NewPresignClient(aws.S3Client)
-	bucket := "voizy-app"
+	dir := fmt.Sprintf("%d/photos", req.UserID)
 
-	var results []models.PresignedFile
+	files := req.Files
 	for _, fileName := range req.FileNames {
-		if fileName == "" {
+		files = append(files, models.UploadFile{FileName: fileName})
+	}
+
+	var results []models.PresignedFile
+	for _, file := range files {
+		if file.FileName == "" {
 			continue
 		}
-		key := fmt.Sprintf("%d/%s/%s", req.UserID, "photos", fileName)
-		input := &s3.PutObjectInput{
-			Bucket: &bucket,
-			Key: &key,
-		}
-		presignReq, err := presignClient.PresignPutObject(
-			context.TODO(),
-			input,
-			s3.WithPresignExpires(5*time.Minute),
-		)
+		contentType := uploads.ContentType(file.FileName, file.ContentType)
+		upload, err := uploads.Presign(ctx, req.UserID, uploads.UserImage, dir, file.FileName, contentType, file.Size)
 		if err != nil {
-			log.Println("Failed to presign put object:", key, "err:", err)
+			if uploads.HTTPStatus(err) == http.StatusInternalServerError {
+				return response, err
+			}
+			// Files the policy refuses are reported back instead of failing the batch.
+			results = append(results, models.PresignedFile{
+				FileName:    dir + "/" + file.FileName,
+				ContentType: contentType,
+				Error:       err.Error(),
+			})
 			continue
 		}
 
-		finalURL := fmt.Sprintf("https://%s.s3.amazonaws.com/%s", bucket, key)
-
 		results = append(results, models.PresignedFile{
-			FileName:    key,
-			PresignedURL: presignReq.URL,
-			FinalURL:    finalURL,
+			FileName:     upload.Key,
+			PresignedURL: upload.PresignedURL,
+			FinalURL:     upload.FinalURL,
+			ContentType:  upload.ContentType,
+			MaxSize:      upload.MaxSize,
+			Headers:      upload.Headers,
 		})
 	}
 	response.Images = results
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	database "VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	postModels "VoizyServer/internal/models/posts"
 	models "VoizyServer/internal/models/users"
 	"database/sql"
 	"encoding/json"
@@ -46,10 +48,19 @@ func getCoverPic(userID int64) (models.GetCoverPicResponse, error) {
 	if err != nil {
 		if err == sql.ErrNoRows {
 			response.CoverPicURL = ""
+			response.CoverPicVariants = []postModels.ImageVariant{}
 			return response, nil
 		}
 		return models.GetCoverPicResponse{}, err
 	}
 
+	images, err := imaging.ForURLs([]string{response.CoverPicURL})
+	if err != nil {
+		return models.GetCoverPicResponse{}, err
+	}
+	image := images[response.CoverPicURL]
+	response.CoverPicBlurhash = image.Blurhash
+	response.CoverPicVariants = image.Variants
+
 	return response, nil
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/users"
 	"encoding/json"
 	"log"
@@ -17,15 +18,13 @@ func GetFriendStatus(w http.ResponseWriter, r *http.Request) {
 
 	q := r.URL.Query()
 
-	userIDString := q.Get("id")
-	if userIDString == "" {
-		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
 		return
 	}
-	userID, err := strconv.ParseInt(userIDString, 10, 64)
-	if err != nil {
-		log.Println("Failed to parse userIDString (string) to userID (int64) due to the following error: ", err)
-		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+	if userIDString := q.Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
 		return
 	}
 
@@ -52,27 +51,22 @@ func GetFriendStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// getStatus returns the friendship between userID and friendID as userID sees it.
+// Only the user who placed a block learns of it; to the blocked user it looks like
+// there is no friendship at all.
 func getStatus(userID, friendID int64) (models.GetFriendStatusResponse, error) {
-	var status string
-
-	const query = `
-		SELECT IFNULL((
-			SELECT status
-			FROM friendships
-			WHERE (user_id = ? AND friend_id = ?)
-				OR (user_id = ? AND friend_id = ?)
-			LIMIT 1
-		), 'idle') AS status
-	`
-	err := database.DB.QueryRow(query,
-		userID, friendID,
-		friendID, userID,
-	).Scan(&status)
+	f, err := findFriendship(database.DB, userID, friendID, false)
 	if err != nil {
 		return models.GetFriendStatusResponse{}, err
 	}
+	if f == nil || (f.status == friendStatusBlocked && f.direction(userID) == friendDirectionIncoming) {
+		return models.GetFriendStatusResponse{
+			Status: friendStatusIdle,
+		}, nil
+	}
 
 	return models.GetFriendStatusResponse{
-		Status: status,
+		Status:    f.status,
+		Direction: f.direction(userID),
 	}, nil
 }
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/users"
 	"VoizyServer/internal/util"
 	"database/sql"
@@ -31,6 +33,14 @@ func GetProfileHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if viewerID, ok := middleware.GetUserIDFromContext(r.Context()); ok {
+		if err := blocks.Check(viewerID, userID); err != nil {
+			fmt.Println("An error occurred while trying to check for a block: ", err)
+			http.Error(w, "Error getting user profile.", blocks.HTTPStatus(err))
+			return
+		}
+	}
+
 	response, err := getProfile(userID)
 	if err != nil {
 		fmt.Println("An error occurred while trying to get the profile from the database: ", err)
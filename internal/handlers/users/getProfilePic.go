@@ -2,6 +2,8 @@ package handlers
 
 import (
 	database "VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	postModels "VoizyServer/internal/models/posts"
 	models "VoizyServer/internal/models/users"
 	"database/sql"
 	"encoding/json"
@@ -46,10 +48,19 @@ func getProfilePic(userID int64) (models.GetProfilePicResponse, error) {
 	if err != nil {
 		if err == sql.ErrNoRows {
 			response.ProfilePicURL = ""
+			response.ProfilePicVariants = []postModels.ImageVariant{}
 			return response, nil
 		}
 		return models.GetProfilePicResponse{}, err
 	}
 
+	images, err := imaging.ForURLs([]string{response.ProfilePicURL})
+	if err != nil {
+		return models.GetProfilePicResponse{}, err
+	}
+	image := images[response.ProfilePicURL]
+	response.ProfilePicBlurhash = image.Blurhash
+	response.ProfilePicVariants = image.Variants
+
 	return response, nil
 }
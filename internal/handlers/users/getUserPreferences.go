@@ -75,7 +75,13 @@ func getPreferences(userID int64) (models.GetUserPreferencesResponse, error) {
         profile_primary_accent,
         profile_secondary_color,
         profile_secondary_accent,
-        profile_song_autoplay
+        profile_song_autoplay,
+        push_notifications,
+        notify_post_reactions,
+        notify_post_comments,
+        notify_friend_requests,
+        notify_wall_posts,
+        notify_mentions
       FROM user_preferences
       WHERE user_id = ?
       LIMIT 1
@@ -94,6 +100,12 @@ func getPreferences(userID int64) (models.GetUserPreferencesResponse, error) {
 			&response.ProfileSecondaryColor,
 			&response.ProfileSecondaryAccent,
 			&response.ProfileSongAutoplay,
+			&response.PushNotifications,
+			&response.NotifyPostReactions,
+			&response.NotifyPostComments,
+			&response.NotifyFriendRequests,
+			&response.NotifyWallPosts,
+			&response.NotifyMentions,
 		)
 	if err != nil {
 		return response, fmt.Errorf("error fetching preferences: %w", err)
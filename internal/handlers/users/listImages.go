@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
 	models "VoizyServer/internal/models/users"
 	"encoding/json"
 	"log"
@@ -115,6 +116,22 @@ func listImages(userID, limit, page int64) (models.ListImagesResponse, error) {
 	if err := rows.Err(); err != nil {
 		return models.ListImagesResponse{}, err
 	}
+
+	imageURLs := make([]string, len(images))
+	for idx, i := range images {
+		imageURLs[idx] = i.ImageURL
+	}
+	processed, err := imaging.ForURLs(imageURLs)
+	if err != nil {
+		return models.ListImagesResponse{}, err
+	}
+	for idx := range images {
+		p := processed[images[idx].ImageURL]
+		images[idx].Width = p.Width
+		images[idx].Height = p.Height
+		images[idx].Blurhash = p.Blurhash
+		images[idx].Variants = p.Variants
+	}
 	totalPages := int64(math.Ceil(float64(totalImages) / float64(limit)))
 
 	return models.ListImagesResponse{
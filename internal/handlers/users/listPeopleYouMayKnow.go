@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/users"
 	"VoizyServer/internal/util"
 	"database/sql"
@@ -19,15 +21,13 @@ func ListPeopleYouMayKnow(w http.ResponseWriter, r *http.Request) {
 
 	q := r.URL.Query()
 
-	userIDString := q.Get("id")
-	if userIDString == "" {
-		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
 		return
 	}
-	userID, err := strconv.ParseInt(userIDString, 10, 64)
-	if err != nil {
-		log.Println("Failed to parse userIDString (string) to userID (int64) due to the following error: ", err)
-		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+	if userIDString := q.Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
 		return
 	}
 
@@ -68,8 +68,10 @@ func ListPeopleYouMayKnow(w http.ResponseWriter, r *http.Request) {
 
 func listPeople(userID, limit, page int64) (models.ListPeopleYouMayKnowResponse, error) {
 	offset := (page - 1) * limit
+	candidateNotBlocked, candidateBlockArgs := blocks.Exclude("friends_of_friends.fof_id", userID)
+	cityNotBlocked, cityBlockArgs := blocks.Exclude("u.user_id", userID)
 
-	const query = `
+	query := `
     WITH
     direct_friends AS (
       SELECT CASE WHEN user_id = ? THEN friend_id ELSE user_id END AS friend_id
@@ -93,6 +95,7 @@ func listPeople(userID, limit, page int64) (models.ListPeopleYouMayKnowResponse,
       FROM friends_of_friends
       WHERE fof_id != ?                       -- never suggest yourself
         AND fof_id NOT IN (SELECT friend_id FROM direct_friends)
+        AND ` + candidateNotBlocked + `
       GROUP BY fof_id
     ),
     interaction_scores AS (
@@ -168,6 +171,7 @@ func listPeople(userID, limit, page int64) (models.ListPeopleYouMayKnowResponse,
       JOIN user_city_parts ucp
       WHERE u.user_id != ?
         AND u.user_id NOT IN (SELECT friend_id FROM direct_friends)
+        AND ` + cityNotBlocked + `
         AND NOT EXISTS (
           SELECT 1 FROM total_scores ts WHERE ts.user_id = u.user_id
         )
@@ -192,14 +196,16 @@ func listPeople(userID, limit, page int64) (models.ListPeopleYouMayKnowResponse,
 	rows, err := database.DB.Query(query,
 		// 1) direct_friends placeholders
 		userID, userID, userID,
-		// 2) exclude self in candidates
+		// 2) exclude self and blocked users in candidates
 		userID,
+		candidateBlockArgs[0], candidateBlockArgs[1],
 		// 3) reaction filter
 		userID,
 		// 4) pull your city tokens
 		userID,
-		// 5) exclude self in city_rows
+		// 5) exclude self and blocked users in city_rows
 		userID,
+		cityBlockArgs[0], cityBlockArgs[1],
 		// 6) final pagination
 		limit, offset,
 	)
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/users"
+	"VoizyServer/internal/uploads"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -22,10 +24,21 @@ func PutUserImagesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+	if req.UserID != 0 && req.UserID != userID {
+		http.Error(w, "Field 'userID' does not match the authenticated user.", http.StatusForbidden)
+		return
+	}
+	req.UserID = userID
+
 	response, err := putUserImages(req)
 	if err != nil {
 		log.Println("Failed to put user images due to the following error: ", err)
-		http.Error(w, "Failed to put user images.", http.StatusInternalServerError)
+		http.Error(w, "Failed to put user images.", uploads.HTTPStatus(err))
 		return
 	}
 
@@ -34,6 +47,13 @@ func PutUserImagesHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func putUserImages(req models.PutUserImagesRequest) (models.PutUserImagesResponse, error) {
+	if err := uploads.Verify(req.UserID, uploads.UserImage, req.Images); err != nil {
+		return models.PutUserImagesResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to put user images due to the following error: %v", err),
+		}, err
+	}
+
 	query := `
 		INSERT INTO user_images
 		(user_id, image_url)
@@ -131,6 +131,36 @@ func putPreferences(req models.PutUserPreferencesRequest) (models.PutUserPrefere
 		placeholders = append(placeholders, "?")
 		vals = append(vals, *req.ProfileSongAutoplay)
 	}
+	if req.PushNotifications != nil {
+		cols = append(cols, "push_notifications")
+		placeholders = append(placeholders, "?")
+		vals = append(vals, *req.PushNotifications)
+	}
+	if req.NotifyPostReactions != nil {
+		cols = append(cols, "notify_post_reactions")
+		placeholders = append(placeholders, "?")
+		vals = append(vals, *req.NotifyPostReactions)
+	}
+	if req.NotifyPostComments != nil {
+		cols = append(cols, "notify_post_comments")
+		placeholders = append(placeholders, "?")
+		vals = append(vals, *req.NotifyPostComments)
+	}
+	if req.NotifyFriendRequests != nil {
+		cols = append(cols, "notify_friend_requests")
+		placeholders = append(placeholders, "?")
+		vals = append(vals, *req.NotifyFriendRequests)
+	}
+	if req.NotifyWallPosts != nil {
+		cols = append(cols, "notify_wall_posts")
+		placeholders = append(placeholders, "?")
+		vals = append(vals, *req.NotifyWallPosts)
+	}
+	if req.NotifyMentions != nil {
+		cols = append(cols, "notify_mentions")
+		placeholders = append(placeholders, "?")
+		vals = append(vals, *req.NotifyMentions)
+	}
 
 	if err == sql.ErrNoRows {
 		query := fmt.Sprintf(
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"VoizyServer/internal/blocks"
 	"VoizyServer/internal/database"
+	"VoizyServer/internal/middleware"
 	models "VoizyServer/internal/models/users"
 	"VoizyServer/internal/util"
 	sql2 "database/sql"
@@ -19,15 +21,13 @@ func SearchPeople(w http.ResponseWriter, r *http.Request) {
 
 	q := r.URL.Query()
 
-	userIDString := q.Get("id")
-	if userIDString == "" {
-		http.Error(w, "Missing required param 'id'.", http.StatusBadRequest)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
 		return
 	}
-	userID, err := strconv.ParseInt(userIDString, 10, 64)
-	if err != nil {
-		log.Println("Failed to parse userIDString (string) to userID (int64) due to the following error: ", err)
-		http.Error(w, "Failed to parse param 'id'.", http.StatusInternalServerError)
+	if userIDString := q.Get("id"); userIDString != "" && userIDString != strconv.FormatInt(userID, 10) {
+		http.Error(w, "Param 'id' does not match the authenticated user.", http.StatusForbidden)
 		return
 	}
 
@@ -75,8 +75,9 @@ func SearchPeople(w http.ResponseWriter, r *http.Request) {
 
 func search(searchQuery string, userID, limit, page int64) (models.SearchPeopleResponse, error) {
 	offset := (page - 1) * limit
+	notBlocked, blockArgs := blocks.Exclude("u.user_id", userID)
 
-	const sql = `
+	sql := `
     WITH
       direct_friends AS (
         SELECT CASE WHEN user_id = ? THEN friend_id ELSE user_id END AS friend_id
@@ -115,6 +116,7 @@ func search(searchQuery string, userID, limit, page int64) (models.SearchPeopleR
         LEFT JOIN user_profiles up ON up.user_id = u.user_id
         LEFT JOIN user_images   ui ON ui.user_id = u.user_id AND ui.is_profile_pic = 1
         WHERE u.user_id != ?
+          AND ` + notBlocked + `
           AND (
             LOWER(u.username) LIKE CONCAT('%', LOWER(?), '%')
             OR LOWER(up.first_name)     LIKE CONCAT('%', LOWER(?), '%')
@@ -173,6 +175,7 @@ func search(searchQuery string, userID, limit, page int64) (models.SearchPeopleR
 		userID,
 		userID,
 		userID,
+		blockArgs[0], blockArgs[1],
 		searchQuery, searchQuery,
 		searchQuery, searchQuery,
 		searchQuery, searchQuery,
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	models "VoizyServer/internal/models/middleware"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestPeopleRefuseSpoofedViewer makes sure search and people you may know cannot
+// be run as someone else by naming them in the id param, which would let a blocked
+// user find the person who blocked them.
+func TestPeopleRefuseSpoofedViewer(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		handler http.HandlerFunc
+	}{
+		{"SearchPeople", http.MethodPost, SearchPeople},
+		{"ListPeopleYouMayKnow", http.MethodGet, ListPeopleYouMayKnow},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "/?id=2&limit=10&page=1", strings.NewReader(`{"query":"a"}`))
+		r = r.WithContext(context.WithValue(r.Context(), models.UserIDContextKey, int64(1)))
+		w := httptest.NewRecorder()
+		tt.handler(w, r)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s with a spoofed id answered %d, want %d", tt.name, w.Code, http.StatusForbidden)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"VoizyServer/internal/middleware"
+	models "VoizyServer/internal/models/users"
+	"VoizyServer/internal/notifications"
+	"VoizyServer/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+var friendActionEvents = map[string]string{
+	friendActionAccept:   "accept_friend_request",
+	friendActionDecline:  "decline_friend_request",
+	friendActionCancel:   "cancel_friend_request",
+	friendActionUnfriend: "unfriend",
+	friendActionBlock:    "block_user",
+	friendActionUnblock:  "unblock_user",
+}
+
+func UpdateFriendshipHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.UpdateFriendshipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if req.FriendID <= 0 {
+		http.Error(w, "Missing required field 'friendID'.", http.StatusBadRequest)
+		return
+	}
+	eventType, ok := friendActionEvents[req.Action]
+	if !ok {
+		http.Error(w, "Invalid field 'action'; must be one of accept, decline, cancel, unfriend, block or unblock.", http.StatusBadRequest)
+		return
+	}
+
+	response, err := updateFriendship(userID, req)
+	if err != nil {
+		log.Println("Failed to update friendship due to the following error: ", err)
+		http.Error(w, fmt.Sprintf("Failed to update friendship (%v).", err), statusForFriendshipError(err))
+		return
+	}
+
+	var friendshipID *int64
+	if response.FriendshipID > 0 {
+		friendshipID = &response.FriendshipID
+	}
+	go util.TrackEvent(userID, eventType, "friendship", friendshipID, map[string]interface{}{
+		"friendID": req.FriendID,
+	})
+	if req.Action == friendActionAccept {
+		go notifications.Send(notifications.Notification{
+			RecipientID: req.FriendID,
+			ActorID:     userID,
+			Type:        notifications.TypeFriendRequestAccepted,
+			ObjectType:  "friendship",
+			ObjectID:    response.FriendshipID,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func updateFriendship(userID int64, req models.UpdateFriendshipRequest) (models.UpdateFriendshipResponse, error) {
+	f, err := applyFriendshipAction(userID, req.FriendID, req.Action)
+	if err != nil {
+		return models.UpdateFriendshipResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to %s due to the following error: %v", req.Action, err),
+		}, err
+	}
+
+	if f == nil {
+		return models.UpdateFriendshipResponse{
+			Success: true,
+			Message: fmt.Sprintf("Successfully applied %s.", req.Action),
+			Status:  friendStatusIdle,
+		}, nil
+	}
+
+	return models.UpdateFriendshipResponse{
+		Success:      true,
+		Message:      fmt.Sprintf("Successfully applied %s.", req.Action),
+		FriendshipID: f.friendshipID,
+		Status:       f.status,
+		Direction:    f.direction(userID),
+	}, nil
+}
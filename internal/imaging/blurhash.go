@@ -0,0 +1,108 @@
+package imaging
+
+import (
+	"image"
+	"math"
+	"strings"
+)
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// blurhashSampleWidth is the width images are shrunk to before the blurhash is
+// computed. The hash only keeps a handful of frequencies, so more pixels would
+// only cost time.
+const blurhashSampleWidth = 32
+
+// blurhash encodes img as a BlurHash (https://blurha.sh): a short string clients
+// decode into a blurred placeholder while the real image loads. It uses four
+// components along the longer side and three along the shorter one.
+func blurhash(img *image.NRGBA) string {
+	if img.Rect.Dx() > blurhashSampleWidth {
+		img = resize(img, blurhashSampleWidth)
+	}
+	xComponents, yComponents := 4, 3
+	if img.Rect.Dy() > img.Rect.Dx() {
+		xComponents, yComponents = 3, 4
+	}
+
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			var factor [3]float64
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					basis := normalisation *
+						math.Cos(math.Pi*float64(i)*float64(x)/float64(w)) *
+						math.Cos(math.Pi*float64(j)*float64(y)/float64(h))
+					p := img.Pix[img.PixOffset(x, y):]
+					factor[0] += basis * srgbToLinear(p[0])
+					factor[1] += basis * srgbToLinear(p[1])
+					factor[2] += basis * srgbToLinear(p[2])
+				}
+			}
+			scale := 1 / float64(w*h)
+			factors = append(factors, [3]float64{factor[0] * scale, factor[1] * scale, factor[2] * scale})
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encode83((xComponents-1)+(yComponents-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maximum := 1.0
+	if len(ac) > 0 {
+		actualMaximum := 0.0
+		for _, f := range ac {
+			actualMaximum = math.Max(actualMaximum, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		quantised := int(math.Max(0, math.Min(82, math.Floor(actualMaximum*166-0.5))))
+		maximum = float64(quantised+1) / 166
+		hash.WriteString(encode83(quantised, 1))
+	} else {
+		hash.WriteString(encode83(0, 1))
+	}
+
+	hash.WriteString(encode83(linearToSRGB(dc[0])<<16|linearToSRGB(dc[1])<<8|linearToSRGB(dc[2]), 4))
+	for _, f := range ac {
+		hash.WriteString(encode83(encodeAC(f, maximum), 2))
+	}
+	return hash.String()
+}
+
+func encodeAC(f [3]float64, maximum float64) int {
+	quant := func(v float64) int {
+		signed := math.Copysign(math.Pow(math.Abs(v/maximum), 0.5), v)
+		return int(math.Max(0, math.Min(18, math.Floor(signed*9+9.5))))
+	}
+	return quant(f[0])*19*19 + quant(f[1])*19 + quant(f[2])
+}
+
+func encode83(value, length int) string {
+	var b strings.Builder
+	for i := 1; i <= length; i++ {
+		digit := value / int(math.Pow(83, float64(length-i))) % 83
+		b.WriteByte(base83Chars[digit])
+	}
+	return b.String()
+}
+
+func srgbToLinear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
@@ -0,0 +1,461 @@
+// Package imaging makes uploaded images fit to serve. Once an image upload is
+// confirmed it is decoded, turned upright and stripped of its EXIF data, which can
+// hold the GPS position a photo was taken at. Smaller copies and a blurhash
+// placeholder are then made of it and recorded against the upload. post_media and
+// user_images rows reach them through their URL, so responses can offer clients a
+// srcset instead of the full-size original.
+package imaging
+
+import (
+	"VoizyServer/internal/database"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/storage"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// variantWidths are the widths resized copies are made at. Only widths smaller
+// than the image itself are made.
+var variantWidths = []int{320, 640, 1080}
+
+const (
+	variantQuality = 80
+	// fullQuality is used when the full-size image has to be encoded again to turn
+	// it upright.
+	fullQuality = 90
+
+	// maxSourceBytes is more than any upload policy allows, so it only guards
+	// against objects that were not uploaded through it.
+	maxSourceBytes = 32 << 20
+
+	processTimeout = 2 * time.Minute
+	// staleProcessing is how long an image may be processing before it is assumed
+	// the server processing it went away and it is taken up again.
+	staleProcessing = 10 * time.Minute
+
+	workerBatchSize = 20
+)
+
+// errUnprocessable marks images that will never process, such as files that do
+// not decode. Other failures are retried.
+var errUnprocessable = errors.New("image cannot be processed")
+
+type variant struct {
+	key         string
+	width       int
+	height      int
+	contentType string
+}
+
+type processed struct {
+	size     int64
+	width    int
+	height   int
+	blurhash string
+	variants []variant
+}
+
+// Process processes the confirmed image upload under key, unless it has already
+// been processed or is being processed elsewhere. It is meant to run in its own
+// goroutine after the upload is confirmed, so it logs failures instead of
+// returning them.
+func Process(key string) {
+	if err := process(key); err != nil {
+		log.Printf("Failed to process image %s due to the following error: %v", key, err)
+	}
+}
+
+// StartWorker periodically processes confirmed images that have not been processed
+// yet, such as those uploaded while the server was restarting or before images
+// were processed at all. It blocks, so it should be started in its own goroutine.
+func StartWorker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := processPending(); err != nil {
+			log.Println("Failed to process pending images due to the following error: ", err)
+		}
+		<-ticker.C
+	}
+}
+
+func processPending() error {
+	rows, err := database.DB.Query(`
+		SELECT object_key
+		FROM uploads
+		WHERE status = 'confirmed'
+			AND content_type LIKE 'image/%'
+			AND (processing_status = 'pending'
+				OR (processing_status = 'processing' AND processed_at < DATE_SUB(NOW(), INTERVAL ? SECOND)))
+		ORDER BY upload_id ASC
+		LIMIT ?
+	`, int64(staleProcessing.Seconds()), workerBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to select pending images: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			log.Println("Scan rows error: ", err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	for _, key := range keys {
+		Process(key)
+	}
+	return nil
+}
+
+func process(key string) error {
+	uploadID, ok, err := claim(key)
+	if err != nil || !ok {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), processTimeout)
+	defer cancel()
+
+	result, err := render(ctx, key)
+	if err != nil {
+		status := "pending"
+		if errors.Is(err, errUnprocessable) {
+			status = "failed"
+		}
+		if _, dbErr := database.DB.Exec(`
+			UPDATE uploads SET processing_status = ?, processed_at = NOW() WHERE upload_id = ?
+		`, status, uploadID); dbErr != nil {
+			log.Println("Failed to release image due to the following error: ", dbErr)
+		}
+		return err
+	}
+	return save(uploadID, result)
+}
+
+// claim marks the upload under key as processing, so no other server processes it
+// at the same time. It reports false when there is nothing to process.
+func claim(key string) (int64, bool, error) {
+	result, err := database.DB.Exec(`
+		UPDATE uploads
+		SET processing_status = 'processing', processed_at = NOW()
+		WHERE object_key = ?
+			AND status = 'confirmed'
+			AND content_type LIKE 'image/%'
+			AND (processing_status = 'pending'
+				OR (processing_status = 'processing' AND processed_at < DATE_SUB(NOW(), INTERVAL ? SECOND)))
+	`, key, int64(staleProcessing.Seconds()))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to claim image: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return 0, false, nil
+	}
+
+	var uploadID int64
+	if err := database.DB.QueryRow(`SELECT upload_id FROM uploads WHERE object_key = ?`, key).Scan(&uploadID); err != nil {
+		return 0, false, fmt.Errorf("failed to get claimed image: %w", err)
+	}
+	return uploadID, true, nil
+}
+
+// render replaces the original with an upright copy without metadata and stores
+// the resized copies next to it.
+func render(ctx context.Context, key string) (processed, error) {
+	store := storage.DefaultStore
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		return processed{}, err
+	}
+	data, err := io.ReadAll(io.LimitReader(body, maxSourceBytes+1))
+	body.Close()
+	if err != nil {
+		return processed{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > maxSourceBytes {
+		return processed{}, fmt.Errorf("%w: larger than %d bytes", errUnprocessable, maxSourceBytes)
+	}
+
+	img, format, orientation, err := decode(data)
+	if err != nil {
+		return processed{}, fmt.Errorf("%w: %v", errUnprocessable, err)
+	}
+
+	original, upright, err := strip(data, img, format, orientation)
+	if err != nil {
+		return processed{}, fmt.Errorf("%w: %v", errUnprocessable, err)
+	}
+	if !bytes.Equal(original, data) {
+		if err := store.Put(ctx, key, bytes.NewReader(original), "image/"+format); err != nil {
+			return processed{}, err
+		}
+	}
+
+	result := processed{
+		size:     int64(len(original)),
+		width:    img.Rect.Dx(),
+		height:   img.Rect.Dy(),
+		blurhash: blurhash(img),
+	}
+	for _, width := range variantWidths {
+		if width >= result.width {
+			break
+		}
+		v, err := putVariant(ctx, key, resize(img, width), variantQuality)
+		if err != nil {
+			return processed{}, err
+		}
+		result.variants = append(result.variants, v)
+	}
+	// An original that could not be turned upright in place gets an upright copy
+	// at full size, which then takes its place in the srcset.
+	if !upright {
+		v, err := putVariant(ctx, key, img, fullQuality)
+		if err != nil {
+			return processed{}, err
+		}
+		result.variants = append(result.variants, v)
+	}
+	return result, nil
+}
+
+// strip returns the original without its metadata. JPEGs and PNGs that are not
+// upright are encoded again from the turned image; WebPs cannot be encoded here,
+// so for them upright is false. GIFs carry no EXIF and are left alone.
+func strip(data []byte, img *image.NRGBA, format string, orientation int) ([]byte, bool, error) {
+	switch format {
+	case "jpeg":
+		if orientation != 1 {
+			encoded, _, err := encode(img, fullQuality)
+			return encoded, true, err
+		}
+		stripped, err := stripJPEG(data)
+		return stripped, true, err
+	case "png":
+		if orientation != 1 {
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, img); err != nil {
+				return nil, false, fmt.Errorf("failed to encode png: %w", err)
+			}
+			return buf.Bytes(), true, nil
+		}
+		stripped, err := stripPNG(data)
+		return stripped, true, err
+	case "webp":
+		stripped, err := stripWebP(data)
+		return stripped, orientation == 1, err
+	}
+	return data, true, nil
+}
+
+func putVariant(ctx context.Context, key string, img *image.NRGBA, quality int) (variant, error) {
+	encoded, contentType, err := encode(img, quality)
+	if err != nil {
+		return variant{}, err
+	}
+	v := variant{
+		key:         variantKey(key, img.Rect.Dx(), contentType),
+		width:       img.Rect.Dx(),
+		height:      img.Rect.Dy(),
+		contentType: contentType,
+	}
+	if err := storage.DefaultStore.Put(ctx, v.key, bytes.NewReader(encoded), contentType); err != nil {
+		return variant{}, err
+	}
+	return v, nil
+}
+
+// variantKey is where the copy of key at width is stored. Uploads are always under
+// a user ID, so the variants prefix never clashes with them.
+func variantKey(key string, width int, contentType string) string {
+	ext := ".jpg"
+	if contentType == "image/png" {
+		ext = ".png"
+	}
+	return "variants/" + key + "/" + strconv.Itoa(width) + "w" + ext
+}
+
+func save(uploadID int64, result processed) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM image_variants WHERE upload_id = ?`, uploadID); err != nil {
+		return fmt.Errorf("failed to clear image variants: %w", err)
+	}
+	for _, v := range result.variants {
+		_, err := tx.Exec(`
+			INSERT INTO image_variants (upload_id, object_key, width, height, content_type)
+			VALUES (?, ?, ?, ?, ?)
+		`, uploadID, v.key, v.width, v.height, v.contentType)
+		if err != nil {
+			return fmt.Errorf("failed to insert image variant: %w", err)
+		}
+	}
+	_, err = tx.Exec(`
+		UPDATE uploads
+		SET size = ?, width = ?, height = ?, blurhash = ?, processing_status = 'done', processed_at = NOW()
+		WHERE upload_id = ?
+	`, result.size, result.width, result.height, result.blurhash, uploadID)
+	if err != nil {
+		return fmt.Errorf("failed to save processed image: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit processed image: %w", err)
+	}
+	return nil
+}
+
+// ForURLs returns the processed form of each image URL, keyed by URL. Every URL
+// has an entry; images that are not processed, or were not uploaded through our
+// storage, only have their URL set.
+func ForURLs(urls []string) (map[string]models.Image, error) {
+	images := make(map[string]models.Image, len(urls))
+	urlsByKey := make(map[string][]string)
+	for _, u := range urls {
+		images[u] = models.Image{URL: u, Variants: []models.ImageVariant{}}
+		if key, ok := storage.DefaultStore.KeyFromURL(u); ok {
+			urlsByKey[key] = append(urlsByKey[key], u)
+		}
+	}
+	if len(urlsByKey) == 0 {
+		return images, nil
+	}
+
+	placeholders := make([]string, 0, len(urlsByKey))
+	args := make([]interface{}, 0, len(urlsByKey))
+	for key := range urlsByKey {
+		placeholders = append(placeholders, "?")
+		args = append(args, key)
+	}
+	rows, err := database.DB.Query(`
+		SELECT u.object_key, u.width, u.height, u.blurhash, v.object_key, v.width, v.height
+		FROM uploads u
+		LEFT JOIN image_variants v ON v.upload_id = u.upload_id
+		WHERE u.object_key IN (`+strings.Join(placeholders, ",")+`)
+			AND u.processing_status = 'done'
+		ORDER BY u.upload_id ASC, v.width ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image variants: %w", err)
+	}
+	defer rows.Close()
+
+	byKey := make(map[string]*models.Image)
+	for rows.Next() {
+		var (
+			key           string
+			width         int
+			height        int
+			hash          string
+			variantKey    sql.NullString
+			variantWidth  sql.NullInt64
+			variantHeight sql.NullInt64
+		)
+		if err := rows.Scan(&key, &width, &height, &hash, &variantKey, &variantWidth, &variantHeight); err != nil {
+			return nil, fmt.Errorf("failed to scan image variant: %w", err)
+		}
+		img, ok := byKey[key]
+		if !ok {
+			img = &models.Image{Width: &width, Height: &height, Blurhash: &hash, Variants: []models.ImageVariant{}}
+			byKey[key] = img
+		}
+		if variantKey.Valid {
+			img.Variants = append(img.Variants, models.ImageVariant{
+				URL:    storage.DefaultStore.URL(variantKey.String),
+				Width:  int(variantWidth.Int64),
+				Height: int(variantHeight.Int64),
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over image variants: %w", err)
+	}
+
+	for key, img := range byKey {
+		variants := img.Variants
+		if n := len(variants); n == 0 || variants[n-1].Width < *img.Width {
+			variants = append(variants, models.ImageVariant{
+				URL:    storage.DefaultStore.URL(key),
+				Width:  *img.Width,
+				Height: *img.Height,
+			})
+		}
+		for _, u := range urlsByKey[key] {
+			images[u] = models.Image{
+				URL:      u,
+				Width:    img.Width,
+				Height:   img.Height,
+				Blurhash: img.Blurhash,
+				Variants: variants,
+			}
+		}
+	}
+	return images, nil
+}
+
+// Variants returns the srcset of the image at url among images returned by
+// ForURLs. It is empty, not nil, when there is no image.
+func Variants(images map[string]models.Image, url *string) []models.ImageVariant {
+	if url == nil {
+		return []models.ImageVariant{}
+	}
+	if img, ok := images[*url]; ok {
+		return img.Variants
+	}
+	return []models.ImageVariant{}
+}
+
+// DeleteVariants deletes the resized copies of the upload under key from storage.
+// Their rows go when the upload's row is deleted.
+func DeleteVariants(ctx context.Context, key string) error {
+	rows, err := database.DB.Query(`
+		SELECT v.object_key
+		FROM image_variants v
+		JOIN uploads u ON u.upload_id = v.upload_id
+		WHERE u.object_key = ?
+	`, key)
+	if err != nil {
+		return fmt.Errorf("failed to get image variants: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var variantKey string
+		if err := rows.Scan(&variantKey); err != nil {
+			return fmt.Errorf("failed to scan image variant: %w", err)
+		}
+		keys = append(keys, variantKey)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over image variants: %w", err)
+	}
+
+	for _, variantKey := range keys {
+		if err := storage.DefaultStore.Delete(ctx, variantKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
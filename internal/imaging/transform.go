@@ -0,0 +1,345 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// maxPixels caps the size of the images that are decoded. A small file can
+// declare enormous dimensions, and decoding it would take all the memory we have.
+const maxPixels = 40_000_000
+
+var (
+	errTooManyPixels = errors.New("image has too many pixels to process")
+	errBadMetadata   = errors.New("image metadata is malformed")
+)
+
+// decode decodes data after checking its declared dimensions, and returns the
+// image the right way up together with its format.
+func decode(data []byte) (*image.NRGBA, string, int, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to read image header: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, "", 0, errTooManyPixels
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to decode image: %w", err)
+	}
+	orientation := exifOrientation(data, format)
+	return orient(toNRGBA(img), orientation), format, orientation, nil
+}
+
+func toNRGBA(img image.Image) *image.NRGBA {
+	if nrgba, ok := img.(*image.NRGBA); ok && nrgba.Rect.Min == (image.Point{}) {
+		return nrgba
+	}
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst
+}
+
+// orient turns img the way an EXIF orientation tag says it has to be turned to
+// be displayed upright. Orientations 5 to 8 swap width and height.
+func orient(img *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], img.Pix[img.PixOffset(sx, sy):img.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+// resize scales img to width, keeping its aspect ratio.
+func resize(img *image.NRGBA, width int) *image.NRGBA {
+	b := img.Bounds()
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// encode writes img as a JPEG, or as a PNG when it has transparency a JPEG would
+// lose. It returns the content type it chose.
+func encode(img *image.NRGBA, quality int) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if !img.Opaque() {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", fmt.Errorf("failed to encode png: %w", err)
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, "", fmt.Errorf("failed to encode jpeg: %w", err)
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+// exifOrientation returns the EXIF orientation of an image, 1 when it has none.
+func exifOrientation(data []byte, format string) int {
+	var tiff []byte
+	switch format {
+	case "jpeg":
+		tiff = jpegExif(data)
+	case "png":
+		tiff, _ = pngChunk(data, "eXIf")
+	case "webp":
+		tiff, _ = webpChunk(data, "EXIF")
+		tiff = bytes.TrimPrefix(tiff, []byte("Exif\x00\x00"))
+	}
+	orientation, err := tiffOrientation(tiff)
+	if err != nil || orientation < 1 || orientation > 8 {
+		return 1
+	}
+	return orientation
+}
+
+// tiffOrientation reads the orientation tag from the first IFD of an EXIF block.
+func tiffOrientation(tiff []byte) (int, error) {
+	if len(tiff) < 8 {
+		return 1, nil
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, errBadMetadata
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 0, errBadMetadata
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0, errBadMetadata
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0, errBadMetadata
+		}
+		// Orientation is tag 0x0112, a single SHORT stored in the entry itself.
+		if order.Uint16(tiff[entry:]) == 0x0112 && order.Uint16(tiff[entry+2:]) == 3 {
+			return int(order.Uint16(tiff[entry+8:])), nil
+		}
+	}
+	return 1, nil
+}
+
+// jpegSegments calls fn with the marker and payload of every segment before the
+// image data starts. fn returns whether to keep going.
+func jpegSegments(data []byte, fn func(marker byte, start, end int, payload []byte) bool) error {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return errBadMetadata
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return errBadMetadata
+		}
+		marker := data[i+1]
+		if marker == 0xFF {
+			i++
+			continue
+		}
+		// Start of scan: everything after is image data.
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return errBadMetadata
+		}
+		if !fn(marker, i, i+2+length, data[i+4:i+2+length]) {
+			return nil
+		}
+		i += 2 + length
+	}
+	return errBadMetadata
+}
+
+func jpegExif(data []byte) []byte {
+	var tiff []byte
+	jpegSegments(data, func(marker byte, start, end int, payload []byte) bool {
+		if marker == 0xE1 && bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			tiff = payload[6:]
+			return false
+		}
+		return true
+	})
+	return tiff
+}
+
+// stripJPEG drops the EXIF, XMP and IPTC segments and comments from a JPEG
+// without touching the image data. The colour profile and the JFIF and Adobe
+// segments decoders need are kept.
+func stripJPEG(data []byte) ([]byte, error) {
+	var drop [][2]int
+	err := jpegSegments(data, func(marker byte, start, end int, payload []byte) bool {
+		if marker == 0xE1 || marker == 0xED || marker == 0xFE {
+			drop = append(drop, [2]int{start, end})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cut(data, drop), nil
+}
+
+// pngMetadataChunks are the ancillary chunks that can carry EXIF or free text.
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// pngChunks calls fn with the type and extent of every chunk of a PNG.
+func pngChunks(data []byte, fn func(chunkType string, start, end int, payload []byte)) error {
+	if len(data) < 8 || string(data[1:4]) != "PNG" {
+		return errBadMetadata
+	}
+	for i := 8; i < len(data); {
+		if i+12 > len(data) {
+			return errBadMetadata
+		}
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return errBadMetadata
+		}
+		fn(string(data[i+4:i+8]), i, end, data[i+8:i+8+length])
+		i = end
+	}
+	return nil
+}
+
+func pngChunk(data []byte, chunkType string) ([]byte, error) {
+	var found []byte
+	err := pngChunks(data, func(t string, start, end int, payload []byte) {
+		if t == chunkType && found == nil {
+			found = payload
+		}
+	})
+	return found, err
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	var drop [][2]int
+	err := pngChunks(data, func(t string, start, end int, payload []byte) {
+		if pngMetadataChunks[t] {
+			drop = append(drop, [2]int{start, end})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cut(data, drop), nil
+}
+
+// webpChunks calls fn with the FourCC and extent of every chunk of a WebP file.
+func webpChunks(data []byte, fn func(fourCC string, start, end int, payload []byte)) error {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return errBadMetadata
+	}
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return errBadMetadata
+		}
+		length := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + length + length%2
+		if length < 0 || i+8+length > len(data) {
+			return errBadMetadata
+		}
+		if end > len(data) {
+			end = len(data)
+		}
+		fn(string(data[i:i+4]), i, end, data[i+8:i+8+length])
+		i = end
+	}
+	return nil
+}
+
+func webpChunk(data []byte, fourCC string) ([]byte, error) {
+	var found []byte
+	err := webpChunks(data, func(c string, start, end int, payload []byte) {
+		if c == fourCC && found == nil {
+			found = payload
+		}
+	})
+	return found, err
+}
+
+// stripWebP drops the EXIF and XMP chunks of an extended WebP and clears the
+// flags that announce them.
+func stripWebP(data []byte) ([]byte, error) {
+	var drop [][2]int
+	vp8x := -1
+	err := webpChunks(data, func(c string, start, end int, payload []byte) {
+		switch c {
+		case "EXIF", "XMP ":
+			drop = append(drop, [2]int{start, end})
+		case "VP8X":
+			vp8x = start + 8
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	stripped := cut(data, drop)
+	if vp8x >= 0 {
+		stripped[vp8x] &^= 0x08 | 0x04
+	}
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+	return stripped, nil
+}
+
+// cut returns data without the given ranges, which must be ordered and disjoint.
+func cut(data []byte, ranges [][2]int) []byte {
+	out := make([]byte, 0, len(data))
+	prev := 0
+	for _, r := range ranges {
+		out = append(out, data[prev:r[0]]...)
+		prev = r[1]
+	}
+	return append(out, data[prev:]...)
+}
@@ -0,0 +1,198 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	fetchTimeout = 5 * time.Second
+	maxBodyBytes = 512 << 10
+	maxRedirects = 3
+)
+
+var (
+	errUnsupportedURL   = errors.New("only http and https URLs can be previewed")
+	errBlockedAddress   = errors.New("address is not publicly routable")
+	errTooManyRedirects = errors.New("too many redirects")
+	errNotHTML          = errors.New("response is not an HTML page")
+)
+
+// Page is a fetched HTML document. URL is where it was finally served from, after
+// any redirects, so relative image URLs resolve against the right base.
+type Page struct {
+	URL  *url.URL
+	Body []byte
+}
+
+// Fetcher downloads the page a link points at.
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*Page, error)
+}
+
+// HTTPFetcher fetches pages over HTTP with Client, reading at most MaxBytes of each
+// body. NewSafeFetcher returns one that is safe to point at user-supplied URLs; a
+// zero MaxBytes means maxBodyBytes.
+type HTTPFetcher struct {
+	Client   *http.Client
+	MaxBytes int64
+}
+
+// NewSafeFetcher returns a fetcher that only ever connects to public addresses. The
+// check runs on the resolved address of every connection, redirects included, so
+// neither a hostname resolving to a private range nor a redirect to one gets
+// through. Proxies from the environment are ignored, since they would connect on
+// our behalf without the check.
+func NewSafeFetcher() *HTTPFetcher {
+	return newFetcher(checkDialAddress)
+}
+
+// newFetcher returns a fetcher that runs checkDial before every connection, and
+// otherwise behaves like NewSafeFetcher's. A nil checkDial allows any address.
+func newFetcher(checkDial func(network, address string, c syscall.RawConn) error) *HTTPFetcher {
+	dialer := &net.Dialer{
+		Timeout: fetchTimeout,
+		Control: checkDial,
+	}
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   fetchTimeout,
+		ResponseHeaderTimeout: fetchTimeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+	return &HTTPFetcher{
+		Client: &http.Client{
+			Transport: transport,
+			Timeout:   fetchTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > maxRedirects {
+					return errTooManyRedirects
+				}
+				return checkURL(req.URL)
+			},
+		},
+		MaxBytes: maxBodyBytes,
+	}
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (*Page, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+	if err := checkURL(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", "VoizyBot/1.0 (+link previews)")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch page: status %d", resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, errNotHTML
+	}
+
+	maxBytes := f.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = maxBodyBytes
+	}
+	// The metadata lives in <head>, so a page cut off at the limit still previews.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+
+	return &Page{URL: resp.Request.URL, Body: body}, nil
+}
+
+// checkURL rejects anything but plain http and https. Credentials in the URL are
+// refused too, as a link in a post has no business carrying them.
+func checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errUnsupportedURL
+	}
+	if u.User != nil || u.Hostname() == "" {
+		return errUnsupportedURL
+	}
+	return nil
+}
+
+// checkDialAddress runs just before each connection is made, once the hostname has
+// been resolved, and refuses any address that is not publicly routable or any port
+// but the default web ones.
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	if network != "tcp4" && network != "tcp6" {
+		return errBlockedAddress
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return errBlockedAddress
+	}
+	if port != "80" && port != "443" {
+		return errBlockedAddress
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !isPublic(addr) {
+		return errBlockedAddress
+	}
+	return nil
+}
+
+// nonPublicPrefixes are the special-purpose ranges that netip's predicates do not
+// already cover.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsUnspecified() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,250 @@
+// Package linkpreview unfurls the links people put in posts and messages. When text
+// is saved, the pages it links to are fetched in the background and their
+// OpenGraph, Twitter card or <title> metadata is cached in link_previews for a
+// while. Responses then carry the cached previews of the links in their text; a
+// link that has not been fetched yet, or could not be, simply has none.
+package linkpreview
+
+import (
+	"VoizyServer/internal/database"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// maxLinks is how many links in one text get a preview.
+	maxLinks = 3
+	// maxURLLength keeps absurd URLs out of the cache.
+	maxURLLength = 2048
+
+	previewTTL = 24 * time.Hour
+	// failedTTL is how long a page that could not be previewed is left alone before
+	// it is tried again.
+	failedTTL = time.Hour
+)
+
+// DefaultFetcher fetches the pages previews are made from.
+var DefaultFetcher Fetcher = NewSafeFetcher()
+
+var urlPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"'` + "`" + `]+`)
+
+// ExtractURLs returns the first few distinct http and https links in text, in the
+// order they appear. Punctuation that ends a sentence is not taken as part of the
+// link, and neither is a closing bracket without a matching opening one.
+func ExtractURLs(text string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, match := range urlPattern.FindAllString(text, -1) {
+		link := trimLink(match)
+		if seen[link] || len(link) > maxURLLength {
+			continue
+		}
+		u, err := url.Parse(link)
+		if err != nil || checkURL(u) != nil {
+			continue
+		}
+		seen[link] = true
+		urls = append(urls, link)
+		if len(urls) == maxLinks {
+			break
+		}
+	}
+	return urls
+}
+
+func trimLink(link string) string {
+	for link != "" {
+		last := link[len(link)-1]
+		switch {
+		case strings.IndexByte(".,;:!?'\"*", last) >= 0:
+			link = link[:len(link)-1]
+		case last == ')' && strings.Count(link, "(") < strings.Count(link, ")"):
+			link = link[:len(link)-1]
+		case last == ']' && strings.Count(link, "[") < strings.Count(link, "]"):
+			link = link[:len(link)-1]
+		default:
+			return link
+		}
+	}
+	return link
+}
+
+func hashURL(link string) string {
+	sum := sha256.Sum256([]byte(link))
+	return hex.EncodeToString(sum[:])
+}
+
+// Refresh fetches the previews of urls that are not cached or whose cache entry
+// has expired. It is meant to run in its own goroutine after the text is saved, so
+// it logs failures instead of returning them.
+func Refresh(urls []string) {
+	if len(urls) == 0 {
+		return
+	}
+
+	placeholders := make([]string, len(urls))
+	args := make([]interface{}, len(urls))
+	for i, link := range urls {
+		placeholders[i] = "?"
+		args[i] = hashURL(link)
+	}
+	rows, err := database.DB.Query(`
+		SELECT url_hash
+		FROM link_previews
+		WHERE url_hash IN (`+strings.Join(placeholders, ",")+`)
+			AND expires_at > NOW()
+	`, args...)
+	if err != nil {
+		log.Println("Failed to check cached link previews due to the following error: ", err)
+		return
+	}
+	fresh := make(map[string]bool)
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			rows.Close()
+			log.Println("Failed to scan cached link preview due to the following error: ", err)
+			return
+		}
+		fresh[hash] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Println("Failed to iterate over cached link previews due to the following error: ", err)
+		return
+	}
+
+	for _, link := range urls {
+		if fresh[hashURL(link)] {
+			continue
+		}
+		if err := refreshURL(link); err != nil {
+			log.Printf("Failed to save link preview for %s due to the following error: %v", link, err)
+		}
+	}
+}
+
+func refreshURL(link string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	var meta metadata
+	page, err := DefaultFetcher.Fetch(ctx, link)
+	if err == nil {
+		meta = parse(page)
+	}
+	if err != nil || meta.empty() {
+		if err != nil {
+			log.Printf("Failed to fetch link preview for %s due to the following error: %v", link, err)
+		}
+		// A page that previewed before keeps its last good preview until it can be
+		// fetched again.
+		_, err := database.DB.Exec(`
+			INSERT INTO link_previews (url_hash, url, status, fetched_at, expires_at)
+			VALUES (?, ?, 'failed', NOW(), DATE_ADD(NOW(), INTERVAL ? SECOND))
+			ON DUPLICATE KEY UPDATE expires_at = VALUES(expires_at)
+		`, hashURL(link), link, int64(failedTTL.Seconds()))
+		return err
+	}
+
+	_, err = database.DB.Exec(`
+		INSERT INTO link_previews (url_hash, url, status, title, description, image_url, site_name, fetched_at, expires_at)
+		VALUES (?, ?, 'ok', ?, ?, ?, ?, NOW(), DATE_ADD(NOW(), INTERVAL ? SECOND))
+		ON DUPLICATE KEY UPDATE
+			status = VALUES(status),
+			title = VALUES(title),
+			description = VALUES(description),
+			image_url = VALUES(image_url),
+			site_name = VALUES(site_name),
+			fetched_at = VALUES(fetched_at),
+			expires_at = VALUES(expires_at)
+	`, hashURL(link), link, nullIfEmpty(meta.title), nullIfEmpty(meta.description), nullIfEmpty(meta.imageURL), nullIfEmpty(meta.siteName), int64(previewTTL.Seconds()))
+	return err
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// ForTexts returns the cached previews of the links in each text, keyed like
+// texts. Every key has an entry, empty when none of its links has a preview.
+// Expired previews are still returned; they are only refreshed when the links are
+// saved again.
+func ForTexts(texts map[int64]string) (map[int64][]models.LinkPreview, error) {
+	previews := make(map[int64][]models.LinkPreview, len(texts))
+	urlsByID := make(map[int64][]string, len(texts))
+	hashes := make(map[string]bool)
+	for id, text := range texts {
+		previews[id] = []models.LinkPreview{}
+		urlsByID[id] = ExtractURLs(text)
+		for _, link := range urlsByID[id] {
+			hashes[hashURL(link)] = true
+		}
+	}
+	if len(hashes) == 0 {
+		return previews, nil
+	}
+
+	placeholders := make([]string, 0, len(hashes))
+	args := make([]interface{}, 0, len(hashes))
+	for hash := range hashes {
+		placeholders = append(placeholders, "?")
+		args = append(args, hash)
+	}
+	rows, err := database.DB.Query(`
+		SELECT url_hash, title, description, image_url, site_name, fetched_at
+		FROM link_previews
+		WHERE url_hash IN (`+strings.Join(placeholders, ",")+`)
+			AND status = 'ok'
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link previews: %w", err)
+	}
+	defer rows.Close()
+
+	cached := make(map[string]models.LinkPreview)
+	for rows.Next() {
+		var (
+			hash        string
+			title       sql.NullString
+			description sql.NullString
+			imageURL    sql.NullString
+			siteName    sql.NullString
+			preview     models.LinkPreview
+		)
+		if err := rows.Scan(&hash, &title, &description, &imageURL, &siteName, &preview.FetchedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan link preview: %w", err)
+		}
+		preview.Title = util.SqlNullStringToPtr(title)
+		preview.Description = util.SqlNullStringToPtr(description)
+		preview.ImageURL = util.SqlNullStringToPtr(imageURL)
+		preview.SiteName = util.SqlNullStringToPtr(siteName)
+		cached[hash] = preview
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over link previews: %w", err)
+	}
+
+	for id, urls := range urlsByID {
+		for _, link := range urls {
+			if preview, ok := cached[hashURL(link)]; ok {
+				preview.URL = link
+				previews[id] = append(previews[id], preview)
+			}
+		}
+	}
+	return previews, nil
+}
@@ -0,0 +1,60 @@
+package linkpreview
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractURLs(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"no links here", nil},
+		{"see https://example.com.", []string{"https://example.com"}},
+		{"see https://example.com/a?b=1!?", []string{"https://example.com/a?b=1"}},
+		{"HTTP://Example.com/x, then", []string{"HTTP://Example.com/x"}},
+		{"(see https://example.com/page)", []string{"https://example.com/page"}},
+		{"https://en.wikipedia.org/wiki/Go_(game)", []string{"https://en.wikipedia.org/wiki/Go_(game)"}},
+		{"[https://example.com/x]", []string{"https://example.com/x"}},
+		{`"https://example.com/q"`, []string{"https://example.com/q"}},
+		{"<https://example.com/a>", []string{"https://example.com/a"}},
+		{"https://example.com https://example.com", []string{"https://example.com"}},
+		{"ftp://example.com and mailto:a@example.com", nil},
+		{"https://user:pw@example.com/", nil},
+		{"https://", nil},
+		{
+			"https://a.example https://b.example https://c.example https://d.example",
+			[]string{"https://a.example", "https://b.example", "https://c.example"},
+		},
+		{"https://example.com/" + strings.Repeat("a", maxURLLength) + " https://ok.example", []string{"https://ok.example"}},
+	}
+	for _, tt := range tests {
+		if got := ExtractURLs(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExtractURLs(%.60q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTrimLink(t *testing.T) {
+	tests := []struct {
+		link, want string
+	}{
+		{"https://example.com", "https://example.com"},
+		{"https://example.com/...", "https://example.com/"},
+		{"https://example.com/a*", "https://example.com/a"},
+		{"https://example.com/a'", "https://example.com/a"},
+		{"https://example.com/a).", "https://example.com/a"},
+		{"https://example.com/(a)", "https://example.com/(a)"},
+		{"https://example.com/(a))", "https://example.com/(a)"},
+		{"https://example.com/[a]]", "https://example.com/[a]"},
+		{"https://example.com/a]).", "https://example.com/a"},
+		{".,;", ""},
+	}
+	for _, tt := range tests {
+		if got := trimLink(tt.link); got != tt.want {
+			t.Errorf("trimLink(%q) = %q, want %q", tt.link, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package linkpreview
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	maxTitleRunes       = 300
+	maxDescriptionRunes = 1000
+	maxSiteNameRunes    = 100
+	maxImageURLLength   = 2048
+)
+
+// metadata is what a page says about itself. The OpenGraph tags win over the
+// Twitter card ones, which win over the plain <title> and description.
+type metadata struct {
+	title       string
+	description string
+	imageURL    string
+	siteName    string
+}
+
+func (m metadata) empty() bool {
+	return m.title == "" && m.description == "" && m.imageURL == ""
+}
+
+// parse reads the metadata out of the <head> of page, stopping at <body> since
+// nothing after it is used.
+func parse(page *Page) metadata {
+	var (
+		ogTitle, ogDescription, ogImage, ogSiteName string
+		twTitle, twDescription, twImage             string
+		title, description                          string
+	)
+
+	z := html.NewTokenizer(bytes.NewReader(page.Body))
+	inTitle := false
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			switch t.DataAtom {
+			case atom.Body:
+				break loop
+			case atom.Title:
+				inTitle = title == ""
+			case atom.Meta:
+				var key, content string
+				for _, attr := range t.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "property", "name":
+						if key == "" {
+							key = strings.ToLower(strings.TrimSpace(attr.Val))
+						}
+					case "content":
+						content = strings.TrimSpace(attr.Val)
+					}
+				}
+				if content == "" {
+					continue
+				}
+				switch key {
+				case "og:title":
+					ogTitle = firstNonEmpty(ogTitle, content)
+				case "og:description":
+					ogDescription = firstNonEmpty(ogDescription, content)
+				case "og:image", "og:image:url", "og:image:secure_url":
+					ogImage = firstNonEmpty(ogImage, content)
+				case "og:site_name":
+					ogSiteName = firstNonEmpty(ogSiteName, content)
+				case "twitter:title":
+					twTitle = firstNonEmpty(twTitle, content)
+				case "twitter:description":
+					twDescription = firstNonEmpty(twDescription, content)
+				case "twitter:image", "twitter:image:src":
+					twImage = firstNonEmpty(twImage, content)
+				case "description":
+					description = firstNonEmpty(description, content)
+				}
+			}
+		case html.TextToken:
+			if inTitle {
+				title += string(z.Text())
+			}
+		case html.EndTagToken:
+			t := z.Token()
+			if t.DataAtom == atom.Title {
+				inTitle = false
+			} else if t.DataAtom == atom.Head {
+				break loop
+			}
+		}
+	}
+
+	return metadata{
+		title:       truncate(collapseSpace(firstNonEmpty(ogTitle, twTitle, title)), maxTitleRunes),
+		description: truncate(collapseSpace(firstNonEmpty(ogDescription, twDescription, description)), maxDescriptionRunes),
+		imageURL:    resolveImageURL(page.URL, firstNonEmpty(ogImage, twImage)),
+		siteName:    truncate(collapseSpace(ogSiteName), maxSiteNameRunes),
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncate(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
+
+// resolveImageURL makes a relative image URL absolute against the page it came
+// from. Only http and https images are kept, since clients load them directly.
+func resolveImageURL(base *url.URL, raw string) string {
+	if raw == "" {
+		return ""
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	u := base.ResolveReference(ref)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	if s := u.String(); len(s) <= maxImageURLLength {
+		return s
+	}
+	return ""
+}
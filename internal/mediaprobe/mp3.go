@@ -0,0 +1,138 @@
+package mediaprobe
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// mp3SyncSearchBytes is how far past the ID3 tag the first frame is looked for.
+const mp3SyncSearchBytes = 64 << 10
+
+// Bitrates in kbit/s, indexed by the header's bitrate index.
+var (
+	mp1Layer1Bitrates = [15]int{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448}
+	mp1Layer2Bitrates = [15]int{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384}
+	mp1Layer3Bitrates = [15]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320}
+	mp2Layer1Bitrates = [15]int{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256}
+	mp2Layer2Bitrates = [15]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160}
+)
+
+// mp3SampleRates are indexed by the header's version bits, MPEG 2.5 being 0, MPEG
+// 2 being 2 and MPEG 1 being 3, and then by its sample rate index.
+var mp3SampleRates = [4][3]int{0: {11025, 12000, 8000}, 2: {22050, 24000, 16000}, 3: {44100, 48000, 32000}}
+
+type mp3Frame struct {
+	mpeg1           bool
+	layer           int
+	bitrate         int
+	sampleRate      int
+	samplesPerFrame int
+	mono            bool
+}
+
+// parseMP3Frame reads a frame header, reporting false if h is not one.
+func parseMP3Frame(h []byte) (mp3Frame, bool) {
+	if len(h) < 4 || h[0] != 0xFF || h[1]&0xE0 != 0xE0 {
+		return mp3Frame{}, false
+	}
+	version := (h[1] >> 3) & 3
+	layerBits := (h[1] >> 1) & 3
+	bitrateIndex := h[2] >> 4
+	sampleRateIndex := (h[2] >> 2) & 3
+	if version == 1 || layerBits == 0 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return mp3Frame{}, false
+	}
+
+	frame := mp3Frame{mpeg1: version == 3, layer: 4 - int(layerBits), mono: h[3]>>6 == 3}
+	frame.sampleRate = mp3SampleRates[version][sampleRateIndex]
+
+	switch {
+	case frame.mpeg1 && frame.layer == 1:
+		frame.bitrate = mp1Layer1Bitrates[bitrateIndex]
+	case frame.mpeg1 && frame.layer == 2:
+		frame.bitrate = mp1Layer2Bitrates[bitrateIndex]
+	case frame.mpeg1:
+		frame.bitrate = mp1Layer3Bitrates[bitrateIndex]
+	case frame.layer == 1:
+		frame.bitrate = mp2Layer1Bitrates[bitrateIndex]
+	default:
+		frame.bitrate = mp2Layer2Bitrates[bitrateIndex]
+	}
+
+	switch {
+	case frame.layer == 1:
+		frame.samplesPerFrame = 384
+	case frame.layer == 3 && !frame.mpeg1:
+		frame.samplesPerFrame = 576
+	default:
+		frame.samplesPerFrame = 1152
+	}
+	return frame, true
+}
+
+// probeMP3 reads the first frame after any ID3 tag. VBR files say how many frames
+// they have in a Xing, Info or VBRI header in that frame; for the others the
+// duration follows from the size and the constant bitrate.
+func probeMP3(r io.ReaderAt, size int64) (Info, error) {
+	start := int64(0)
+	id3, err := readAt(r, 0, 10)
+	if err != nil {
+		return Info{}, err
+	}
+	if len(id3) == 10 && string(id3[:3]) == "ID3" {
+		// The tag size is stored seven bits to a byte, and excludes the header and
+		// any footer.
+		tagSize := int64(id3[6])<<21 | int64(id3[7])<<14 | int64(id3[8])<<7 | int64(id3[9])
+		start = 10 + tagSize
+		if id3[5]&0x10 != 0 {
+			start += 10
+		}
+	}
+
+	data, err := readAt(r, start, min(size-start, mp3SyncSearchBytes))
+	if err != nil {
+		return Info{}, err
+	}
+	for i := 0; i+4 <= len(data); i++ {
+		frame, ok := parseMP3Frame(data[i:])
+		if !ok {
+			continue
+		}
+		codec := [4]string{1: "mp1", 2: "mp2", 3: "mp3"}[frame.layer]
+		info := Info{Container: "mp3", HasAudio: true, Codecs: []string{codec}}
+
+		if frames, ok := vbrFrameCount(data[i:], frame); ok {
+			info.Duration = seconds(uint64(frames)*uint64(frame.samplesPerFrame), uint64(frame.sampleRate))
+		} else {
+			audioBytes := size - start - int64(i)
+			info.Duration = seconds(uint64(audioBytes)*8, uint64(frame.bitrate)*1000)
+		}
+		return info, nil
+	}
+	return Info{}, ErrMalformed
+}
+
+// vbrFrameCount reads the frame count from a Xing, Info or VBRI header.
+func vbrFrameCount(frameData []byte, frame mp3Frame) (uint32, bool) {
+	sideInfo := 32
+	switch {
+	case frame.mpeg1 && frame.mono:
+		sideInfo = 17
+	case !frame.mpeg1 && frame.mono:
+		sideInfo = 9
+	case !frame.mpeg1:
+		sideInfo = 17
+	}
+
+	if xing := 4 + sideInfo; len(frameData) >= xing+12 {
+		tag := string(frameData[xing : xing+4])
+		flags := binary.BigEndian.Uint32(frameData[xing+4:])
+		if (tag == "Xing" || tag == "Info") && flags&1 != 0 {
+			return binary.BigEndian.Uint32(frameData[xing+8:]), true
+		}
+	}
+	if vbri := 4 + 32; len(frameData) >= vbri+18 && string(frameData[vbri:vbri+4]) == "VBRI" {
+		return binary.BigEndian.Uint32(frameData[vbri+14:]), true
+	}
+	return 0, false
+}
@@ -0,0 +1,172 @@
+package mediaprobe
+
+import (
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+// maxMoovBytes caps how much of an MP4's metadata is read. The moov box only
+// indexes the samples, so even hours of media stay well below it.
+const maxMoovBytes = 64 << 20
+
+// probeMP4 reads the moov box of an ISO base media file (MP4, M4A, MOV). It can be
+// at either end of the file, so the top-level boxes are walked until it is found.
+func probeMP4(r io.ReaderAt, size int64) (Info, error) {
+	for offset := int64(0); offset+8 <= size; {
+		header, err := readAt(r, offset, 16)
+		if err != nil {
+			return Info{}, err
+		}
+		if len(header) < 8 {
+			return Info{}, ErrMalformed
+		}
+		boxSize, headerSize := int64(binary.BigEndian.Uint32(header)), int64(8)
+		switch boxSize {
+		case 0:
+			boxSize = size - offset
+		case 1:
+			if len(header) < 16 {
+				return Info{}, ErrMalformed
+			}
+			boxSize, headerSize = int64(binary.BigEndian.Uint64(header[8:])), 16
+		}
+		if boxSize < headerSize || boxSize > size-offset {
+			return Info{}, ErrMalformed
+		}
+
+		if string(header[4:8]) == "moov" {
+			if boxSize-headerSize > maxMoovBytes {
+				return Info{}, ErrMalformed
+			}
+			moov, err := readAt(r, offset+headerSize, boxSize-headerSize)
+			if err != nil {
+				return Info{}, err
+			}
+			return parseMoov(moov)
+		}
+		offset += boxSize
+	}
+	return Info{}, ErrMalformed
+}
+
+// boxes calls fn with the type and payload of each box in data.
+func boxes(data []byte, fn func(boxType string, payload []byte) error) error {
+	for len(data) >= 8 {
+		size, headerSize := uint64(binary.BigEndian.Uint32(data)), uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return ErrMalformed
+			}
+			size, headerSize = binary.BigEndian.Uint64(data[8:]), 16
+		}
+		if size < headerSize || size > uint64(len(data)) {
+			return ErrMalformed
+		}
+		if err := fn(string(data[4:8]), data[headerSize:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	return nil
+}
+
+func parseMoov(moov []byte) (Info, error) {
+	info := Info{Container: "mp4"}
+	err := boxes(moov, func(boxType string, payload []byte) error {
+		switch boxType {
+		case "mvhd":
+			return parseMvhd(&info, payload)
+		case "trak":
+			return parseTrak(&info, payload)
+		}
+		return nil
+	})
+	if err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+func parseMvhd(info *Info, mvhd []byte) error {
+	if len(mvhd) < 1 {
+		return ErrMalformed
+	}
+	if mvhd[0] == 1 {
+		if len(mvhd) < 32 {
+			return ErrMalformed
+		}
+		info.Duration = seconds(binary.BigEndian.Uint64(mvhd[24:]), uint64(binary.BigEndian.Uint32(mvhd[20:])))
+		return nil
+	}
+	if len(mvhd) < 20 {
+		return ErrMalformed
+	}
+	info.Duration = seconds(uint64(binary.BigEndian.Uint32(mvhd[16:])), uint64(binary.BigEndian.Uint32(mvhd[12:])))
+	return nil
+}
+
+// parseTrak records the kind and codec of a track. The kind is in the mdia's
+// handler, the codec is the format of the first sample description.
+func parseTrak(info *Info, trak []byte) error {
+	var handler, codec string
+	err := boxes(trak, func(boxType string, mdia []byte) error {
+		if boxType != "mdia" {
+			return nil
+		}
+		return boxes(mdia, func(boxType string, payload []byte) error {
+			switch boxType {
+			case "hdlr":
+				if len(payload) < 12 {
+					return ErrMalformed
+				}
+				handler = string(payload[8:12])
+			case "minf":
+				var err error
+				codec, err = sampleFormat(payload)
+				return err
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	switch handler {
+	case "vide":
+		info.HasVideo = true
+	case "soun":
+		info.HasAudio = true
+	default:
+		return nil
+	}
+	if codec != "" {
+		info.addCodec(codec)
+	}
+	return nil
+}
+
+func sampleFormat(minf []byte) (string, error) {
+	var format string
+	err := boxes(minf, func(boxType string, stbl []byte) error {
+		if boxType != "stbl" {
+			return nil
+		}
+		return boxes(stbl, func(boxType string, stsd []byte) error {
+			if boxType != "stsd" {
+				return nil
+			}
+			// Version and flags, entry count, then the first entry's size and format.
+			if len(stsd) < 16 {
+				return ErrMalformed
+			}
+			format = strings.TrimSpace(string(stsd[12:16]))
+			return nil
+		})
+	})
+	return format, err
+}
@@ -0,0 +1,152 @@
+package mediaprobe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+const (
+	// oggHeadBytes is how much of the start of an Ogg file is read for the stream
+	// headers, which sit in the first pages.
+	oggHeadBytes = 64 << 10
+	// oggTailBytes is how much of the end is read for the last page, whose
+	// granule position says how long the stream plays. Pages are at most 64 KiB.
+	oggTailBytes = 65307 * 2
+
+	oggPageHeaderSize = 27
+	// opusGranuleRate is the rate Opus granule positions count at, whatever the
+	// sample rate of the input was.
+	opusGranuleRate = 48000
+)
+
+type oggPage struct {
+	granule int64
+	serial  uint32
+	// lacing is the page's segment table, body its data.
+	lacing []byte
+	body   []byte
+}
+
+// readOggPage reads the page at the start of data and returns it with its length.
+func readOggPage(data []byte) (oggPage, int, bool) {
+	if len(data) < oggPageHeaderSize || !bytes.HasPrefix(data, []byte("OggS")) {
+		return oggPage{}, 0, false
+	}
+	segments := int(data[26])
+	if len(data) < oggPageHeaderSize+segments {
+		return oggPage{}, 0, false
+	}
+	lacing := data[oggPageHeaderSize : oggPageHeaderSize+segments]
+	bodySize := 0
+	for _, l := range lacing {
+		bodySize += int(l)
+	}
+	end := oggPageHeaderSize + segments + bodySize
+	if len(data) < end {
+		return oggPage{}, 0, false
+	}
+	return oggPage{
+		granule: int64(binary.LittleEndian.Uint64(data[6:])),
+		serial:  binary.LittleEndian.Uint32(data[14:]),
+		lacing:  lacing,
+		body:    data[oggPageHeaderSize+segments : end],
+	}, end, true
+}
+
+// packets splits a page's body into packets. The last one is cut short when it
+// continues on the next page.
+func (p oggPage) packets() [][]byte {
+	var packets [][]byte
+	start, offset := 0, 0
+	for _, l := range p.lacing {
+		offset += int(l)
+		if l < 255 {
+			packets = append(packets, p.body[start:offset])
+			start = offset
+		}
+	}
+	if start < offset {
+		packets = append(packets, p.body[start:offset])
+	}
+	return packets
+}
+
+// oggStream describes the first logical stream of an Ogg file.
+type oggStream struct {
+	serial  uint32
+	codec   string
+	video   bool
+	rate    uint64
+	preSkip uint64
+}
+
+// readOggStream identifies the codec from the first packet of the first stream.
+func readOggStream(head []byte) (oggStream, error) {
+	page, _, ok := readOggPage(head)
+	if !ok {
+		return oggStream{}, ErrMalformed
+	}
+	packets := page.packets()
+	if len(packets) == 0 {
+		return oggStream{}, ErrMalformed
+	}
+	first := packets[0]
+	stream := oggStream{serial: page.serial}
+	switch {
+	case bytes.HasPrefix(first, []byte("OpusHead")) && len(first) >= 19:
+		stream.codec = "opus"
+		stream.rate = opusGranuleRate
+		stream.preSkip = uint64(binary.LittleEndian.Uint16(first[10:]))
+	case bytes.HasPrefix(first, []byte("\x01vorbis")) && len(first) >= 16:
+		stream.codec = "vorbis"
+		stream.rate = uint64(binary.LittleEndian.Uint32(first[12:]))
+	case bytes.HasPrefix(first, []byte("\x7fFLAC")) && len(first) >= 31:
+		stream.codec = "flac"
+		// The sample rate is the first 20 bits after the STREAMINFO block header.
+		stream.rate = uint64(binary.BigEndian.Uint32(first[27:])) >> 12
+	case bytes.HasPrefix(first, []byte("\x80theora")):
+		stream.codec = "theora"
+		stream.video = true
+	default:
+		return oggStream{}, ErrUnknownFormat
+	}
+	return stream, nil
+}
+
+// probeOgg reads the codec from the first page and the duration from the
+// granule position of the last page of the same stream.
+func probeOgg(r io.ReaderAt, size int64) (Info, error) {
+	head, err := readAt(r, 0, min(size, oggHeadBytes))
+	if err != nil {
+		return Info{}, err
+	}
+	stream, err := readOggStream(head)
+	if err != nil {
+		return Info{}, err
+	}
+
+	info := Info{Container: "ogg", HasVideo: stream.video, HasAudio: !stream.video, Codecs: []string{stream.codec}}
+	if stream.rate == 0 {
+		return info, nil
+	}
+
+	tailStart := max(size-oggTailBytes, 0)
+	tail, err := readAt(r, tailStart, size-tailStart)
+	if err != nil {
+		return Info{}, err
+	}
+	for i := bytes.LastIndex(tail, []byte("OggS")); i >= 0; i = bytes.LastIndex(tail[:i], []byte("OggS")) {
+		page, _, ok := readOggPage(tail[i:])
+		// A granule position of -1 marks a page on which no packet ends.
+		if !ok || page.serial != stream.serial || page.granule < 0 {
+			continue
+		}
+		granule := uint64(page.granule)
+		if granule > stream.preSkip {
+			info.Duration = seconds(granule-stream.preSkip, stream.rate)
+		}
+		break
+	}
+	return info, nil
+}
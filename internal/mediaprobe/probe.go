@@ -0,0 +1,90 @@
+// Package mediaprobe reads what an audio or video file contains, and how long it
+// plays, from its container headers alone. Nothing is decoded and no external
+// tools such as ffprobe are needed, so it is cheap enough to run on every upload.
+// MP4 and QuickTime, WebM and Matroska, Ogg, WAV and MP3 are understood. Waveform
+// outlines the loudness of WAV and Ogg Opus recordings for drawing voice notes.
+package mediaprobe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownFormat = errors.New("not a recognised audio or video container")
+	ErrMalformed     = errors.New("media container is malformed")
+)
+
+// Info is what a probe found out about a file.
+type Info struct {
+	// Container is "mp4", "webm", "ogg", "wav" or "mp3". QuickTime files and M4A
+	// audio are "mp4", Matroska files are "webm".
+	Container string
+	HasVideo  bool
+	HasAudio  bool
+	// Codecs names the codec of each track, e.g. "avc1" and "mp4a", or "opus".
+	Codecs []string
+	// Duration is zero when the container does not record it, as is the case for
+	// WebM files written by browsers while recording.
+	Duration time.Duration
+}
+
+// Codec is Codecs as one comma-separated string.
+func (i Info) Codec() string {
+	return strings.Join(i.Codecs, ",")
+}
+
+func (i *Info) addCodec(codec string) {
+	for _, c := range i.Codecs {
+		if c == codec {
+			return
+		}
+	}
+	i.Codecs = append(i.Codecs, codec)
+}
+
+// Probe reads the container headers of the size bytes in r.
+func Probe(r io.ReaderAt, size int64) (Info, error) {
+	head, err := readAt(r, 0, min(size, 16))
+	if err != nil {
+		return Info{}, err
+	}
+	switch {
+	case len(head) >= 8 && string(head[4:8]) == "ftyp":
+		return probeMP4(r, size)
+	case bytes.HasPrefix(head, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return probeWebM(r, size)
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return probeOgg(r, size)
+	case len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == "WAVE":
+		return probeWAV(r, size)
+	case bytes.HasPrefix(head, []byte("ID3")) || (len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0):
+		return probeMP3(r, size)
+	}
+	return Info{}, ErrUnknownFormat
+}
+
+// readAt reads n bytes at off, or fewer when the file ends first.
+func readAt(r io.ReaderAt, off, n int64) ([]byte, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	buf := make([]byte, n)
+	read, err := r.ReadAt(buf, off)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read media: %w", err)
+	}
+	return buf[:read], nil
+}
+
+// seconds converts a count of ticks at rate ticks per second to a duration.
+func seconds(ticks uint64, rate uint64) time.Duration {
+	if rate == 0 {
+		return 0
+	}
+	return time.Duration(float64(ticks) / float64(rate) * float64(time.Second))
+}
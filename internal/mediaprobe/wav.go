@@ -0,0 +1,122 @@
+package mediaprobe
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// wavFormats names the WAVE format tags that are common enough to be worth a name.
+var wavFormats = map[uint16]string{
+	0x0001: "pcm",
+	0x0003: "pcm_float",
+	0x0006: "alaw",
+	0x0007: "mulaw",
+	0x0055: "mp3",
+}
+
+const wavFormatExtensible = 0xFFFE
+
+// wavFormat is the layout of the samples of a WAV file.
+type wavFormat struct {
+	tag           uint16
+	channels      int
+	sampleRate    int
+	byteRate      int
+	blockAlign    int
+	bitsPerSample int
+}
+
+// wavLayout is where a WAV file keeps its samples.
+type wavLayout struct {
+	format     wavFormat
+	dataOffset int64
+	dataSize   int64
+}
+
+// readWAVLayout walks the chunks of a WAV file to its fmt and data chunks.
+func readWAVLayout(r io.ReaderAt, size int64) (wavLayout, error) {
+	var layout wavLayout
+	sawFormat := false
+	for offset := int64(12); offset+8 <= size; {
+		header, err := readAt(r, offset, 8)
+		if err != nil {
+			return wavLayout{}, err
+		}
+		if len(header) < 8 {
+			return wavLayout{}, ErrMalformed
+		}
+		chunkSize := int64(binary.LittleEndian.Uint32(header[4:]))
+		switch string(header[:4]) {
+		case "fmt ":
+			fmtChunk, err := readAt(r, offset+8, min(chunkSize, 40))
+			if err != nil {
+				return wavLayout{}, err
+			}
+			if len(fmtChunk) < 16 {
+				return wavLayout{}, ErrMalformed
+			}
+			layout.format = wavFormat{
+				tag:           binary.LittleEndian.Uint16(fmtChunk),
+				channels:      int(binary.LittleEndian.Uint16(fmtChunk[2:])),
+				sampleRate:    int(binary.LittleEndian.Uint32(fmtChunk[4:])),
+				byteRate:      int(binary.LittleEndian.Uint32(fmtChunk[8:])),
+				blockAlign:    int(binary.LittleEndian.Uint16(fmtChunk[12:])),
+				bitsPerSample: int(binary.LittleEndian.Uint16(fmtChunk[14:])),
+			}
+			// The extensible format keeps the real tag in the first two bytes of
+			// its subformat GUID.
+			if layout.format.tag == wavFormatExtensible && len(fmtChunk) >= 26 {
+				layout.format.tag = binary.LittleEndian.Uint16(fmtChunk[24:])
+			}
+			sawFormat = true
+		case "data":
+			// Streaming writers leave the size unset; the data then runs to the end.
+			if chunkSize == 0 || chunkSize == 0xFFFFFFFF || offset+8+chunkSize > size {
+				chunkSize = size - offset - 8
+			}
+			if !sawFormat {
+				return wavLayout{}, ErrMalformed
+			}
+			layout.dataOffset = offset + 8
+			layout.dataSize = chunkSize
+			return layout, nil
+		}
+		offset += 8 + chunkSize + chunkSize%2
+	}
+	return wavLayout{}, ErrMalformed
+}
+
+// frameFormats are the WAVE format tags whose samples are stored in fixed-size
+// frames, so their duration follows from the size of the data.
+var frameFormats = map[uint16]bool{
+	0x0001: true,
+	0x0003: true,
+	0x0006: true,
+	0x0007: true,
+}
+
+// probeWAV works the duration out from the size of the data and the frame layout.
+// The byte rate in the header is only checked against it, as it is a redundant
+// field that a file can set to anything.
+func probeWAV(r io.ReaderAt, size int64) (Info, error) {
+	layout, err := readWAVLayout(r, size)
+	if err != nil {
+		return Info{}, err
+	}
+	codec, ok := wavFormats[layout.format.tag]
+	if !ok {
+		codec = fmt.Sprintf("wav_0x%04x", layout.format.tag)
+	}
+	info := Info{Container: "wav", HasAudio: true, Codecs: []string{codec}}
+	format := layout.format
+	if frameFormats[format.tag] {
+		frameRate := format.sampleRate * format.blockAlign
+		if format.channels < 1 || format.blockAlign != format.channels*((format.bitsPerSample+7)/8) ||
+			frameRate <= 0 || format.byteRate != frameRate {
+			return Info{}, ErrMalformed
+		}
+		info.Duration = seconds(uint64(layout.dataSize), uint64(frameRate))
+	}
+	return info, nil
+}
@@ -0,0 +1,232 @@
+package mediaprobe
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// maxWaveformBytes caps how much of an Ogg file is read for a waveform. Unlike WAV
+// data, which is streamed, Ogg pages are read into memory.
+const maxWaveformBytes = 16 << 20
+
+// Waveform returns the loudness of each of buckets equal stretches of a recording,
+// scaled so that the loudest is 255. WAV files with PCM samples are measured
+// exactly. Ogg Opus is not decoded; the bitrate of each stretch stands in for its
+// loudness, which is close enough to draw speech by. Other formats give
+// ErrUnknownFormat.
+func Waveform(r io.ReaderAt, size int64, buckets int) ([]byte, error) {
+	if buckets < 1 {
+		return nil, nil
+	}
+	head, err := readAt(r, 0, min(size, 12))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == "WAVE":
+		return wavWaveform(r, size, buckets)
+	case len(head) >= 4 && string(head[:4]) == "OggS":
+		return opusWaveform(r, size, buckets)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// wavWaveform measures the RMS of the samples of every channel in each bucket.
+func wavWaveform(r io.ReaderAt, size int64, buckets int) ([]byte, error) {
+	layout, err := readWAVLayout(r, size)
+	if err != nil {
+		return nil, err
+	}
+	format := layout.format
+	sampleBytes := format.bitsPerSample / 8
+	switch {
+	case format.tag == 0x0001 && sampleBytes >= 1 && sampleBytes <= 4:
+	case format.tag == 0x0003 && sampleBytes == 4:
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if format.channels < 1 || format.blockAlign != format.channels*sampleBytes {
+		return nil, ErrMalformed
+	}
+
+	frames := layout.dataSize / int64(format.blockAlign)
+	sums := make([]float64, buckets)
+	counts := make([]int64, buckets)
+	data := bufio.NewReaderSize(io.NewSectionReader(r, layout.dataOffset, frames*int64(format.blockAlign)), 64<<10)
+	frame := make([]byte, format.blockAlign)
+	for i := int64(0); i < frames; i++ {
+		if _, err := io.ReadFull(data, frame); err != nil {
+			return nil, fmt.Errorf("failed to read media: %w", err)
+		}
+		bucket := int(i * int64(buckets) / frames)
+		for c := 0; c < format.channels; c++ {
+			v := pcmSample(frame[c*sampleBytes:(c+1)*sampleBytes], format.tag)
+			sums[bucket] += v * v
+		}
+		counts[bucket] += int64(format.channels)
+	}
+
+	levels := make([]float64, buckets)
+	for i := range levels {
+		if counts[i] > 0 {
+			levels[i] = math.Sqrt(sums[i] / float64(counts[i]))
+		}
+	}
+	return scaleLevels(levels), nil
+}
+
+// pcmSample reads one little-endian sample as a value between -1 and 1. Eight-bit
+// samples are unsigned, wider ones signed.
+func pcmSample(b []byte, tag uint16) float64 {
+	if tag == 0x0003 {
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	}
+	if len(b) == 1 {
+		return (float64(b[0]) - 128) / 128
+	}
+	var v int32
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | int32(b[i])
+	}
+	// Sign-extend from the sample's width.
+	shift := 32 - 8*len(b)
+	v = v << shift >> shift
+	return float64(v) / float64(int64(1)<<(8*len(b)-1))
+}
+
+// opusWaveform spreads the bytes of each Opus packet over the buckets its samples
+// fall in, and takes each bucket's bytes per sample as its level.
+func opusWaveform(r io.ReaderAt, size int64, buckets int) ([]byte, error) {
+	data, err := readAt(r, 0, min(size, maxWaveformBytes))
+	if err != nil {
+		return nil, err
+	}
+	stream, err := readOggStream(data)
+	if err != nil {
+		return nil, err
+	}
+	if stream.codec != "opus" {
+		return nil, ErrUnknownFormat
+	}
+
+	type packet struct{ bytes, samples int }
+	var packets []packet
+	total := 0
+	// The first two packets are the OpusHead and OpusTags headers.
+	headers := 2
+	oggPackets(data, stream.serial, func(p []byte) {
+		if headers > 0 {
+			headers--
+			return
+		}
+		if samples := opusPacketSamples(p); samples > 0 {
+			packets = append(packets, packet{len(p), samples})
+			total += samples
+		}
+	})
+
+	bytes := make([]float64, buckets)
+	samples := make([]float64, buckets)
+	position := 0
+	for _, p := range packets {
+		bucket := int(int64(position) * int64(buckets) / int64(max(total, 1)))
+		bytes[bucket] += float64(p.bytes)
+		samples[bucket] += float64(p.samples)
+		position += p.samples
+	}
+
+	levels := make([]float64, buckets)
+	for i := range levels {
+		if samples[i] > 0 {
+			levels[i] = bytes[i] / samples[i]
+		}
+	}
+	return scaleLevels(levels), nil
+}
+
+// oggPackets calls fn with each whole packet of the stream with the given serial,
+// joining packets that continue from one page onto the next.
+func oggPackets(data []byte, serial uint32, fn func(packet []byte)) {
+	var partial []byte
+	for offset := 0; offset < len(data); {
+		page, n, ok := readOggPage(data[offset:])
+		if !ok {
+			return
+		}
+		offset += n
+		if page.serial != serial {
+			continue
+		}
+		start, end := 0, 0
+		for _, l := range page.lacing {
+			end += int(l)
+			if l < 255 {
+				p := page.body[start:end]
+				if partial != nil {
+					p = append(partial, p...)
+					partial = nil
+				}
+				fn(p)
+				start = end
+			}
+		}
+		if start < end {
+			partial = append(partial, page.body[start:end]...)
+		}
+	}
+}
+
+// opusFrameSamples are the frame sizes, at 48 kHz, of the SILK, hybrid and CELT
+// configurations of an Opus TOC byte.
+var (
+	silkFrameSamples   = [4]int{480, 960, 1920, 2880}
+	hybridFrameSamples = [2]int{480, 960}
+	celtFrameSamples   = [4]int{120, 240, 480, 960}
+)
+
+// opusPacketSamples reads from a packet's TOC byte how many 48 kHz samples it
+// holds (RFC 6716, section 3.1).
+func opusPacketSamples(p []byte) int {
+	if len(p) == 0 {
+		return 0
+	}
+	config := int(p[0] >> 3)
+	var frame int
+	switch {
+	case config < 12:
+		frame = silkFrameSamples[config%4]
+	case config < 16:
+		frame = hybridFrameSamples[config%2]
+	default:
+		frame = celtFrameSamples[config%4]
+	}
+	switch p[0] & 3 {
+	case 0:
+		return frame
+	case 1, 2:
+		return 2 * frame
+	}
+	if len(p) < 2 {
+		return 0
+	}
+	return int(p[1]&0x3F) * frame
+}
+
+// scaleLevels scales levels so that the highest becomes 255.
+func scaleLevels(levels []float64) []byte {
+	highest := 0.0
+	for _, l := range levels {
+		highest = max(highest, l)
+	}
+	scaled := make([]byte, len(levels))
+	if highest == 0 {
+		return scaled
+	}
+	for i, l := range levels {
+		scaled[i] = byte(math.Round(l / highest * 255))
+	}
+	return scaled
+}
@@ -0,0 +1,210 @@
+package mediaprobe
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strings"
+)
+
+// maxWebMHeaderBytes is how much of a WebM file is read for its headers. The
+// segment info and track list come before the first cluster of media.
+const maxWebMHeaderBytes = 1 << 20
+
+// EBML element IDs, with their length marker bits kept as is customary.
+const (
+	ebmlHeaderID    = 0x1A45DFA3
+	ebmlDocTypeID   = 0x4282
+	segmentID       = 0x18538067
+	infoID          = 0x1549A966
+	timecodeScaleID = 0x2AD7B1
+	durationID      = 0x4489
+	tracksID        = 0x1654AE6B
+	trackEntryID    = 0xAE
+	trackTypeID     = 0x83
+	codecIDID       = 0x86
+	clusterID       = 0x1F43B675
+)
+
+// webmCodecs names Matroska codec IDs the way MP4 names them where there is an
+// equivalent.
+var webmCodecs = map[string]string{
+	"V_VP8":            "vp8",
+	"V_VP9":            "vp9",
+	"V_AV1":            "av01",
+	"V_MPEG4/ISO/AVC":  "avc1",
+	"V_MPEGH/ISO/HEVC": "hvc1",
+	"A_OPUS":           "opus",
+	"A_VORBIS":         "vorbis",
+	"A_AAC":            "mp4a",
+	"A_MPEG/L3":        "mp3",
+	"A_FLAC":           "flac",
+	"A_PCM/INT/LIT":    "pcm",
+}
+
+// probeWebM reads the segment info and tracks of a WebM or Matroska file.
+func probeWebM(r io.ReaderAt, size int64) (Info, error) {
+	data, err := readAt(r, 0, min(size, maxWebMHeaderBytes))
+	if err != nil {
+		return Info{}, err
+	}
+
+	info := Info{Container: "webm"}
+	timecodeScale := uint64(1_000_000)
+	var duration float64
+	sawHeader := false
+
+	err = elements(data, func(id uint64, payload []byte) (bool, error) {
+		switch id {
+		case ebmlHeaderID:
+			sawHeader = true
+			return false, elements(payload, func(id uint64, payload []byte) (bool, error) {
+				if id == ebmlDocTypeID && string(payload) != "webm" && string(payload) != "matroska" {
+					return false, ErrUnknownFormat
+				}
+				return false, nil
+			})
+		case segmentID:
+			// The segment holds everything else, so its children are walked in turn.
+			return true, nil
+		case infoID:
+			return false, elements(payload, func(id uint64, payload []byte) (bool, error) {
+				switch id {
+				case timecodeScaleID:
+					timecodeScale = readUint(payload)
+				case durationID:
+					duration = readFloat(payload)
+				}
+				return false, nil
+			})
+		case tracksID:
+			return false, elements(payload, func(id uint64, payload []byte) (bool, error) {
+				if id == trackEntryID {
+					parseTrackEntry(&info, payload)
+				}
+				return false, nil
+			})
+		case clusterID:
+			return false, errStop
+		}
+		return false, nil
+	})
+	if err != nil && err != errStop {
+		return Info{}, err
+	}
+	if !sawHeader {
+		return Info{}, ErrMalformed
+	}
+
+	if duration > 0 && !math.IsInf(duration, 0) && !math.IsNaN(duration) {
+		info.Duration = seconds(uint64(duration*float64(timecodeScale)), 1_000_000_000)
+	}
+	return info, nil
+}
+
+func parseTrackEntry(info *Info, entry []byte) {
+	var trackType uint64
+	var codecID string
+	elements(entry, func(id uint64, payload []byte) (bool, error) {
+		switch id {
+		case trackTypeID:
+			trackType = readUint(payload)
+		case codecIDID:
+			codecID = strings.TrimRight(string(payload), "\x00")
+		}
+		return false, nil
+	})
+
+	switch trackType {
+	case 1:
+		info.HasVideo = true
+	case 2:
+		info.HasAudio = true
+	default:
+		return
+	}
+	if codec, ok := webmCodecs[codecID]; ok {
+		info.addCodec(codec)
+	} else if codecID != "" {
+		info.addCodec(strings.ToLower(codecID))
+	}
+}
+
+// errStop ends a walk over elements early without it being an error.
+var errStop = errors.New("stop")
+
+// elements calls fn with the ID and payload of each element in data. When fn
+// returns true the element's children are walked as if they followed it, which
+// is how an element of unknown size has to be read. An element running past the
+// end of data is passed cut short.
+func elements(data []byte, fn func(id uint64, payload []byte) (bool, error)) error {
+	for len(data) > 0 {
+		id, idLen, ok := readVint(data, true)
+		if !ok {
+			return ErrMalformed
+		}
+		size, sizeLen, ok := readVint(data[idLen:], false)
+		if !ok {
+			return ErrMalformed
+		}
+		start := idLen + sizeLen
+		unknown := size == (uint64(1)<<(7*sizeLen))-1
+		end := len(data)
+		if !unknown && size < uint64(len(data)-start) {
+			end = start + int(size)
+		}
+
+		descend, err := fn(id, data[start:end])
+		if err != nil {
+			return err
+		}
+		if descend {
+			data = data[start:]
+			continue
+		}
+		data = data[end:]
+	}
+	return nil
+}
+
+// readVint reads an EBML variable-length integer. IDs keep their length marker,
+// sizes do not.
+func readVint(data []byte, keepMarker bool) (uint64, int, bool) {
+	if len(data) == 0 || data[0] == 0 {
+		return 0, 0, false
+	}
+	length := 1
+	for mask := byte(0x80); data[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 8 || len(data) < length {
+		return 0, 0, false
+	}
+	value := uint64(data[0])
+	if !keepMarker {
+		value &= uint64(0xFF >> length)
+	}
+	for _, b := range data[1:length] {
+		value = value<<8 | uint64(b)
+	}
+	return value, length, true
+}
+
+func readUint(payload []byte) uint64 {
+	var value uint64
+	for _, b := range payload {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
+
+func readFloat(payload []byte) float64 {
+	switch len(payload) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(payload)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(payload))
+	}
+	return 0
+}
@@ -166,6 +166,11 @@ func GetUsernameFromContext(ctx context.Context) (string, bool) {
 	return username, ok
 }
 
+func GetUserIDFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(models.UserIDContextKey).(int64)
+	return userID, ok
+}
+
 func GetAPIKeyFromContext(ctx context.Context) (string, bool) {
 	apiKey, ok := ctx.Value(models.APIKeyContextKey).(string)
 	return apiKey, ok
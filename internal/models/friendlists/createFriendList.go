@@ -0,0 +1,13 @@
+package models
+
+type CreateFriendListRequest struct {
+	Name      string  `json:"name"`
+	MemberIDs []int64 `json:"memberIDs"`
+}
+
+type CreateFriendListResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message,omitempty"`
+	ListID       int64  `json:"listID,omitempty"`
+	MembersAdded int64  `json:"membersAdded"`
+}
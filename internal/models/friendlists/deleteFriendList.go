@@ -0,0 +1,7 @@
+package models
+
+type DeleteFriendListResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message,omitempty"`
+	PostsUpdated int64  `json:"postsUpdated"`
+}
@@ -0,0 +1,6 @@
+package models
+
+type DeleteFriendListMemberResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
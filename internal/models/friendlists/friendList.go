@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type FriendList struct {
+	ListID      int64      `json:"listID"`
+	Name        string     `json:"name"`
+	MemberCount int64      `json:"memberCount"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+}
+
+type FriendListMember struct {
+	UserID        int64      `json:"userID"`
+	Username      *string    `json:"username"`
+	FirstName     *string    `json:"firstName"`
+	LastName      *string    `json:"lastName"`
+	PreferredName *string    `json:"preferredName"`
+	ProfilePicURL *string    `json:"profilePicURL"`
+	AddedAt       *time.Time `json:"addedAt"`
+}
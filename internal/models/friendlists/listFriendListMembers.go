@@ -0,0 +1,9 @@
+package models
+
+type ListFriendListMembersResponse struct {
+	Members      []FriendListMember `json:"members"`
+	Limit        int64              `json:"limit"`
+	Page         int64              `json:"page"`
+	TotalMembers int64              `json:"totalMembers"`
+	TotalPages   int64              `json:"totalPages"`
+}
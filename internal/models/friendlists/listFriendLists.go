@@ -0,0 +1,5 @@
+package models
+
+type ListFriendListsResponse struct {
+	Lists []FriendList `json:"lists"`
+}
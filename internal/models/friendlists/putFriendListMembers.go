@@ -0,0 +1,12 @@
+package models
+
+type PutFriendListMembersRequest struct {
+	ListID    int64   `json:"listID"`
+	MemberIDs []int64 `json:"memberIDs"`
+}
+
+type PutFriendListMembersResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message,omitempty"`
+	MembersAdded int64  `json:"membersAdded"`
+}
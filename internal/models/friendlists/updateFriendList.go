@@ -0,0 +1,11 @@
+package models
+
+type UpdateFriendListRequest struct {
+	ListID int64  `json:"listID"`
+	Name   string `json:"name"`
+}
+
+type UpdateFriendListResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type CreateGroupRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Privacy     string  `json:"privacy"`
+}
+
+type CreateGroupResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	GroupID int64  `json:"groupID,omitempty"`
+}
@@ -0,0 +1,7 @@
+package models
+
+type GetGroupResponse struct {
+	Group          Group   `json:"group"`
+	ViewerRole     *string `json:"viewerRole"`
+	PendingRequest bool    `json:"pendingRequest"`
+}
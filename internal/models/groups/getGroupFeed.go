@@ -0,0 +1,48 @@
+package models
+
+import (
+	postModels "VoizyServer/internal/models/posts"
+	"time"
+)
+
+type GroupPost struct {
+	PostID             int64                     `json:"postID"`
+	UserID             int64                     `json:"userID"`
+	GroupID            int64                     `json:"groupID"`
+	OriginalPostID     *int64                    `json:"originalPostID"`
+	OriginalPost       *postModels.SharedPost    `json:"originalPost"`
+	FirstName          *string                   `json:"firstName"`
+	LastName           *string                   `json:"lastName"`
+	PreferredName      *string                   `json:"preferredName"`
+	Username           *string                   `json:"username"`
+	Impressions        int64                     `json:"impressions"`
+	Views              int64                     `json:"views"`
+	ContentText        *string                   `json:"contentText"`
+	LinkPreviews       []postModels.LinkPreview  `json:"linkPreviews"`
+	CreatedAt          *time.Time                `json:"createdAt"`
+	UpdatedAt          *time.Time                `json:"updatedAt"`
+	LocationName       *string                   `json:"locationName"`
+	LocationLat        *float64                  `json:"locationLat"`
+	LocationLong       *float64                  `json:"locationLong"`
+	IsPoll             *bool                     `json:"isPoll"`
+	PollQuestion       *string                   `json:"pollQuestion"`
+	PollDurationType   *string                   `json:"pollDurationType"`
+	PollDurationLength *int64                    `json:"pollDurationLength"`
+	Edited             bool                      `json:"edited"`
+	EditedAt           *time.Time                `json:"editedAt"`
+	UserReaction       *string                   `json:"userReaction"`
+	ProfilePicURL      *string                   `json:"profilePicURL"`
+	ProfilePicVariants []postModels.ImageVariant `json:"profilePicVariants"`
+	TotalReactions     int64                     `json:"totalReactions"`
+	ReactionCounts     map[string]int64          `json:"reactionCounts"`
+	TotalComments      int64                     `json:"totalComments"`
+	TotalPostShares    int64                     `json:"totalPostShares"`
+}
+
+type GetGroupFeedResponse struct {
+	GroupPosts []GroupPost `json:"groupPosts"`
+	Limit      int64       `json:"limit"`
+	Page       int64       `json:"page"`
+	TotalPosts int64       `json:"totalPosts"`
+	TotalPages int64       `json:"totalPages"`
+}
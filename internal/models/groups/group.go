@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type Group struct {
+	GroupID      int64      `json:"groupID"`
+	Name         string     `json:"name"`
+	Description  *string    `json:"description"`
+	Privacy      string     `json:"privacy"`
+	CreatorID    int64      `json:"creatorID"`
+	CreatedAt    *time.Time `json:"createdAt"`
+	UpdatedAt    *time.Time `json:"updatedAt"`
+	TotalMembers int64      `json:"totalMembers"`
+}
+
+type GroupMember struct {
+	UserID        int64      `json:"userID"`
+	Username      *string    `json:"username"`
+	FirstName     *string    `json:"firstName"`
+	LastName      *string    `json:"lastName"`
+	PreferredName *string    `json:"preferredName"`
+	ProfilePicURL *string    `json:"profilePicURL"`
+	Role          string     `json:"role"`
+	JoinedAt      *time.Time `json:"joinedAt"`
+}
@@ -0,0 +1,12 @@
+package models
+
+type JoinGroupRequest struct {
+	GroupID int64 `json:"groupID"`
+}
+
+type JoinGroupResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	// Status is "joined" for public groups and "requested" for private and closed groups.
+	Status string `json:"status,omitempty"`
+}
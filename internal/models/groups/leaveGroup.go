@@ -0,0 +1,10 @@
+package models
+
+type LeaveGroupRequest struct {
+	GroupID int64 `json:"groupID"`
+}
+
+type LeaveGroupResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type ListGroup struct {
+	Group
+	Role string `json:"role"`
+}
+
+type ListGroupsResponse struct {
+	Groups      []ListGroup `json:"groups"`
+	Limit       int64       `json:"limit"`
+	Page        int64       `json:"page"`
+	TotalGroups int64       `json:"totalGroups"`
+	TotalPages  int64       `json:"totalPages"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type GroupJoinRequest struct {
+	GroupJoinRequestID int64      `json:"groupJoinRequestID"`
+	GroupID            int64      `json:"groupID"`
+	UserID             int64      `json:"userID"`
+	Username           *string    `json:"username"`
+	FirstName          *string    `json:"firstName"`
+	LastName           *string    `json:"lastName"`
+	PreferredName      *string    `json:"preferredName"`
+	ProfilePicURL      *string    `json:"profilePicURL"`
+	CreatedAt          *time.Time `json:"createdAt"`
+}
+
+type ListJoinRequestsResponse struct {
+	JoinRequests  []GroupJoinRequest `json:"joinRequests"`
+	Limit         int64              `json:"limit"`
+	Page          int64              `json:"page"`
+	TotalRequests int64              `json:"totalRequests"`
+	TotalPages    int64              `json:"totalPages"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type RespondJoinRequestRequest struct {
+	GroupJoinRequestID int64 `json:"groupJoinRequestID"`
+	Approve            bool  `json:"approve"`
+}
+
+type RespondJoinRequestResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type UpdateGroupRequest struct {
+	GroupID     int64   `json:"groupID"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Privacy     *string `json:"privacy,omitempty"`
+}
+
+type UpdateGroupResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+type UpdateMemberRoleRequest struct {
+	GroupID  int64  `json:"groupID"`
+	MemberID int64  `json:"memberID"`
+	Role     string `json:"role"`
+}
+
+type UpdateMemberRoleResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type HashtagSuggestion struct {
+	HashtagID int64  `json:"hashtagID"`
+	Tag       string `json:"tag"`
+	PostCount int64  `json:"postCount"`
+}
+
+type AutocompleteHashtagsResponse struct {
+	Hashtags []HashtagSuggestion `json:"hashtags"`
+	Prefix   string              `json:"prefix"`
+	Limit    int64               `json:"limit"`
+}
@@ -0,0 +1,15 @@
+package models
+
+type TrendingHashtag struct {
+	HashtagID       int64   `json:"hashtagID"`
+	Tag             string  `json:"tag"`
+	Uses            int64   `json:"uses"`
+	DistinctAuthors int64   `json:"distinctAuthors"`
+	Score           float64 `json:"score"`
+}
+
+type ListTrendingHashtagsResponse struct {
+	Hashtags    []TrendingHashtag `json:"hashtags"`
+	WindowHours int64             `json:"windowHours"`
+	Limit       int64             `json:"limit"`
+}
@@ -0,0 +1,12 @@
+package models
+
+type AddConversationMembersRequest struct {
+	ConversationID int64   `json:"conversationID"`
+	MemberIDs      []int64 `json:"memberIDs"`
+}
+
+type AddConversationMembersResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message,omitempty"`
+	MembersAdded int64  `json:"membersAdded"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type Conversation struct {
+	ConversationID   int64     `json:"conversationID"`
+	ConversationName *string   `json:"conversationName"`
+	IsGroupChat      bool      `json:"isGroupChat"`
+	CreatedBy        *int64    `json:"createdBy"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+type ConversationMember struct {
+	UserID        int64     `json:"userID"`
+	Username      *string   `json:"username"`
+	FirstName     *string   `json:"firstName"`
+	LastName      *string   `json:"lastName"`
+	PreferredName *string   `json:"preferredName"`
+	ProfilePicURL *string   `json:"profilePicURL"`
+	JoinedAt      time.Time `json:"joinedAt"`
+}
+
+// MessageAttachment is a file sent with a message. Voice notes, of type "audio",
+// also have a duration and a waveform of levels from 0 to 255 to draw.
+type MessageAttachment struct {
+	AttachmentID int64     `json:"attachmentID"`
+	FileURL      string    `json:"fileURL"`
+	FileType     string    `json:"fileType"`
+	DurationMS   *int64    `json:"durationMS,omitempty"`
+	Waveform     []int     `json:"waveform,omitempty"`
+	UploadedAt   time.Time `json:"uploadedAt"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type CreateConversationRequest struct {
+	MemberIDs        []int64 `json:"memberIDs"`
+	ConversationName *string `json:"conversationName,omitempty"`
+	IsGroupChat      bool    `json:"isGroupChat"`
+}
+
+type CreateConversationResponse struct {
+	Success        bool   `json:"success"`
+	Message        string `json:"message,omitempty"`
+	ConversationID int64  `json:"conversationID,omitempty"`
+	AlreadyExisted bool   `json:"alreadyExisted"`
+}
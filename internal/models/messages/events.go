@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type MessageReactionEvent struct {
+	MessageID    int64  `json:"messageID"`
+	ReactionType string `json:"reactionType"`
+	Removed      bool   `json:"removed"`
+}
+
+type MessagesReadEvent struct {
+	MessageIDs []int64   `json:"messageIDs,omitempty"`
+	ReadAt     time.Time `json:"readAt"`
+}
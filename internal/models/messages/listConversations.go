@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type ListConversation struct {
+	ConversationID    int64                `json:"conversationID"`
+	ConversationName  *string              `json:"conversationName"`
+	IsGroupChat       bool                 `json:"isGroupChat"`
+	CreatedBy         *int64               `json:"createdBy"`
+	CreatedAt         time.Time            `json:"createdAt"`
+	LastMessageID     *int64               `json:"lastMessageID"`
+	LastMessageText   *string              `json:"lastMessageText"`
+	LastMessageSender *int64               `json:"lastMessageSenderID"`
+	LastMessageSentAt *time.Time           `json:"lastMessageSentAt"`
+	UnreadCount       int64                `json:"unreadCount"`
+	Members           []ConversationMember `json:"members"`
+}
+
+type ListConversationsResponse struct {
+	Conversations      []ListConversation `json:"conversations"`
+	Limit              int64              `json:"limit"`
+	Page               int64              `json:"page"`
+	TotalConversations int64              `json:"totalConversations"`
+	TotalPages         int64              `json:"totalPages"`
+}
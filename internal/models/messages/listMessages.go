@@ -0,0 +1,30 @@
+package models
+
+import (
+	postModels "VoizyServer/internal/models/posts"
+	"time"
+)
+
+type ListMessage struct {
+	MessageID      int64                    `json:"messageID"`
+	ConversationID int64                    `json:"conversationID"`
+	SenderID       int64                    `json:"senderID"`
+	Username       *string                  `json:"username"`
+	PreferredName  *string                  `json:"preferredName"`
+	ProfilePicURL  *string                  `json:"profilePicURL"`
+	ContentText    *string                  `json:"contentText"`
+	LinkPreviews   []postModels.LinkPreview `json:"linkPreviews"`
+	SentAt         time.Time                `json:"sentAt"`
+	IsRead         bool                     `json:"isRead"`
+	ReadAt         *time.Time               `json:"readAt"`
+	ReadByCount    int64                    `json:"readByCount"`
+	Attachments    []MessageAttachment      `json:"attachments"`
+}
+
+type ListMessagesResponse struct {
+	Messages      []ListMessage `json:"messages"`
+	Limit         int64         `json:"limit"`
+	Page          int64         `json:"page"`
+	TotalMessages int64         `json:"totalMessages"`
+	TotalPages    int64         `json:"totalPages"`
+}
@@ -0,0 +1,12 @@
+package models
+
+type MarkMessagesReadRequest struct {
+	ConversationID int64   `json:"conversationID"`
+	MessageIDs     []int64 `json:"messageIDs,omitempty"`
+}
+
+type MarkMessagesReadResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message,omitempty"`
+	MessagesRead int64  `json:"messagesRead"`
+}
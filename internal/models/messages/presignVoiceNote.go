@@ -0,0 +1,22 @@
+package models
+
+type PresignVoiceNoteRequest struct {
+	ConversationID int64  `json:"conversationID"`
+	FileName       string `json:"fileName"`
+	ContentType    string `json:"contentType,omitempty"`
+	Size           int64  `json:"size,omitempty"`
+}
+
+// PresignVoiceNoteResponse says where to upload the recording. The PUT has to
+// carry Headers.
+type PresignVoiceNoteResponse struct {
+	Success       bool              `json:"success"`
+	Message       string            `json:"message,omitempty"`
+	FileName      string            `json:"fileName,omitempty"`
+	PresignedURL  string            `json:"presignedURL,omitempty"`
+	FinalURL      string            `json:"finalURL,omitempty"`
+	ContentType   string            `json:"contentType,omitempty"`
+	MaxSize       int64             `json:"maxSize,omitempty"`
+	MaxDurationMS int64             `json:"maxDurationMS,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type PutMessageReactionRequest struct {
+	MessageID    int64  `json:"messageID"`
+	ReactionType string `json:"reactionType"`
+}
+
+type PutMessageReactionResponse struct {
+	Success    bool   `json:"success"`
+	Message    string `json:"message,omitempty"`
+	ReactionID int64  `json:"reactionID,omitempty"`
+	Removed    bool   `json:"removed"`
+}
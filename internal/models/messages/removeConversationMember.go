@@ -0,0 +1,11 @@
+package models
+
+type RemoveConversationMemberRequest struct {
+	ConversationID int64 `json:"conversationID"`
+	MemberID       int64 `json:"memberID"`
+}
+
+type RemoveConversationMemberResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
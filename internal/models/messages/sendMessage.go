@@ -0,0 +1,18 @@
+package models
+
+type SendMessageAttachment struct {
+	FileURL  string `json:"fileURL"`
+	FileType string `json:"fileType"`
+}
+
+type SendMessageRequest struct {
+	ConversationID int64                   `json:"conversationID"`
+	ContentText    string                  `json:"contentText"`
+	Attachments    []SendMessageAttachment `json:"attachments"`
+}
+
+type SendMessageResponse struct {
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+	MessageID int64  `json:"messageID,omitempty"`
+}
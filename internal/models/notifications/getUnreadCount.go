@@ -0,0 +1,6 @@
+package models
+
+type GetUnreadCountResponse struct {
+	UnreadCount         int64 `json:"unreadCount"`
+	UnreadNotifications int64 `json:"unreadNotifications"`
+}
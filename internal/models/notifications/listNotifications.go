@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// NotificationGroup collapses every notification that shares a group key and read
+// state, e.g. all unread reactions to one post.
+type NotificationGroup struct {
+	GroupKey              string    `json:"groupKey"`
+	NotificationType      string    `json:"notificationType"`
+	ObjectType            string    `json:"objectType"`
+	ObjectID              int64     `json:"objectID"`
+	IsRead                bool      `json:"isRead"`
+	LatestActorID         int64     `json:"latestActorID"`
+	LatestActorUsername   string    `json:"latestActorUsername"`
+	LatestActorName       *string   `json:"latestActorName"`
+	LatestActorProfilePic *string   `json:"latestActorProfilePicURL"`
+	ActorCount            int64     `json:"actorCount"`
+	NotificationCount     int64     `json:"notificationCount"`
+	Summary               string    `json:"summary"`
+	LatestAt              time.Time `json:"latestAt"`
+}
+
+type ListNotificationsResponse struct {
+	Notifications []NotificationGroup `json:"notifications"`
+	Limit         int64               `json:"limit"`
+	Page          int64               `json:"page"`
+	TotalGroups   int64               `json:"totalGroups"`
+	TotalPages    int64               `json:"totalPages"`
+}
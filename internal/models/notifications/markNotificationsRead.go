@@ -0,0 +1,11 @@
+package models
+
+type MarkNotificationsReadRequest struct {
+	GroupKeys []string `json:"groupKeys,omitempty"`
+}
+
+type MarkNotificationsReadResponse struct {
+	Success           bool   `json:"success"`
+	Message           string `json:"message,omitempty"`
+	NotificationsRead int64  `json:"notificationsRead"`
+}
@@ -1,24 +1,39 @@
 package models
 
 type CreatePostRequest struct {
-	UserID             int64    `json:"userID"`
-	ToUserID           int64    `json:"toUserID"`
-	OriginalPostID     *int64   `json:"originalPostID,omitempty"`
-	ContentText        string   `json:"contentText"`
-	LocationName       string   `json:"locationName"`
-	LocationLat        float64  `json:"locationLat"`
-	LocationLong       float64  `json:"locationLong"`
-	Images             []string `json:"images"`
-	Hashtags           []string `json:"hashtags"`
-	IsPoll             bool     `json:"isPoll"`
-	PollQuestion       string   `json:"pollQuestion"`
-	PollDurationType   string   `json:"pollDurationType"`
-	PollDurationLength int64    `json:"pollDurationLength"`
-	PollOptions        []string `json:"pollOptions"`
+	UserID             int64           `json:"userID"`
+	ToUserID           int64           `json:"toUserID"`
+	OriginalPostID     *int64          `json:"originalPostID,omitempty"`
+	GroupID            *int64          `json:"groupID,omitempty"`
+	Audience           string          `json:"audience,omitempty"`
+	AudienceListID     *int64          `json:"audienceListID,omitempty"`
+	ContentText        string          `json:"contentText"`
+	LocationName       string          `json:"locationName"`
+	LocationLat        float64         `json:"locationLat"`
+	LocationLong       float64         `json:"locationLong"`
+	Images             []string        `json:"images"`
+	Media              []PostMediaItem `json:"media,omitempty"`
+	Hashtags           []string        `json:"hashtags"`
+	IsPoll             bool            `json:"isPoll"`
+	PollQuestion       string          `json:"pollQuestion"`
+	PollDurationType   string          `json:"pollDurationType"`
+	PollDurationLength int64           `json:"pollDurationLength"`
+	PollOptions        []string        `json:"pollOptions"`
+}
+
+// PostMediaItem is one image, video or audio file of a post. Media is attached
+// after Images, which are all images. MediaType has to match what was uploaded and
+// may be left out. DurationMS is only taken from the client when it could not be
+// read from the file itself.
+type PostMediaItem struct {
+	URL        string `json:"url"`
+	MediaType  string `json:"mediaType,omitempty"`
+	DurationMS *int64 `json:"durationMS,omitempty"`
 }
 
 type CreatePostResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
-	PostID  int64  `json:"postID,omitempty"`
+	Success  bool          `json:"success"`
+	Message  string        `json:"message,omitempty"`
+	PostID   int64         `json:"postID,omitempty"`
+	Mentions []MentionSpan `json:"mentions,omitempty"`
 }
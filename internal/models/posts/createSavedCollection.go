@@ -0,0 +1,12 @@
+package models
+
+type CreateSavedCollectionRequest struct {
+	Name string `json:"name"`
+}
+
+type CreateSavedCollectionResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message,omitempty"`
+	CollectionID int64  `json:"collectionID,omitempty"`
+	Position     int64  `json:"position"`
+}
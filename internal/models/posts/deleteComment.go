@@ -0,0 +1,7 @@
+package models
+
+type DeleteCommentResponse struct {
+	Success         bool   `json:"success"`
+	Message         string `json:"message,omitempty"`
+	CommentsDeleted int64  `json:"commentsDeleted"`
+}
@@ -0,0 +1,7 @@
+package models
+
+type DeletePinnedPostResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	PostID  int64  `json:"postID"`
+}
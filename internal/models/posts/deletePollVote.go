@@ -0,0 +1,10 @@
+package models
+
+type DeletePollVoteRequest struct {
+	PostID int64 `json:"postID"`
+}
+
+type DeletePollVoteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type DeletePostResponse struct {
+	Success   bool       `json:"success"`
+	Message   string     `json:"message,omitempty"`
+	PostID    int64      `json:"postID"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	PurgeAt   *time.Time `json:"purgeAt,omitempty"`
+}
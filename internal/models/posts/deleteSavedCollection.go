@@ -0,0 +1,7 @@
+package models
+
+type DeleteSavedCollectionResponse struct {
+	Success    bool   `json:"success"`
+	Message    string `json:"message,omitempty"`
+	PostsMoved int64  `json:"postsMoved"`
+}
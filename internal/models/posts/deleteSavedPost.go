@@ -0,0 +1,7 @@
+package models
+
+type DeleteSavedPostResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	PostID  int64  `json:"postID"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type PollOptionResult struct {
+	PollOptionID int64   `json:"pollOptionID"`
+	OptionText   string  `json:"optionText"`
+	VoteCount    int64   `json:"voteCount"`
+	Percentage   float64 `json:"percentage"`
+}
+
+type GetPollResultsResponse struct {
+	PostID           int64              `json:"postID"`
+	PollQuestion     *string            `json:"pollQuestion"`
+	PollEndDatetime  *time.Time         `json:"pollEndDatetime"`
+	IsClosed         bool               `json:"isClosed"`
+	TotalVotes       int64              `json:"totalVotes"`
+	UserPollOptionID *int64             `json:"userPollOptionID"`
+	Options          []PollOptionResult `json:"options"`
+}
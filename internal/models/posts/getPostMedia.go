@@ -1,6 +1,9 @@
 package models
 
 type GetMediaResponse struct {
-	Images []string `json:"images"`
-	Videos []string `json:"videos"`
+	Images       []string        `json:"images"`
+	ImageSources []Image         `json:"imageSources"`
+	Videos       []string        `json:"videos"`
+	Audio        []string        `json:"audio"`
+	Media        []PostMediaItem `json:"media"`
 }
@@ -0,0 +1,5 @@
+package models
+
+type GetTotalSharesResponse struct {
+	TotalShares int64 `json:"totalShares"`
+}
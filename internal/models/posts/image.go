@@ -0,0 +1,18 @@
+package models
+
+type ImageVariant struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// Image is an uploaded image with the resized copies made of it. Variants reads
+// like a srcset: it is ordered by width and ends with the full-size image. It is
+// empty until the image has been processed, and Blurhash is nil until then.
+type Image struct {
+	URL      string         `json:"url"`
+	Width    *int           `json:"width"`
+	Height   *int           `json:"height"`
+	Blurhash *string        `json:"blurhash"`
+	Variants []ImageVariant `json:"variants"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type LinkPreview struct {
+	URL         string    `json:"url"`
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	ImageURL    *string   `json:"imageURL"`
+	SiteName    *string   `json:"siteName"`
+	FetchedAt   time.Time `json:"fetchedAt"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type DraftPost struct {
+	PostID             int64      `json:"postID"`
+	ToUserID           int64      `json:"toUserID"`
+	GroupID            *int64     `json:"groupID"`
+	Audience           string     `json:"audience"`
+	AudienceListID     *int64     `json:"audienceListID"`
+	ContentText        *string    `json:"contentText"`
+	LocationName       *string    `json:"locationName"`
+	LocationLat        *float64   `json:"locationLat"`
+	LocationLong       *float64   `json:"locationLong"`
+	IsPoll             *bool      `json:"isPoll"`
+	PollQuestion       *string    `json:"pollQuestion"`
+	PollDurationType   *string    `json:"pollDurationType"`
+	PollDurationLength *int64     `json:"pollDurationLength"`
+	Status             string     `json:"status"`
+	PublishAt          *time.Time `json:"publishAt"`
+	CreatedAt          *time.Time `json:"createdAt"`
+	UpdatedAt          *time.Time `json:"updatedAt"`
+	Images             []string   `json:"images"`
+	Hashtags           []string   `json:"hashtags"`
+}
+
+type ListDraftsResponse struct {
+	Drafts      []DraftPost `json:"drafts"`
+	Limit       int64       `json:"limit"`
+	Page        int64       `json:"page"`
+	TotalDrafts int64       `json:"totalDrafts"`
+	TotalPages  int64       `json:"totalPages"`
+}
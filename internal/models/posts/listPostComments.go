@@ -3,19 +3,26 @@ package models
 import "time"
 
 type ListComment struct {
-	CommentID   	int64     `json:"commentID"`
-	PostID      	int64     `json:"postID"`
-	UserID      	int64     `json:"userID"`
-	ContentText 	string    `json:"contentText"`
-	CreatedAt   	time.Time `json:"createdAt"`
-	UpdatedAt   	time.Time `json:"updatedAt"`
-	Username 			*string   `json:"username"`
-	FirstName			*string   `json:"firstName"`
-	LastName			*string   `json:"lastName"`
-	PreferredName *string   `json:"preferredName"`
-	ProfilePicURL *string   `json:"profilePicURL"`
-	Reactions			[]string  `json:"reactions"`
-	ReactionCount	int64     `json:"reactionCount"`
+	CommentID       int64            `json:"commentID"`
+	PostID          int64            `json:"postID"`
+	UserID          int64            `json:"userID"`
+	ParentCommentID *int64           `json:"parentCommentID"`
+	Depth           int              `json:"depth"`
+	ContentText     string           `json:"contentText"`
+	CreatedAt       time.Time        `json:"createdAt"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
+	Edited          bool             `json:"edited"`
+	EditedAt        *time.Time       `json:"editedAt"`
+	ReplyCount      int64            `json:"replyCount"`
+	Username        *string          `json:"username"`
+	FirstName       *string          `json:"firstName"`
+	LastName        *string          `json:"lastName"`
+	PreferredName   *string          `json:"preferredName"`
+	ProfilePicURL   *string          `json:"profilePicURL"`
+	Reactions       []string         `json:"reactions"`
+	ReactionCount   int64            `json:"reactionCount"`
+	ReactionCounts  map[string]int64 `json:"reactionCounts"`
+	Mentions        []MentionSpan    `json:"mentions,omitempty"`
 }
 
 type ListCommentsResponse struct {
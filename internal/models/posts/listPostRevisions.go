@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type PostRevision struct {
+	RevisionNumber int64     `json:"revisionNumber"`
+	EditorID       int64     `json:"editorID"`
+	ContentText    *string   `json:"contentText"`
+	LocationName   *string   `json:"locationName"`
+	LocationLat    *float64  `json:"locationLat"`
+	LocationLong   *float64  `json:"locationLong"`
+	Images         []string  `json:"images"`
+	Videos         []string  `json:"videos"`
+	Audio          []string  `json:"audio"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type ListPostRevisionsResponse struct {
+	PostID         int64          `json:"postID"`
+	Revisions      []PostRevision `json:"revisions"`
+	Limit          int64          `json:"limit"`
+	Page           int64          `json:"page"`
+	TotalRevisions int64          `json:"totalRevisions"`
+	TotalPages     int64          `json:"totalPages"`
+}
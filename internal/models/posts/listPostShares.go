@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type PostShare struct {
+	ShareID       int64     `json:"shareID"`
+	SharePostID   int64     `json:"sharePostID"`
+	UserID        int64     `json:"userID"`
+	Username      *string   `json:"username"`
+	FirstName     *string   `json:"firstName"`
+	LastName      *string   `json:"lastName"`
+	PreferredName *string   `json:"preferredName"`
+	ProfilePicURL *string   `json:"profilePicURL"`
+	ContentText   *string   `json:"contentText"`
+	SharedAt      time.Time `json:"sharedAt"`
+}
+
+type ListPostSharesResponse struct {
+	Shares      []PostShare `json:"shares"`
+	Limit       int64       `json:"limit"`
+	Page        int64       `json:"page"`
+	TotalShares int64       `json:"totalShares"`
+	TotalPages  int64       `json:"totalPages"`
+}
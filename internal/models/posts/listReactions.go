@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type Reactor struct {
+	UserID        int64     `json:"userID"`
+	Username      *string   `json:"username"`
+	FirstName     *string   `json:"firstName"`
+	LastName      *string   `json:"lastName"`
+	PreferredName *string   `json:"preferredName"`
+	ProfilePicURL *string   `json:"profilePicURL"`
+	ReactionType  string    `json:"reactionType"`
+	ReactedAt     time.Time `json:"reactedAt"`
+	IsFriend      bool      `json:"isFriend"`
+}
+
+type ListReactionsResponse struct {
+	Reactions      []Reactor        `json:"reactions"`
+	ReactionCounts map[string]int64 `json:"reactionCounts"`
+	Limit          int64            `json:"limit"`
+	Page           int64            `json:"page"`
+	TotalReactions int64            `json:"totalReactions"`
+	TotalPages     int64            `json:"totalPages"`
+}
@@ -3,15 +3,20 @@ package models
 import "time"
 
 type RecommendedPost struct {
-	PostID        int64     `json:"postID"`
-	UserID        int64     `json:"userID"`
-	ContentText   string    `json:"contentText"`
-	CreatedAt     time.Time `json:"createdAt"`
-	ReactionCount int64     `json:"reactionCount"`
-	CommentCount  int64     `json:"commentCount"`
-	Views         int64     `json:"views"`
-	Impressions   int64     `json:"impressions"`
-	Score         float64   `json:"score"`
+	PostID         int64            `json:"postID"`
+	UserID         int64            `json:"userID"`
+	ContentText    string           `json:"contentText"`
+	LinkPreviews   []LinkPreview    `json:"linkPreviews"`
+	OriginalPost   *SharedPost      `json:"originalPost"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	Edited         bool             `json:"edited"`
+	EditedAt       *time.Time       `json:"editedAt"`
+	ReactionCount  int64            `json:"reactionCount"`
+	ReactionCounts map[string]int64 `json:"reactionCounts"`
+	CommentCount   int64            `json:"commentCount"`
+	Views          int64            `json:"views"`
+	Impressions    int64            `json:"impressions"`
+	Score          float64          `json:"score"`
 }
 
 type ListRecommendedFeedResponse struct {
@@ -0,0 +1,5 @@
+package models
+
+type ListSavedCollectionsResponse struct {
+	Collections []SavedCollection `json:"collections"`
+}
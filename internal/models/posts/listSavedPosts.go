@@ -0,0 +1,9 @@
+package models
+
+type ListSavedPostsResponse struct {
+	SavedPosts []RecommendedFeedPost `json:"savedPosts"`
+	Limit      int64                 `json:"limit"`
+	Page       int64                 `json:"page"`
+	TotalPosts int64                 `json:"totalPosts"`
+	TotalPages int64                 `json:"totalPages"`
+}
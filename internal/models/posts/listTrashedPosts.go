@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type TrashedPost struct {
+	PostID         int64      `json:"postID"`
+	ToUserID       int64      `json:"toUserID"`
+	OriginalPostID *int64     `json:"originalPostID"`
+	GroupID        *int64     `json:"groupID"`
+	ContentText    *string    `json:"contentText"`
+	IsPoll         *bool      `json:"isPoll"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	DeletedAt      time.Time  `json:"deletedAt"`
+	PurgeAt        time.Time  `json:"purgeAt"`
+}
+
+type ListTrashedPostsResponse struct {
+	Posts      []TrashedPost `json:"posts"`
+	Limit      int64         `json:"limit"`
+	Page       int64         `json:"page"`
+	TotalPosts int64         `json:"totalPosts"`
+	TotalPages int64         `json:"totalPages"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// MentionSpan marks an @username in contentText that resolved to a user. Start and
+// End are character (rune) offsets, End exclusive, and cover the leading '@'.
+type MentionSpan struct {
+	UserID   int64  `json:"userID"`
+	Username string `json:"username"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+}
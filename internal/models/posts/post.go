@@ -21,4 +21,5 @@ type Post struct {
 	PollQuestion       sql.NullString  `json:"pollQuestion"`
 	PollDurationType   sql.NullString  `json:"pollDurationType"`
 	PollDurationLength sql.NullInt64   `json:"pollDurationLength"`
+	EditedAt           sql.NullTime    `json:"editedAt"`
 }
@@ -0,0 +1,11 @@
+package models
+
+type PublishDraftRequest struct {
+	PostID int64 `json:"postID"`
+}
+
+type PublishDraftResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	PostID  int64  `json:"postID"`
+}
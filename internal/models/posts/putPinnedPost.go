@@ -0,0 +1,12 @@
+package models
+
+type PutPinnedPostRequest struct {
+	PostID int64 `json:"postID"`
+}
+
+type PutPinnedPostResponse struct {
+	Success  bool   `json:"success"`
+	Message  string `json:"message,omitempty"`
+	PostID   int64  `json:"postID"`
+	Position int64  `json:"position"`
+}
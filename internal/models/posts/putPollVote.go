@@ -0,0 +1,13 @@
+package models
+
+type PutPollVoteRequest struct {
+	PostID       int64 `json:"postID"`
+	PollOptionID int64 `json:"pollOptionID"`
+}
+
+type PutPollVoteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	// PreviousPollOptionID is set when the vote replaced an earlier choice.
+	PreviousPollOptionID *int64 `json:"previousPollOptionID,omitempty"`
+}
@@ -1,13 +1,17 @@
 package models
 
 type PutCommentRequest struct {
-	PostID      int64  `json:"postID"`
-	UserID      int64  `json:"userID"`
-	ContentText string `json:"contentText"`
+	PostID          int64  `json:"postID"`
+	UserID          int64  `json:"userID"`
+	ParentCommentID *int64 `json:"parentCommentID,omitempty"`
+	ContentText     string `json:"contentText"`
 }
 
 type PutCommentResponse struct {
-	Success   bool   `json:"success"`
-	Message   string `json:"message,omitempty"`
-	CommentID int64  `json:"commentID,omitempty"`
+	Success         bool          `json:"success"`
+	Message         string        `json:"message,omitempty"`
+	CommentID       int64         `json:"commentID,omitempty"`
+	ParentCommentID *int64        `json:"parentCommentID,omitempty"`
+	Depth           int           `json:"depth"`
+	Mentions        []MentionSpan `json:"mentions,omitempty"`
 }
@@ -0,0 +1,13 @@
+package models
+
+type PutSavedPostRequest struct {
+	PostID       int64  `json:"postID"`
+	CollectionID *int64 `json:"collectionID,omitempty"`
+}
+
+type PutSavedPostResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message,omitempty"`
+	PostID       int64  `json:"postID"`
+	CollectionID *int64 `json:"collectionID"`
+}
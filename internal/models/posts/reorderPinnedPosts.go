@@ -0,0 +1,10 @@
+package models
+
+type ReorderPinnedPostsRequest struct {
+	PostIDs []int64 `json:"postIDs"`
+}
+
+type ReorderPinnedPostsResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
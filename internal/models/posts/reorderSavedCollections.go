@@ -0,0 +1,10 @@
+package models
+
+type ReorderSavedCollectionsRequest struct {
+	CollectionIDs []int64 `json:"collectionIDs"`
+}
+
+type ReorderSavedCollectionsResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
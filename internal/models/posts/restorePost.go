@@ -0,0 +1,11 @@
+package models
+
+type RestorePostRequest struct {
+	PostID int64 `json:"postID"`
+}
+
+type RestorePostResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	PostID  int64  `json:"postID"`
+}
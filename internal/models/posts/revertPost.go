@@ -0,0 +1,14 @@
+package models
+
+type RevertPostRequest struct {
+	PostID         int64 `json:"postID"`
+	RevisionNumber int64 `json:"revisionNumber"`
+}
+
+type RevertPostResponse struct {
+	Success        bool          `json:"success"`
+	Message        string        `json:"message,omitempty"`
+	PostID         int64         `json:"postID"`
+	RevisionNumber int64         `json:"revisionNumber,omitempty"`
+	Mentions       []MentionSpan `json:"mentions,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type SaveDraftRequest struct {
+	CreatePostRequest
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+}
+
+type SaveDraftResponse struct {
+	Success   bool          `json:"success"`
+	Message   string        `json:"message,omitempty"`
+	PostID    int64         `json:"postID,omitempty"`
+	Status    string        `json:"status,omitempty"`
+	PublishAt *time.Time    `json:"publishAt,omitempty"`
+	Mentions  []MentionSpan `json:"mentions,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type SavedCollection struct {
+	CollectionID int64      `json:"collectionID"`
+	Name         string     `json:"name"`
+	Position     int64      `json:"position"`
+	TotalPosts   int64      `json:"totalPosts"`
+	CreatedAt    *time.Time `json:"createdAt"`
+	UpdatedAt    *time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type SharedPost struct {
+	Available          bool           `json:"available"`
+	PostID             *int64         `json:"postID,omitempty"`
+	UserID             *int64         `json:"userID,omitempty"`
+	Username           *string        `json:"username,omitempty"`
+	FirstName          *string        `json:"firstName,omitempty"`
+	LastName           *string        `json:"lastName,omitempty"`
+	PreferredName      *string        `json:"preferredName,omitempty"`
+	ProfilePicURL      *string        `json:"profilePicURL,omitempty"`
+	ProfilePicVariants []ImageVariant `json:"profilePicVariants,omitempty"`
+	ContentText        *string        `json:"contentText,omitempty"`
+	CreatedAt          *time.Time     `json:"createdAt,omitempty"`
+	Edited             bool           `json:"edited"`
+	IsPoll             bool           `json:"isPoll"`
+	PollQuestion       *string        `json:"pollQuestion,omitempty"`
+	Images             []string       `json:"images,omitempty"`
+	ImageSources       []Image        `json:"imageSources,omitempty"`
+	Videos             []string       `json:"videos,omitempty"`
+	Audio              []string       `json:"audio,omitempty"`
+}
@@ -12,7 +12,9 @@ type UpdatePostRequest struct {
 }
 
 type UpdatePostResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
-	PostID  int64  `json:"postID,omitempty"`
+	Success        bool          `json:"success"`
+	Message        string        `json:"message,omitempty"`
+	PostID         int64         `json:"postID,omitempty"`
+	RevisionNumber int64         `json:"revisionNumber,omitempty"`
+	Mentions       []MentionSpan `json:"mentions,omitempty"`
 }
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type UpdateCommentRequest struct {
+	CommentID   int64  `json:"commentID"`
+	ContentText string `json:"contentText"`
+}
+
+type UpdateCommentResponse struct {
+	Success   bool          `json:"success"`
+	Message   string        `json:"message,omitempty"`
+	CommentID int64         `json:"commentID,omitempty"`
+	Edited    bool          `json:"edited"`
+	EditedAt  *time.Time    `json:"editedAt,omitempty"`
+	Mentions  []MentionSpan `json:"mentions,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type UpdateDraftResponse struct {
+	Success   bool          `json:"success"`
+	Message   string        `json:"message,omitempty"`
+	PostID    int64         `json:"postID"`
+	Status    string        `json:"status,omitempty"`
+	PublishAt *time.Time    `json:"publishAt,omitempty"`
+	Mentions  []MentionSpan `json:"mentions,omitempty"`
+}
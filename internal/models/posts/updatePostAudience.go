@@ -0,0 +1,16 @@
+package models
+
+type UpdatePostAudienceRequest struct {
+	PostID         int64  `json:"postID"`
+	Audience       string `json:"audience"`
+	AudienceListID *int64 `json:"audienceListID,omitempty"`
+}
+
+type UpdatePostAudienceResponse struct {
+	Success        bool   `json:"success"`
+	Message        string `json:"message,omitempty"`
+	PostID         int64  `json:"postID"`
+	Audience       string `json:"audience"`
+	AudienceListID *int64 `json:"audienceListID"`
+	Unpinned       bool   `json:"unpinned"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type UpdateSavedCollectionRequest struct {
+	CollectionID int64  `json:"collectionID"`
+	Name         string `json:"name"`
+}
+
+type UpdateSavedCollectionResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
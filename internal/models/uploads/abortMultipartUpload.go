@@ -0,0 +1,11 @@
+package models
+
+type AbortMultipartUploadRequest struct {
+	FileName string `json:"fileName"`
+	UploadID string `json:"uploadID"`
+}
+
+type AbortMultipartUploadResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+type CompletedPart struct {
+	PartNumber int32  `json:"partNumber"`
+	ETag       string `json:"eTag"`
+}
+
+type CompleteMultipartUploadRequest struct {
+	FileName string          `json:"fileName"`
+	UploadID string          `json:"uploadID"`
+	Parts    []CompletedPart `json:"parts"`
+}
+
+type CompleteMultipartUploadResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message,omitempty"`
+	Upload  ConfirmedUpload `json:"upload"`
+}
@@ -0,0 +1,22 @@
+package models
+
+type ConfirmUploadsRequest struct {
+	FileNames []string `json:"fileNames"`
+}
+
+type ConfirmedUpload struct {
+	FileName    string `json:"fileName"`
+	FinalURL    string `json:"finalURL,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	DurationMS  int64  `json:"durationMS,omitempty"`
+	Codec       string `json:"codec,omitempty"`
+	Confirmed   bool   `json:"confirmed"`
+	Error       string `json:"error,omitempty"`
+}
+
+type ConfirmUploadsResponse struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message,omitempty"`
+	Uploads []ConfirmedUpload `json:"uploads"`
+}
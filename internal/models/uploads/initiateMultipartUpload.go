@@ -0,0 +1,19 @@
+package models
+
+type InitiateMultipartUploadRequest struct {
+	PostID      int64  `json:"postID"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+type InitiateMultipartUploadResponse struct {
+	Success     bool   `json:"success"`
+	Message     string `json:"message,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	UploadID    string `json:"uploadID,omitempty"`
+	FinalURL    string `json:"finalURL,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	MaxSize     int64  `json:"maxSize,omitempty"`
+	PartSize    int64  `json:"partSize,omitempty"`
+}
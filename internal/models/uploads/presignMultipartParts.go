@@ -0,0 +1,18 @@
+package models
+
+type PresignMultipartPartsRequest struct {
+	FileName    string  `json:"fileName"`
+	UploadID    string  `json:"uploadID"`
+	PartNumbers []int32 `json:"partNumbers"`
+}
+
+type PresignedPart struct {
+	PartNumber   int32  `json:"partNumber"`
+	PresignedURL string `json:"presignedURL"`
+}
+
+type PresignMultipartPartsResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message,omitempty"`
+	Parts   []PresignedPart `json:"parts"`
+}
@@ -9,4 +9,6 @@ type CreateFriendRequestResponse struct {
 	Success      bool   `json:"success"`
 	Message      string `json:"message,omitempty"`
 	FriendshipID int64  `json:"friendshipID,omitempty"`
+	// Status is "accepted" when the other user had already sent a request.
+	Status string `json:"status,omitempty"`
 }
@@ -1,14 +1,29 @@
 package models
 
 type GetBatchUserImagesPresignedPutUrlsRequest struct {
-	UserID int64    `json:"userID"`
-	FileNames []string `json:"fileNames"`
+	UserID    int64        `json:"userID"`
+	FileNames []string     `json:"fileNames"`
+	Files     []UploadFile `json:"files"`
 }
 
+// UploadFile is a file the client wants to upload. Clients that only send
+// fileNames get the content type their extensions imply and no size check until
+// the upload is confirmed.
+type UploadFile struct {
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+// PresignedFile is where to upload a file. The PUT has to carry Headers.
 type PresignedFile struct {
-	FileName 		 string `json:"fileName"`
-	PresignedURL string `json:"presignedURL"`
-	FinalURL 		 string `json:"finalURL"`
+	FileName     string            `json:"fileName"`
+	PresignedURL string            `json:"presignedURL"`
+	FinalURL     string            `json:"finalURL"`
+	ContentType  string            `json:"contentType"`
+	MaxSize      int64             `json:"maxSize"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Error        string            `json:"error,omitempty"`
 }
 
 type GetBatchUserImagesPresignedPutUrlsResponse struct {
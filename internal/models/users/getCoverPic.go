@@ -1,5 +1,9 @@
 package models
 
+import postModels "VoizyServer/internal/models/posts"
+
 type GetCoverPicResponse struct {
-	CoverPicURL string `json:"coverPicURL"`
+	CoverPicURL      string                    `json:"coverPicURL"`
+	CoverPicBlurhash *string                   `json:"coverPicBlurhash"`
+	CoverPicVariants []postModels.ImageVariant `json:"coverPicVariants"`
 }
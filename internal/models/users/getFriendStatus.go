@@ -2,4 +2,8 @@ package models
 
 type GetFriendStatusResponse struct {
 	Status string `json:"status"`
+	// Direction is "outgoing" when the requesting user sent the pending request or
+	// placed the block, and "incoming" when the other user sent the request. Empty
+	// when idle; a user who is blocked sees idle.
+	Direction string `json:"direction,omitempty"`
 }
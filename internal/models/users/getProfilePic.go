@@ -1,5 +1,9 @@
 package models
 
+import postModels "VoizyServer/internal/models/posts"
+
 type GetProfilePicResponse struct {
-	ProfilePicURL string `json:"profilePicURL"`
+	ProfilePicURL      string                    `json:"profilePicURL"`
+	ProfilePicBlurhash *string                   `json:"profilePicBlurhash"`
+	ProfilePicVariants []postModels.ImageVariant `json:"profilePicVariants"`
 }
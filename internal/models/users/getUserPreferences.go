@@ -12,4 +12,10 @@ type GetUserPreferencesResponse struct {
 	ProfileSecondaryColor  string `json:"profileSecondaryColor"`
 	ProfileSecondaryAccent string `json:"profileSecondaryAccent"`
 	ProfileSongAutoplay    bool   `json:"profileSongAutoplay"`
+	PushNotifications      bool   `json:"pushNotifications"`
+	NotifyPostReactions    bool   `json:"notifyPostReactions"`
+	NotifyPostComments     bool   `json:"notifyPostComments"`
+	NotifyFriendRequests   bool   `json:"notifyFriendRequests"`
+	NotifyWallPosts        bool   `json:"notifyWallPosts"`
+	NotifyMentions         bool   `json:"notifyMentions"`
 }
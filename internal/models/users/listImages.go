@@ -1,13 +1,20 @@
 package models
 
-import "time"
+import (
+	postModels "VoizyServer/internal/models/posts"
+	"time"
+)
 
 type UserImage struct {
-	UserID           int64     `json:"userID"`
-	ImageID 				 int64     `json:"imageID"`
-	ImageURL         string    `json:"imageURL"`
-	IsProfilePicture bool      `json:"isProfilePicture"`
-	UploadedAt       time.Time `json:"uploadedAt"`
+	UserID           int64                     `json:"userID"`
+	ImageID          int64                     `json:"imageID"`
+	ImageURL         string                    `json:"imageURL"`
+	IsProfilePicture bool                      `json:"isProfilePicture"`
+	UploadedAt       time.Time                 `json:"uploadedAt"`
+	Width            *int                      `json:"width"`
+	Height           *int                      `json:"height"`
+	Blurhash         *string                   `json:"blurhash"`
+	Variants         []postModels.ImageVariant `json:"variants"`
 }
 
 type ListImagesResponse struct {
@@ -12,6 +12,12 @@ type PutUserPreferencesRequest struct {
 	ProfileSecondaryColor  *string `json:"profileSecondaryColor,omitempty"`
 	ProfileSecondaryAccent *string `json:"profileSecondaryAccent,omitempty"`
 	ProfileSongAutoplay    *bool   `json:"profileSongAutoplay,omitempty"`
+	PushNotifications      *bool   `json:"pushNotifications,omitempty"`
+	NotifyPostReactions    *bool   `json:"notifyPostReactions,omitempty"`
+	NotifyPostComments     *bool   `json:"notifyPostComments,omitempty"`
+	NotifyFriendRequests   *bool   `json:"notifyFriendRequests,omitempty"`
+	NotifyWallPosts        *bool   `json:"notifyWallPosts,omitempty"`
+	NotifyMentions         *bool   `json:"notifyMentions,omitempty"`
 }
 
 type PutUserPreferencesResponse struct {
@@ -0,0 +1,15 @@
+package models
+
+type UpdateFriendshipRequest struct {
+	FriendID int64 `json:"friendID"`
+	// Action is one of accept, decline, cancel, unfriend, block or unblock.
+	Action string `json:"action"`
+}
+
+type UpdateFriendshipResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message,omitempty"`
+	FriendshipID int64  `json:"friendshipID,omitempty"`
+	Status       string `json:"status"`
+	Direction    string `json:"direction,omitempty"`
+}
@@ -0,0 +1,207 @@
+package notifications
+
+import (
+	"VoizyServer/internal/database"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Payload is what a Deliverer sends to the recipient's devices for one notification.
+type Payload struct {
+	NotificationID int64     `json:"notificationID"`
+	UserID         int64     `json:"userID"`
+	ActorID        int64     `json:"actorID"`
+	ActorName      string    `json:"actorName"`
+	Type           string    `json:"notificationType"`
+	ObjectType     string    `json:"objectType"`
+	ObjectID       int64     `json:"objectID"`
+	Summary        string    `json:"summary"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// Deliverer sends a notification outside the app, e.g. to a push gateway.
+// Deliver is retried by the Dispatcher when it fails, unless the error is
+// wrapped with Permanent.
+type Deliverer interface {
+	Name() string
+	Deliver(ctx context.Context, p Payload) error
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as one that retrying cannot fix, such as a rejected payload,
+// so the Dispatcher dead-letters the notification straight away.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// Dispatcher delivers stored notifications through a Deliverer, retrying failed
+// attempts with exponential backoff and recording the ones that never get through
+// in notification_dead_letters.
+type Dispatcher struct {
+	Deliverer   Deliverer
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration
+
+	// deadLetter records a notification that never got through.
+	deadLetter func(deliverer string, p Payload, attempts int, lastErr error) error
+}
+
+var DefaultDispatcher *Dispatcher
+
+func NewDispatcher(d Deliverer) *Dispatcher {
+	return &Dispatcher{
+		Deliverer:   d,
+		MaxAttempts: 5,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+		Timeout:     10 * time.Second,
+		deadLetter:  deadLetter,
+	}
+}
+
+// InitDelivery makes d the deliverer used for every notification from now on.
+func InitDelivery(d Deliverer) {
+	DefaultDispatcher = NewDispatcher(d)
+}
+
+// backoff returns how long to wait after the given failed attempt: BaseBackoff,
+// doubled for every attempt after the first and capped at MaxBackoff.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	wait := d.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if wait >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+	return wait
+}
+
+// Dispatch delivers the stored notification in the background when the recipient
+// has opted in to it.
+func (d *Dispatcher) Dispatch(notificationID int64, n Notification) {
+	go func() {
+		if err := d.dispatch(notificationID, n); err != nil {
+			log.Println("Failed to dispatch notification due to the following error: ", err)
+		}
+	}()
+}
+
+func (d *Dispatcher) dispatch(notificationID int64, n Notification) error {
+	enabled, err := deliveryEnabled(n.RecipientID, n.Type)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	payload, err := buildPayload(notificationID, n)
+	if err != nil {
+		return err
+	}
+	return d.send(payload)
+}
+
+// send delivers payload, retrying until it gets through or MaxAttempts is used up,
+// and dead-letters it if it never does.
+func (d *Dispatcher) send(payload Payload) error {
+	var lastErr error
+	attempts := 0
+	for attempts < d.MaxAttempts {
+		attempts++
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout)
+		lastErr = d.Deliverer.Deliver(ctx, payload)
+		cancel()
+		if lastErr == nil {
+			return nil
+		}
+
+		var permanent permanentError
+		if errors.As(lastErr, &permanent) {
+			break
+		}
+		if attempts < d.MaxAttempts {
+			wait := d.backoff(attempts)
+			log.Printf("Delivery of notification %d via %s failed (attempt %d of %d), retrying in %s: %v",
+				payload.NotificationID, d.Deliverer.Name(), attempts, d.MaxAttempts, wait, lastErr)
+			time.Sleep(wait)
+		}
+	}
+
+	return d.deadLetter(d.Deliverer.Name(), payload, attempts, lastErr)
+}
+
+func buildPayload(notificationID int64, n Notification) (Payload, error) {
+	var (
+		username  string
+		actorName sql.NullString
+		createdAt time.Time
+	)
+	query := `
+		SELECT u.username, COALESCE(up.preferred_name, up.first_name), n.created_at
+		FROM notifications n
+		JOIN users u ON u.user_id = n.actor_id
+		LEFT JOIN user_profiles up ON up.user_id = n.actor_id
+		WHERE n.notification_id = ?
+		LIMIT 1
+	`
+	err := database.DB.QueryRow(query, notificationID).Scan(&username, &actorName, &createdAt)
+	if err != nil {
+		return Payload{}, fmt.Errorf("failed to load notification %d: %w", notificationID, err)
+	}
+
+	name := username
+	if actorName.Valid && actorName.String != "" {
+		name = actorName.String
+	}
+
+	return Payload{
+		NotificationID: notificationID,
+		UserID:         n.RecipientID,
+		ActorID:        n.ActorID,
+		ActorName:      name,
+		Type:           n.Type,
+		ObjectType:     n.ObjectType,
+		ObjectID:       n.ObjectID,
+		Summary:        Summary(n.Type, name, 1),
+		CreatedAt:      createdAt,
+	}, nil
+}
+
+func deadLetter(deliverer string, p Payload, attempts int, lastErr error) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter payload: %w", err)
+	}
+
+	errText := ""
+	if lastErr != nil {
+		errText = lastErr.Error()
+	}
+
+	_, err = database.DB.Exec(`
+		INSERT INTO notification_dead_letters (notification_id, user_id, deliverer, payload, attempts, last_error)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, p.NotificationID, p.UserID, deliverer, body, attempts, errText)
+	if err != nil {
+		return fmt.Errorf("failed to insert dead letter: %w", err)
+	}
+
+	log.Printf("Notification %d dead-lettered after %d attempts via %s: %s", p.NotificationID, attempts, deliverer, errText)
+	return nil
+}
@@ -0,0 +1,19 @@
+package notifications
+
+import (
+	"context"
+	"log"
+)
+
+// LogDeliverer only logs each payload. It is the deliverer used when no push
+// endpoint is configured, e.g. in local development.
+type LogDeliverer struct{}
+
+func (LogDeliverer) Name() string {
+	return "log"
+}
+
+func (LogDeliverer) Deliver(ctx context.Context, p Payload) error {
+	log.Printf("Notification %d for user %d: %s", p.NotificationID, p.UserID, p.Summary)
+	return nil
+}
@@ -0,0 +1,101 @@
+// Package notifications records in-app notifications for activity that involves a
+// user, such as reactions and comments on their posts or friend requests. Handlers
+// raise them with Send, in a goroutine, once their own write has succeeded.
+package notifications
+
+import (
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"fmt"
+	"log"
+)
+
+const (
+	TypePostReaction          = "post_reaction"
+	TypePostComment           = "post_comment"
+	TypeCommentReply          = "comment_reply"
+	TypeFriendRequest         = "friend_request"
+	TypeFriendRequestAccepted = "friend_request_accepted"
+	TypeWallPost              = "wall_post"
+	TypePostMention           = "post_mention"
+	TypeCommentMention        = "comment_mention"
+)
+
+// groupedByObject lists the types that are grouped per object, so reactions to one
+// post collapse together while reactions to another post stay separate. Every other
+// type is grouped per type, e.g. all pending friend requests.
+var groupedByObject = map[string]bool{
+	TypePostReaction: true,
+	TypePostComment:  true,
+	TypeCommentReply: true,
+}
+
+// Notification is one piece of activity by ActorID that RecipientID should hear about.
+type Notification struct {
+	RecipientID int64
+	ActorID     int64
+	Type        string
+	ObjectType  string
+	ObjectID    int64
+}
+
+// GroupKey identifies the group the notification is collapsed into when listed.
+func (n Notification) GroupKey() string {
+	if groupedByObject[n.Type] {
+		return fmt.Sprintf("%s:%s:%d", n.Type, n.ObjectType, n.ObjectID)
+	}
+	return n.Type
+}
+
+// notify stores n for its recipient and hands it to the DefaultDispatcher, if one
+// is set, for delivery to their devices. Nothing is stored when users act on their
+// own content or when either user has blocked the other. An unread notification
+// from the same actor in the same group is replaced, so reacting twice to a post
+// does not count the actor twice.
+func notify(n Notification) error {
+	if n.RecipientID <= 0 || n.RecipientID == n.ActorID {
+		return nil
+	}
+
+	blocked, err := blocks.IsBlocked(n.RecipientID, n.ActorID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return nil
+	}
+
+	groupKey := n.GroupKey()
+	_, err = database.DB.Exec(`
+		DELETE FROM notifications
+		WHERE user_id = ? AND group_key = ? AND actor_id = ? AND is_read = 0
+	`, n.RecipientID, groupKey, n.ActorID)
+	if err != nil {
+		return fmt.Errorf("failed to replace notification: %w", err)
+	}
+
+	result, err := database.DB.Exec(`
+		INSERT INTO notifications (user_id, actor_id, notification_type, object_type, object_id, group_key)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, n.RecipientID, n.ActorID, n.Type, n.ObjectType, n.ObjectID, groupKey)
+	if err != nil {
+		return fmt.Errorf("failed to insert notification: %w", err)
+	}
+	notificationID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get notification id: %w", err)
+	}
+
+	if DefaultDispatcher != nil {
+		DefaultDispatcher.Dispatch(notificationID, n)
+	}
+	return nil
+}
+
+// Send runs notify and logs any failure. Handlers call it in a goroutine so a
+// failed notification never fails the request that caused it.
+func Send(n Notification) {
+	if err := notify(n); err != nil {
+		log.Println("Failed to send notification due to the following error: ", err)
+	}
+}
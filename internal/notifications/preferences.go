@@ -0,0 +1,46 @@
+package notifications
+
+import (
+	"VoizyServer/internal/database"
+	"database/sql"
+	"fmt"
+)
+
+// preferenceColumns maps each notification type to the user_preferences column
+// that lets the user turn its delivery off.
+var preferenceColumns = map[string]string{
+	TypePostReaction:          "notify_post_reactions",
+	TypePostComment:           "notify_post_comments",
+	TypeCommentReply:          "notify_post_comments",
+	TypeFriendRequest:         "notify_friend_requests",
+	TypeFriendRequestAccepted: "notify_friend_requests",
+	TypeWallPost:              "notify_wall_posts",
+	TypePostMention:           "notify_mentions",
+	TypeCommentMention:        "notify_mentions",
+}
+
+// deliveryEnabled reports whether userID wants notifications of this type pushed
+// to their devices. Users without a preferences row get the defaults, which are on.
+func deliveryEnabled(userID int64, notificationType string) (bool, error) {
+	column, ok := preferenceColumns[notificationType]
+	if !ok {
+		column = "push_notifications"
+	}
+
+	var pushEnabled, typeEnabled bool
+	query := fmt.Sprintf(`
+		SELECT push_notifications, %s
+		FROM user_preferences
+		WHERE user_id = ?
+		LIMIT 1
+	`, column)
+	err := database.DB.QueryRow(query, userID).Scan(&pushEnabled, &typeEnabled)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return pushEnabled && typeEnabled, nil
+}
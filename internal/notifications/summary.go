@@ -0,0 +1,32 @@
+package notifications
+
+import "fmt"
+
+var summaryPhrases = map[string]string{
+	TypePostReaction:          "reacted to your post",
+	TypePostComment:           "commented on your post",
+	TypeCommentReply:          "replied to your comment",
+	TypeFriendRequest:         "sent you a friend request",
+	TypeFriendRequestAccepted: "accepted your friend request",
+	TypeWallPost:              "posted on your profile",
+	TypePostMention:           "mentioned you in a post",
+	TypeCommentMention:        "mentioned you in a comment",
+}
+
+// Summary describes a group of notifications from the most recent actor's side,
+// e.g. "Alice and 4 others reacted to your post".
+func Summary(notificationType, actorName string, actorCount int64) string {
+	phrase, ok := summaryPhrases[notificationType]
+	if !ok {
+		phrase = "interacted with you"
+	}
+
+	switch others := actorCount - 1; {
+	case others <= 0:
+		return fmt.Sprintf("%s %s", actorName, phrase)
+	case others == 1:
+		return fmt.Sprintf("%s and 1 other %s", actorName, phrase)
+	default:
+		return fmt.Sprintf("%s and %d others %s", actorName, others, phrase)
+	}
+}
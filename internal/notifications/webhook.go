@@ -0,0 +1,73 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebhookDeliverer POSTs each payload as JSON to URL. When Secret is set the body
+// is signed with HMAC-SHA256 and the hex digest sent in X-Voizy-Signature, so the
+// receiver can verify it came from us.
+type WebhookDeliverer struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func NewWebhookDeliverer(url, secret string) *WebhookDeliverer {
+	return &WebhookDeliverer{
+		URL:    url,
+		Secret: secret,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *WebhookDeliverer) Name() string {
+	return "webhook"
+}
+
+// Deliver treats any 2xx response as delivered. 4xx responses other than 408 and
+// 429 mean the receiver rejected the payload and are not retried.
+func (w *WebhookDeliverer) Deliver(ctx context.Context, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to marshal payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to build webhook request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Voizy-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package notifications
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type deadLettered struct {
+	deliverer string
+	payload   Payload
+	attempts  int
+	lastErr   error
+}
+
+// newTestDispatcher returns a Dispatcher that POSTs to url without waiting between
+// attempts and collects what it dead-letters instead of storing it.
+func newTestDispatcher(url string) (*Dispatcher, *[]deadLettered) {
+	var letters []deadLettered
+	d := NewDispatcher(NewWebhookDeliverer(url, "secret"))
+	d.BaseBackoff = time.Millisecond
+	d.MaxBackoff = time.Millisecond
+	d.deadLetter = func(deliverer string, p Payload, attempts int, lastErr error) error {
+		letters = append(letters, deadLettered{deliverer, p, attempts, lastErr})
+		return nil
+	}
+	return d, &letters
+}
+
+func TestWebhookRetriesServerErrorsUntilDelivered(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if got, want := r.Header.Get("X-Voizy-Signature"), hex.EncodeToString(mac.Sum(nil)); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		var p Payload
+		if err := json.Unmarshal(body, &p); err != nil || p.NotificationID != 7 {
+			t.Errorf("payload = %s (%v), want notification 7", body, err)
+		}
+
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d, letters := newTestDispatcher(server.URL)
+	if err := d.send(Payload{NotificationID: 7, UserID: 1}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("webhook called %d times, want 3", n)
+	}
+	if len(*letters) != 0 {
+		t.Errorf("dead-lettered %+v, want nothing", *letters)
+	}
+}
+
+func TestWebhookDeadLettersAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	d, letters := newTestDispatcher(server.URL)
+	d.MaxAttempts = 3
+	if err := d.send(Payload{NotificationID: 7, UserID: 1}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("webhook called %d times, want 3", n)
+	}
+	if len(*letters) != 1 {
+		t.Fatalf("dead-lettered %d notifications, want 1", len(*letters))
+	}
+	letter := (*letters)[0]
+	if letter.deliverer != "webhook" || letter.payload.NotificationID != 7 || letter.attempts != 3 {
+		t.Errorf("dead letter = %+v, want notification 7 via webhook after 3 attempts", letter)
+	}
+	if letter.lastErr == nil {
+		t.Error("dead letter has no error")
+	}
+}
+
+func TestWebhookDeadLettersRejectedPayloadWithoutRetrying(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	d, letters := newTestDispatcher(server.URL)
+	if err := d.send(Payload{NotificationID: 7, UserID: 1}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("webhook called %d times, want 1", n)
+	}
+	if len(*letters) != 1 || (*letters)[0].attempts != 1 {
+		t.Errorf("dead-lettered %+v, want one after 1 attempt", *letters)
+	}
+}
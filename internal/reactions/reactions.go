@@ -0,0 +1,99 @@
+// Package reactions knows the reaction types users can leave on posts and comments
+// and counts them per type. A user has at most one reaction on each post or
+// comment.
+package reactions
+
+import (
+	"VoizyServer/internal/database"
+	"fmt"
+	"strings"
+)
+
+const (
+	Like         = "like"
+	Love         = "love"
+	Laugh        = "laugh"
+	Congratulate = "congratulate"
+	Shocked      = "shocked"
+	Sad          = "sad"
+	Angry        = "angry"
+)
+
+// Types lists every reaction type in the order clients show them.
+var Types = []string{Like, Love, Laugh, Congratulate, Shocked, Sad, Angry}
+
+// Valid reports whether t is a known reaction type.
+func Valid(t string) bool {
+	for _, reactionType := range Types {
+		if t == reactionType {
+			return true
+		}
+	}
+	return false
+}
+
+// EmptyCounts returns a count of zero for every reaction type, so responses always
+// carry all of them.
+func EmptyCounts() map[string]int64 {
+	counts := make(map[string]int64, len(Types))
+	for _, reactionType := range Types {
+		counts[reactionType] = 0
+	}
+	return counts
+}
+
+// PostCounts returns the per-type reaction counts of a page of posts, keyed by
+// post ID. Every post in postIDs has an entry, even when nobody reacted to it.
+func PostCounts(postIDs []int64) (map[int64]map[string]int64, error) {
+	return loadCounts("post_reactions", "post_id", postIDs)
+}
+
+// CommentCounts returns the per-type reaction counts of a page of comments, keyed
+// by comment ID. Every comment in commentIDs has an entry.
+func CommentCounts(commentIDs []int64) (map[int64]map[string]int64, error) {
+	return loadCounts("comment_reactions", "comment_id", commentIDs)
+}
+
+func loadCounts(table, keyColumn string, ids []int64) (map[int64]map[string]int64, error) {
+	counts := make(map[int64]map[string]int64, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+		counts[id] = EmptyCounts()
+	}
+
+	query := `
+		SELECT ` + keyColumn + `, reaction_type, COUNT(*)
+		FROM ` + table + `
+		WHERE ` + keyColumn + ` IN (` + strings.Join(placeholders, ",") + `)
+		GROUP BY ` + keyColumn + `, reaction_type
+	`
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id           int64
+			reactionType string
+			count        int64
+		)
+		if err := rows.Scan(&id, &reactionType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction count: %w", err)
+		}
+		counts[id][reactionType] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over reaction counts: %w", err)
+	}
+
+	return counts, nil
+}
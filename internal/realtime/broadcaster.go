@@ -0,0 +1,90 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Broadcaster moves envelopes between API nodes. Publish hands an envelope
+// to every node (including this one), and Subscribe calls deliver for each
+// envelope that arrives until ctx is cancelled.
+type Broadcaster interface {
+	Publish(ctx context.Context, env Envelope) error
+	Subscribe(ctx context.Context, deliver func(Envelope)) error
+}
+
+// MemoryBroadcaster only reaches connections on the current node.
+type MemoryBroadcaster struct {
+	deliver func(Envelope)
+}
+
+func NewMemoryBroadcaster() *MemoryBroadcaster {
+	return &MemoryBroadcaster{}
+}
+
+func (b *MemoryBroadcaster) Publish(ctx context.Context, env Envelope) error {
+	if b.deliver == nil {
+		return fmt.Errorf("memory broadcaster has no subscriber")
+	}
+	b.deliver(env)
+	return nil
+}
+
+func (b *MemoryBroadcaster) Subscribe(ctx context.Context, deliver func(Envelope)) error {
+	b.deliver = deliver
+	return nil
+}
+
+// RedisBroadcaster fans envelopes out over a Redis pub/sub channel so every
+// node running a Hub receives them.
+type RedisBroadcaster struct {
+	rdb     *redis.Client
+	channel string
+}
+
+func NewRedisBroadcaster(rdb *redis.Client, channel string) *RedisBroadcaster {
+	return &RedisBroadcaster{rdb: rdb, channel: channel}
+}
+
+func (b *RedisBroadcaster) Publish(ctx context.Context, env Envelope) error {
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+	return b.rdb.Publish(ctx, b.channel, payload).Err()
+}
+
+func (b *RedisBroadcaster) Subscribe(ctx context.Context, deliver func(Envelope)) error {
+	sub := b.rdb.Subscribe(ctx, b.channel)
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return fmt.Errorf("failed to subscribe to %s: %w", b.channel, err)
+	}
+
+	go func() {
+		defer sub.Close()
+		ch := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				var env Envelope
+				if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+					log.Println("Failed to unmarshal realtime envelope: ", err)
+					continue
+				}
+				deliver(env)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package realtime
+
+import (
+	"VoizyServer/internal/middleware"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 4096
+	sendBufferSize = 64
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Clients are native apps authenticated by JWT + API key headers, so the
+	// Origin header carries no useful information here.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type client struct {
+	hub    *Hub
+	conn   *websocket.Conn
+	userID int64
+	send   chan []byte
+}
+
+// ServeWS upgrades an authenticated request to a WebSocket. It must be
+// wrapped in middleware.CombinedAuthMiddleware so the user id is in context.
+func ServeWS(w http.ResponseWriter, r *http.Request) {
+	if DefaultHub == nil {
+		http.Error(w, "Realtime gateway is not available.", http.StatusServiceUnavailable)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing authenticated user.", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("Failed to upgrade websocket connection: ", err)
+		return
+	}
+
+	c := &client{
+		hub:    DefaultHub,
+		conn:   conn,
+		userID: userID,
+		send:   make(chan []byte, sendBufferSize),
+	}
+	c.hub.register(c)
+
+	go c.writePump()
+	go c.readPump()
+}
+
+func (c *client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Println("Websocket read error: ", err)
+			}
+			return
+		}
+
+		var in inboundEvent
+		if err := json.Unmarshal(raw, &in); err != nil {
+			log.Println("Failed to unmarshal inbound websocket event: ", err)
+			continue
+		}
+		c.handleInbound(in)
+	}
+}
+
+// handleInbound relays ephemeral client events. Only typing indicators are
+// accepted; everything persistent goes through the REST endpoints.
+func (c *client) handleInbound(in inboundEvent) {
+	switch in.Type {
+	case EventTyping:
+		ok, err := isConversationMember(in.ConversationID, c.userID)
+		if err != nil {
+			log.Println("Failed to check conversation membership for typing event: ", err)
+			return
+		}
+		if !ok {
+			return
+		}
+		PublishToConversation(in.ConversationID, c.userID, true, EventTyping, TypingData{IsTyping: in.IsTyping})
+	default:
+		log.Println("Ignoring unsupported inbound websocket event type: ", in.Type)
+	}
+}
+
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case payload, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package realtime
+
+import (
+	"VoizyServer/internal/database"
+	"fmt"
+)
+
+func conversationMemberIDs(conversationID int64) ([]int64, error) {
+	query := `
+		SELECT user_id
+		FROM conversation_members
+		WHERE conversation_id = ?
+	`
+	rows, err := database.DB.Query(query, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation members: %w", err)
+	}
+	defer rows.Close()
+
+	var memberIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return memberIDs, nil
+}
+
+func isConversationMember(conversationID, userID int64) (bool, error) {
+	var count int64
+	query := `
+		SELECT COUNT(*)
+		FROM conversation_members
+		WHERE conversation_id = ? AND user_id = ?
+	`
+	if err := database.DB.QueryRow(query, conversationID, userID).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,38 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventMessageNew      = "message.new"
+	EventMessageReaction = "message.reaction"
+	EventMessageRead     = "message.read"
+	EventTyping          = "typing"
+)
+
+type Event struct {
+	Type           string          `json:"type"`
+	ConversationID int64           `json:"conversationID,omitempty"`
+	UserID         int64           `json:"userID,omitempty"`
+	Data           json.RawMessage `json:"data,omitempty"`
+	SentAt         time.Time       `json:"sentAt"`
+}
+
+// Envelope is what travels through a Broadcaster: an event plus the users
+// whose connections should receive it, wherever those connections live.
+type Envelope struct {
+	UserIDs []int64 `json:"userIDs"`
+	Event   Event   `json:"event"`
+}
+
+type TypingData struct {
+	IsTyping bool `json:"isTyping"`
+}
+
+type inboundEvent struct {
+	Type           string `json:"type"`
+	ConversationID int64  `json:"conversationID"`
+	IsTyping       bool   `json:"isTyping"`
+}
@@ -0,0 +1,143 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type Hub struct {
+	mu          sync.RWMutex
+	clients     map[int64]map[*client]struct{}
+	broadcaster Broadcaster
+}
+
+var DefaultHub *Hub
+
+func NewHub(b Broadcaster) *Hub {
+	return &Hub{
+		clients:     make(map[int64]map[*client]struct{}),
+		broadcaster: b,
+	}
+}
+
+func InitHub(b Broadcaster) error {
+	hub := NewHub(b)
+	if err := b.Subscribe(context.Background(), hub.deliverLocal); err != nil {
+		return fmt.Errorf("failed to subscribe hub to broadcaster: %w", err)
+	}
+	DefaultHub = hub
+	return nil
+}
+
+func NewEvent(eventType string, conversationID, userID int64, data interface{}) (Event, error) {
+	event := Event{
+		Type:           eventType,
+		ConversationID: conversationID,
+		UserID:         userID,
+		SentAt:         time.Now(),
+	}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return Event{}, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+		event.Data = raw
+	}
+	return event, nil
+}
+
+// Publish sends an event to every connection the given users have open on
+// any node. It is a no-op when the hub has not been initialised.
+func Publish(userIDs []int64, event Event) {
+	if DefaultHub == nil || len(userIDs) == 0 {
+		return
+	}
+	if err := DefaultHub.broadcaster.Publish(context.Background(), Envelope{UserIDs: userIDs, Event: event}); err != nil {
+		log.Println("Failed to publish realtime event: ", err)
+	}
+}
+
+// PublishToConversation looks up the members of a conversation and sends
+// the event to all of them. skipActor leaves out the actor's own connections,
+// which is what ephemeral events like typing want.
+func PublishToConversation(conversationID, actorID int64, skipActor bool, eventType string, data interface{}) {
+	if DefaultHub == nil {
+		return
+	}
+
+	memberIDs, err := conversationMemberIDs(conversationID)
+	if err != nil {
+		log.Println("Failed to load conversation members for realtime event: ", err)
+		return
+	}
+
+	recipients := make([]int64, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		if !skipActor || id != actorID {
+			recipients = append(recipients, id)
+		}
+	}
+
+	event, err := NewEvent(eventType, conversationID, actorID, data)
+	if err != nil {
+		log.Println("Failed to build realtime event: ", err)
+		return
+	}
+	Publish(recipients, event)
+}
+
+func (h *Hub) register(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.clients[c.userID] == nil {
+		h.clients[c.userID] = make(map[*client]struct{})
+	}
+	h.clients[c.userID][c] = struct{}{}
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	conns, ok := h.clients[c.userID]
+	if !ok {
+		return
+	}
+	if _, ok := conns[c]; ok {
+		delete(conns, c)
+		close(c.send)
+	}
+	if len(conns) == 0 {
+		delete(h.clients, c.userID)
+	}
+}
+
+func (h *Hub) deliverLocal(env Envelope) {
+	payload, err := json.Marshal(env.Event)
+	if err != nil {
+		log.Println("Failed to marshal realtime event: ", err)
+		return
+	}
+
+	h.mu.RLock()
+	var slow []*client
+	for _, userID := range env.UserIDs {
+		for c := range h.clients[userID] {
+			select {
+			case c.send <- payload:
+			default:
+				slow = append(slow, c)
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	// A client whose buffer is full is too far behind to catch up; drop it
+	// and let it reconnect and re-sync over the REST endpoints.
+	for _, c := range slow {
+		h.unregister(c)
+	}
+}
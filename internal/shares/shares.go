@@ -0,0 +1,233 @@
+// Package shares embeds the original post in the shares that appear in feeds. A
+// share carries a compact snapshot of its original when the viewer may see it, and
+// a tombstone otherwise, so clients never need a second request and never learn
+// whether a hidden original was deleted or is just not meant for them.
+package shares
+
+import (
+	"VoizyServer/internal/audience"
+	"VoizyServer/internal/blocks"
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/imaging"
+	models "VoizyServer/internal/models/posts"
+	"VoizyServer/internal/util"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Originals returns the original of every share among postIDs as viewerID sees it,
+// keyed by the share's post ID. Posts that are not shares have no entry.
+func Originals(postIDs []int64, viewerID int64) (map[int64]*models.SharedPost, error) {
+	originals := make(map[int64]*models.SharedPost)
+	if len(postIDs) == 0 {
+		return originals, nil
+	}
+
+	notBlocked, blockArgs := blocks.Exclude("o.user_id", viewerID)
+	// Visible also hides originals posted in a group the viewer cannot read, so a
+	// member sharing a private group post does not show it to the rest of their
+	// audience.
+	visible, visibleArgs := audience.Visible("o", viewerID)
+	placeholders := make([]string, len(postIDs))
+	args := make([]interface{}, 0, len(blockArgs)+len(visibleArgs)+len(postIDs))
+	args = append(args, blockArgs...)
+	args = append(args, visibleArgs...)
+	for i, id := range postIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := `
+		SELECT
+			sp.post_id,
+			COALESCE(o.post_id IS NOT NULL
+				AND o.deleted_at IS NULL
+				AND o.status = 'published'
+				AND ` + notBlocked + `
+				AND ` + visible + `, 0) AS available,
+			o.post_id,
+			o.user_id,
+			u.username,
+			up.first_name,
+			up.last_name,
+			up.preferred_name,
+			ui.image_url,
+			o.content_text,
+			o.created_at,
+			o.edited_at,
+			o.is_poll,
+			o.poll_question
+		FROM posts sp
+		LEFT JOIN posts o
+			ON o.post_id = sp.original_post_id
+		LEFT JOIN users u
+			ON u.user_id = o.user_id
+		LEFT JOIN user_profiles up
+			ON up.user_id = o.user_id
+		LEFT JOIN user_images ui
+			ON ui.user_id = o.user_id
+			AND ui.is_profile_pic = 1
+		WHERE sp.post_id IN (` + strings.Join(placeholders, ",") + `)
+			AND sp.is_share = 1
+	`
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared posts: %w", err)
+	}
+	defer rows.Close()
+
+	byOriginal := make(map[int64][]*models.SharedPost)
+	for rows.Next() {
+		var (
+			sharePostID    int64
+			available      bool
+			originalPostID sql.NullInt64
+			userID         sql.NullInt64
+			username       sql.NullString
+			firstName      sql.NullString
+			lastName       sql.NullString
+			preferredName  sql.NullString
+			profilePicURL  sql.NullString
+			contentText    sql.NullString
+			createdAt      sql.NullTime
+			editedAt       sql.NullTime
+			isPoll         sql.NullBool
+			pollQuestion   sql.NullString
+		)
+		err := rows.Scan(
+			&sharePostID,
+			&available,
+			&originalPostID,
+			&userID,
+			&username,
+			&firstName,
+			&lastName,
+			&preferredName,
+			&profilePicURL,
+			&contentText,
+			&createdAt,
+			&editedAt,
+			&isPoll,
+			&pollQuestion,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shared post: %w", err)
+		}
+
+		if !available {
+			originals[sharePostID] = &models.SharedPost{Available: false}
+			continue
+		}
+		original := &models.SharedPost{
+			Available:     true,
+			PostID:        util.SqlNullInt64ToPtr(originalPostID),
+			UserID:        util.SqlNullInt64ToPtr(userID),
+			Username:      util.SqlNullStringToPtr(username),
+			FirstName:     util.SqlNullStringToPtr(firstName),
+			LastName:      util.SqlNullStringToPtr(lastName),
+			PreferredName: util.SqlNullStringToPtr(preferredName),
+			ProfilePicURL: util.SqlNullStringToPtr(profilePicURL),
+			ContentText:   util.SqlNullStringToPtr(contentText),
+			CreatedAt:     util.SqlNullTimeToPtr(createdAt),
+			Edited:        editedAt.Valid,
+			IsPoll:        isPoll.Valid && isPoll.Bool,
+			PollQuestion:  util.SqlNullStringToPtr(pollQuestion),
+		}
+		originals[sharePostID] = original
+		byOriginal[originalPostID.Int64] = append(byOriginal[originalPostID.Int64], original)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over shared posts: %w", err)
+	}
+
+	if err := loadMedia(byOriginal); err != nil {
+		return nil, err
+	}
+	if err := loadImages(byOriginal); err != nil {
+		return nil, err
+	}
+
+	return originals, nil
+}
+
+// loadMedia fills in the images, videos and audio of the visible originals.
+func loadMedia(byOriginal map[int64][]*models.SharedPost) error {
+	if len(byOriginal) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(byOriginal))
+	args := make([]interface{}, 0, len(byOriginal))
+	for id := range byOriginal {
+		placeholders = append(placeholders, "?")
+		args = append(args, id)
+	}
+
+	rows, err := database.DB.Query(`
+		SELECT post_id, media_url, media_type
+		FROM post_media
+		WHERE post_id IN (`+strings.Join(placeholders, ",")+`)
+		ORDER BY media_id ASC
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to get shared post media: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID    int64
+			url       string
+			mediaType string
+		)
+		if err := rows.Scan(&postID, &url, &mediaType); err != nil {
+			return fmt.Errorf("failed to scan shared post media: %w", err)
+		}
+		for _, original := range byOriginal[postID] {
+			switch mediaType {
+			case "video":
+				original.Videos = append(original.Videos, url)
+			case "audio":
+				original.Audio = append(original.Audio, url)
+			default:
+				original.Images = append(original.Images, url)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over shared post media: %w", err)
+	}
+
+	return nil
+}
+
+// loadImages fills in the srcsets of the visible originals' images and their
+// authors' profile pictures.
+func loadImages(byOriginal map[int64][]*models.SharedPost) error {
+	var urls []string
+	for _, originals := range byOriginal {
+		original := originals[0]
+		urls = append(urls, original.Images...)
+		if original.ProfilePicURL != nil {
+			urls = append(urls, *original.ProfilePicURL)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	images, err := imaging.ForURLs(urls)
+	if err != nil {
+		return err
+	}
+	for _, originals := range byOriginal {
+		for _, original := range originals {
+			original.ProfilePicVariants = imaging.Variants(images, original.ProfilePicURL)
+			for _, url := range original.Images {
+				original.ImageSources = append(original.ImageSources, images[url])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,574 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalRoutePrefix is where a LocalStore's handler must be mounted.
+const LocalRoutePrefix = "/storage/local/"
+
+// maxLocalUploadBytes caps a single upload to a LocalStore.
+const maxLocalUploadBytes = 100 << 20
+
+// tempFilePrefix marks uploads still being written, which List skips.
+const tempFilePrefix = ".upload-"
+
+// multipartDir holds the parts of unfinished multipart uploads, one directory per
+// upload. Its name starts with tempFilePrefix, so no key reaches into it.
+const multipartDir = tempFilePrefix + "multipart"
+
+// maxParts is the most parts a multipart upload can have, as with S3.
+const maxParts = 10000
+
+var (
+	errInvalidKey   = errors.New("invalid object key")
+	uploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+)
+
+// LocalStore keeps objects as files under a directory and serves them from the API
+// server at baseURL + LocalRoutePrefix. Uploads need a URL signed with the store's
+// secret, as they would with S3. Downloads do not, just as objects in the bucket
+// are public, but signed download URLs are accepted too.
+type LocalStore struct {
+	dir     string
+	baseURL string
+	secret  []byte
+}
+
+// NewLocalStore keeps objects under dir, creating it if needed. baseURL is the
+// scheme and host clients reach the API server on, e.g. "https://localhost".
+func NewLocalStore(dir, baseURL string, secret []byte) (*LocalStore, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("a local store needs a signing secret")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  secret,
+	}, nil
+}
+
+// cleanKey rejects keys that are empty or would escape the store's directory.
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", errInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", errInvalidKey
+	}
+	for _, part := range strings.Split(cleaned, "/") {
+		if strings.HasPrefix(part, tempFilePrefix) {
+			return "", errInvalidKey
+		}
+	}
+	return cleaned, nil
+}
+
+func (s *LocalStore) filePath(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalStore) sign(method, key string, expires int64, contentType string, contentLength int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%s\n%d", method, key, expires, contentType, contentLength)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStore) signedURL(method, key string, opts PutOptions) (string, error) {
+	if _, err := cleanKey(key); err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().Add(opts.Expires).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	if opts.ContentType != "" {
+		query.Set("contentType", opts.ContentType)
+	}
+	if opts.ContentLength > 0 {
+		query.Set("contentLength", strconv.FormatInt(opts.ContentLength, 10))
+	}
+	query.Set("signature", s.sign(method, key, expiresAt, opts.ContentType, opts.ContentLength))
+	return s.URL(key) + "?" + query.Encode(), nil
+}
+
+// checkSignature reports whether the request carries an unexpired signature for
+// method and key, and returns the upload constraints that were signed with it.
+func (s *LocalStore) checkSignature(r *http.Request, method, key string) (PutOptions, bool) {
+	query := r.URL.Query()
+	expiresAt, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return PutOptions{}, false
+	}
+	opts := PutOptions{ContentType: query.Get("contentType")}
+	if query.Has("contentLength") {
+		opts.ContentLength, err = strconv.ParseInt(query.Get("contentLength"), 10, 64)
+		if err != nil {
+			return PutOptions{}, false
+		}
+	}
+	signature, err := hex.DecodeString(query.Get("signature"))
+	if err != nil {
+		return PutOptions{}, false
+	}
+	expected, _ := hex.DecodeString(s.sign(method, key, expiresAt, opts.ContentType, opts.ContentLength))
+	return opts, hmac.Equal(signature, expected)
+}
+
+func (s *LocalStore) PresignPut(ctx context.Context, key string, opts PutOptions) (string, error) {
+	return s.signedURL(http.MethodPut, key, opts)
+}
+
+func (s *LocalStore) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return s.signedURL(http.MethodGet, key, PutOptions{Expires: expires})
+}
+
+func (s *LocalStore) Head(ctx context.Context, key string) (ObjectInfo, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	f, err := os.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ObjectInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to open object %s: %w", key, err)
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat object %s: %w", key, err)
+	}
+	if stat.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+	// Files on disk carry no content type, so it is sniffed from the first bytes.
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(f, head)
+	return ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  http.DetectContentType(head[:n]),
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object %s: %w", key, err)
+	}
+	return f, nil
+}
+
+func (s *LocalStore) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	body, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	f := body.(*os.File)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to seek in object %s: %w", key, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(f, length), f}, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := s.write(filePath, body); err != nil {
+		return fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	err := filepath.WalkDir(s.dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), tempFilePrefix) {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), tempFilePrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{
+			Key:          key,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+	}
+	return objects, nil
+}
+
+// partsDir returns the directory holding the parts of uploadID, which has to be
+// an upload of key.
+func (s *LocalStore) partsDir(key, uploadID string) (string, error) {
+	if !uploadIDPattern.MatchString(uploadID) {
+		return "", ErrNoSuchUpload
+	}
+	dir := filepath.Join(s.dir, multipartDir, uploadID)
+	owner, err := os.ReadFile(filepath.Join(dir, "key"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNoSuchUpload
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read multipart upload %s: %w", uploadID, err)
+	}
+	if string(owner) != key {
+		return "", ErrNoSuchUpload
+	}
+	return dir, nil
+}
+
+// partMethod is what the signature of a part upload URL is made over in place of
+// the plain method, so it is only good for that one part.
+func partMethod(uploadID string, partNumber int32) string {
+	return fmt.Sprintf("%s part %s %d", http.MethodPut, uploadID, partNumber)
+}
+
+func (s *LocalStore) CreateMultipartUpload(ctx context.Context, key string, opts PutOptions) (string, error) {
+	if _, err := cleanKey(key); err != nil {
+		return "", err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate upload ID: %w", err)
+	}
+	uploadID := hex.EncodeToString(id)
+	dir := filepath.Join(s.dir, multipartDir, uploadID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create multipart upload %s: %w", key, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "key"), []byte(key), 0o644); err != nil {
+		return "", fmt.Errorf("failed to create multipart upload %s: %w", key, err)
+	}
+	return uploadID, nil
+}
+
+func (s *LocalStore) PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, expires time.Duration) (string, error) {
+	if _, err := s.partsDir(key, uploadID); err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().Add(expires).Unix()
+	query := url.Values{}
+	query.Set("uploadId", uploadID)
+	query.Set("partNumber", strconv.Itoa(int(partNumber)))
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	query.Set("signature", s.sign(partMethod(uploadID, partNumber), key, expiresAt, "", 0))
+	return s.URL(key) + "?" + query.Encode(), nil
+}
+
+// CompleteMultipartUpload checks each part against its ETag, as S3 does, so
+// clients work the same against either store.
+func (s *LocalStore) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart) error {
+	dir, err := s.partsDir(key, uploadID)
+	if err != nil {
+		return err
+	}
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return ErrInvalidPart
+	}
+
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	readers := make([]io.Reader, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return ErrInvalidPart
+		}
+		f, err := os.Open(filepath.Join(dir, strconv.Itoa(int(part.PartNumber))))
+		if err != nil {
+			return ErrInvalidPart
+		}
+		files = append(files, f)
+		hash := md5.New()
+		if _, err := io.Copy(hash, f); err != nil {
+			return fmt.Errorf("failed to read part %d of %s: %w", part.PartNumber, key, err)
+		}
+		if hex.EncodeToString(hash.Sum(nil)) != strings.Trim(part.ETag, `"`) {
+			return ErrInvalidPart
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to read part %d of %s: %w", part.PartNumber, key, err)
+		}
+		readers = append(readers, f)
+	}
+
+	err = s.create(filePath, io.MultiReader(readers...))
+	if errors.Is(err, fs.ErrExist) {
+		return ErrExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload %s: %w", key, err)
+	}
+	return os.RemoveAll(dir)
+}
+
+func (s *LocalStore) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	dir, err := s.partsDir(key, uploadID)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to abort multipart upload %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.baseURL + LocalRoutePrefix + key
+}
+
+func (s *LocalStore) KeyFromURL(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	base, err := url.Parse(s.baseURL)
+	if err != nil || u.Scheme != base.Scheme || u.Host != base.Host {
+		return "", false
+	}
+	key, ok := strings.CutPrefix(u.Path, LocalRoutePrefix)
+	if !ok {
+		return "", false
+	}
+	if _, err := cleanKey(key); err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// ServeHTTP serves the signed upload and download URLs handed out by the store.
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, LocalRoutePrefix)
+	filePath, err := s.filePath(key)
+	if err != nil {
+		http.Error(w, "Invalid object key.", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if r.URL.Query().Has("signature") {
+			if _, ok := s.checkSignature(r, http.MethodGet, key); !ok {
+				http.Error(w, "Invalid or expired signature.", http.StatusForbidden)
+				return
+			}
+		}
+		f, err := os.Open(filePath)
+		if err != nil {
+			http.Error(w, "Object not found.", http.StatusNotFound)
+			return
+		}
+		defer f.Close()
+		stat, err := f.Stat()
+		if err != nil || stat.IsDir() {
+			http.Error(w, "Object not found.", http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, r, path.Base(key), stat.ModTime(), f)
+	case http.MethodPut:
+		if r.URL.Query().Has("uploadId") {
+			s.servePart(w, r, key)
+			return
+		}
+		opts, ok := s.checkSignature(r, http.MethodPut, key)
+		if !ok {
+			http.Error(w, "Invalid or expired signature.", http.StatusForbidden)
+			return
+		}
+		if r.Header.Get(CreateOnlyHeader) != CreateOnlyValue {
+			http.Error(w, fmt.Sprintf("Uploads must be sent with '%s: %s'.", CreateOnlyHeader, CreateOnlyValue), http.StatusForbidden)
+			return
+		}
+		if opts.ContentType != "" && r.Header.Get("Content-Type") != opts.ContentType {
+			http.Error(w, "Content-Type does not match the signed upload.", http.StatusForbidden)
+			return
+		}
+		maxBytes := int64(maxLocalUploadBytes)
+		if opts.ContentLength > 0 {
+			if r.ContentLength != opts.ContentLength {
+				http.Error(w, "Content-Length does not match the signed upload.", http.StatusForbidden)
+				return
+			}
+			maxBytes = opts.ContentLength
+		}
+		if err := s.create(filePath, http.MaxBytesReader(w, r.Body, maxBytes)); err != nil {
+			if errors.Is(err, fs.ErrExist) {
+				http.Error(w, "An object already exists under this key.", http.StatusPreconditionFailed)
+				return
+			}
+			log.Println("Failed to store upload due to the following error: ", err)
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "Upload is too large.", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "Failed to store upload.", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "Invalid request method.", http.StatusMethodNotAllowed)
+	}
+}
+
+// servePart stores one part of a multipart upload and answers with its ETag.
+func (s *LocalStore) servePart(w http.ResponseWriter, r *http.Request, key string) {
+	query := r.URL.Query()
+	uploadID := query.Get("uploadId")
+	partNumber, err := strconv.Atoi(query.Get("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > maxParts {
+		http.Error(w, "Invalid part number.", http.StatusBadRequest)
+		return
+	}
+	if _, ok := s.checkSignature(r, partMethod(uploadID, int32(partNumber)), key); !ok {
+		http.Error(w, "Invalid or expired signature.", http.StatusForbidden)
+		return
+	}
+	dir, err := s.partsDir(key, uploadID)
+	if err != nil {
+		http.Error(w, "Upload not found.", http.StatusNotFound)
+		return
+	}
+
+	hash := md5.New()
+	body := io.TeeReader(http.MaxBytesReader(w, r.Body, maxLocalUploadBytes), hash)
+	if err := s.write(filepath.Join(dir, strconv.Itoa(partNumber)), body); err != nil {
+		log.Println("Failed to store upload part due to the following error: ", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Upload part is too large.", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Failed to store upload part.", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("ETag", `"`+hex.EncodeToString(hash.Sum(nil))+`"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+// write stores body at filePath. It is written to a temporary file first, so a
+// failed or partial upload never replaces what was there.
+func (s *LocalStore) write(filePath string, body io.Reader) error {
+	tmp, err := writeTemp(filePath, body)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	return os.Rename(tmp, filePath)
+}
+
+// create is write for uploads, which never replace an object: it fails with
+// fs.ErrExist if there already is one at filePath.
+func (s *LocalStore) create(filePath string, body io.Reader) error {
+	tmp, err := writeTemp(filePath, body)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	// Unlike a rename, a hard link refuses to replace an existing file, and does so
+	// atomically.
+	return os.Link(tmp, filePath)
+}
+
+// writeTemp stores body in a temporary file next to filePath and returns its path.
+func writeTemp(filePath string, body io.Reader) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), tempFilePrefix+"*")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
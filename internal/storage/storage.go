@@ -0,0 +1,135 @@
+// Package storage keeps the files users upload. Clients never send file bodies
+// through the API: they are handed short-lived signed URLs to upload to and the
+// permanent URL the file will be served from, which is what posts and profiles
+// store. S3Store keeps files in an S3 bucket; LocalStore keeps them on disk and
+// serves the signed URLs from the API server itself, for development and tests.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned by Head and Get when there is no object under the key.
+	ErrNotFound = errors.New("object not found")
+	// ErrNoSuchUpload is returned for a multipart upload that does not exist, or
+	// no longer does because it was completed or aborted.
+	ErrNoSuchUpload = errors.New("no such multipart upload")
+	// ErrInvalidPart is returned by CompleteMultipartUpload when a part is
+	// missing, out of order, too small or does not match its ETag.
+	ErrInvalidPart = errors.New("multipart upload part is missing or does not match its ETag")
+	// ErrExists is returned by CompleteMultipartUpload when there already is an
+	// object under the key.
+	ErrExists = errors.New("an object already exists under the key")
+)
+
+// Uploads through a presigned URL only ever create an object; once one exists
+// under the key, they are refused. That way an upload that has been checked
+// cannot be swapped for another file while its URL is still valid. Clients have
+// to send CreateOnlyHeader with the value CreateOnlyValue to show they expect
+// this.
+const (
+	CreateOnlyHeader = "If-None-Match"
+	CreateOnlyValue  = "*"
+)
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// PutOptions constrain what a presigned upload URL accepts. An empty ContentType
+// or a zero ContentLength leaves that part unconstrained.
+type PutOptions struct {
+	Expires       time.Duration
+	ContentType   string
+	ContentLength int64
+}
+
+// CompletedPart is a part of a multipart upload as the client uploaded it. ETag
+// is the value of the ETag header the part's upload was answered with.
+type CompletedPart struct {
+	PartNumber int32
+	ETag       string
+}
+
+// Store is where uploaded files live. Keys are slash-separated paths such as
+// "12/photos/avatar.jpg".
+type Store interface {
+	// PresignPut returns a URL the client can PUT the object's body to until it
+	// expires. The upload is refused unless it has the content type and length
+	// in opts and CreateOnlyHeader, or if the object already exists.
+	PresignPut(ctx context.Context, key string, opts PutOptions) (string, error)
+	// PresignGet returns a URL the object can be downloaded from until it expires.
+	PresignGet(ctx context.Context, key string, expires time.Duration) (string, error)
+	Head(ctx context.Context, key string) (ObjectInfo, error)
+	// Put stores body under key, replacing any object already there. It is for
+	// files the server makes itself; clients upload through PresignPut.
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// Get opens the object's body. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetRange opens length bytes of the object's body from offset on.
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// List returns every object whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// CreateMultipartUpload starts an upload of key in parts, for files too large
+	// to upload in one request, and returns its ID. Only opts.ContentType is used.
+	CreateMultipartUpload(ctx context.Context, key string, opts PutOptions) (string, error)
+	// PresignUploadPart returns a URL the client can PUT one part to until it
+	// expires. Parts are numbered from 1.
+	PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, expires time.Duration) (string, error)
+	// CompleteMultipartUpload joins the parts, in order, into the object. It
+	// returns ErrExists rather than replace an object that is already there.
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart) error
+	// AbortMultipartUpload discards an unfinished multipart upload and its parts.
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+	// URL is the permanent URL the object is served from.
+	URL(key string) string
+	// KeyFromURL is the inverse of URL. It reports false for URLs that do not
+	// point into this store.
+	KeyFromURL(rawURL string) (string, bool)
+}
+
+// DefaultStore holds every upload. It is set once at startup by Init.
+var DefaultStore Store
+
+// ReaderAt reads the object under key with ranged gets, so parts of a large
+// object can be read without downloading all of it.
+func ReaderAt(ctx context.Context, s Store, key string) io.ReaderAt {
+	return objectReaderAt{ctx: ctx, store: s, key: key}
+}
+
+type objectReaderAt struct {
+	ctx   context.Context
+	store Store
+	key   string
+}
+
+func (r objectReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	body, err := r.store.GetRange(r.ctx, r.key, off, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	n, err := io.ReadFull(body, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Init makes s the store used for every upload from now on.
+func Init(s Store) {
+	DefaultStore = s
+}
@@ -0,0 +1,181 @@
+package uploads
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// partExpiry is how long a presigned part URL stays valid. Parts are large, so
+	// it is longer than presignExpiry.
+	partExpiry = time.Hour
+	// PartSize is the size clients should split files into. Every part but the
+	// last has to be at least 5 MiB for S3 to accept it.
+	PartSize = 8 << 20
+	// maxPartNumber is the highest part number S3 accepts.
+	maxPartNumber = 10000
+	// maxPartsPerRequest caps how many part URLs are presigned at once.
+	maxPartsPerRequest = 100
+)
+
+// Multipart is an upload the client may now send in parts of PartSize bytes. It
+// asks for part URLs with PresignParts and finishes with CompleteMultipart.
+type Multipart struct {
+	Key         string
+	UploadID    string
+	FinalURL    string
+	ContentType string
+	MaxSize     int64
+	PartSize    int64
+}
+
+// PresignedPart is where one part of a multipart upload is PUT to. The ETag
+// header of the answer has to be passed back to CompleteMultipart.
+type PresignedPart struct {
+	PartNumber   int32
+	PresignedURL string
+}
+
+// InitiateMultipart is Presign for files too large to upload in one request. The
+// upload is recorded as pending just the same, along with its multipart upload ID.
+func InitiateMultipart(ctx context.Context, userID int64, purpose Purpose, dir, fileName, contentType string, size int64) (Multipart, error) {
+	maxSize, err := checkPolicy(purpose, fileName, contentType, size)
+	if err != nil {
+		return Multipart{}, err
+	}
+	key := dir + "/" + fileName
+	if err := recordPending(ctx, userID, purpose, key, contentType, size); err != nil {
+		return Multipart{}, err
+	}
+
+	store := storage.DefaultStore
+	uploadID, err := store.CreateMultipartUpload(ctx, key, storage.PutOptions{ContentType: contentType})
+	if err != nil {
+		return Multipart{}, err
+	}
+	_, err = database.DB.Exec(`
+		UPDATE uploads
+		SET multipart_upload_id = ?
+		WHERE object_key = ? AND status = 'pending'
+	`, uploadID, key)
+	if err != nil {
+		return Multipart{}, fmt.Errorf("failed to record multipart upload: %w", err)
+	}
+
+	return Multipart{
+		Key:         key,
+		UploadID:    uploadID,
+		FinalURL:    store.URL(key),
+		ContentType: contentType,
+		MaxSize:     maxSize,
+		PartSize:    PartSize,
+	}, nil
+}
+
+// PresignParts returns a URL for each of partNumbers of a pending multipart
+// upload of userID.
+func PresignParts(ctx context.Context, userID int64, key, uploadID string, partNumbers []int32) ([]PresignedPart, error) {
+	if len(partNumbers) == 0 || len(partNumbers) > maxPartsPerRequest {
+		return nil, ErrInvalidPart
+	}
+	if err := checkMultipart(userID, key, uploadID); err != nil {
+		return nil, err
+	}
+
+	parts := make([]PresignedPart, 0, len(partNumbers))
+	for _, partNumber := range partNumbers {
+		if partNumber < 1 || partNumber > maxPartNumber {
+			return nil, ErrInvalidPart
+		}
+		presignedURL, err := storage.DefaultStore.PresignUploadPart(ctx, key, uploadID, partNumber, partExpiry)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, PresignedPart{PartNumber: partNumber, PresignedURL: presignedURL})
+	}
+	return parts, nil
+}
+
+// CompleteMultipart joins the uploaded parts into the object and confirms it, so
+// it goes through the same checks as a file uploaded in one piece.
+func CompleteMultipart(ctx context.Context, userID int64, key, uploadID string, parts []storage.CompletedPart) (Confirmed, error) {
+	if len(parts) == 0 || len(parts) > maxPartNumber {
+		return Confirmed{}, ErrInvalidPart
+	}
+	if err := checkMultipart(userID, key, uploadID); err != nil {
+		return Confirmed{}, err
+	}
+
+	err := storage.DefaultStore.CompleteMultipartUpload(ctx, key, uploadID, parts)
+	if errors.Is(err, storage.ErrNoSuchUpload) {
+		return Confirmed{}, ErrNotMultipart
+	}
+	if errors.Is(err, storage.ErrInvalidPart) {
+		return Confirmed{}, fmt.Errorf("%w: %v", ErrInvalidPart, err)
+	}
+	if errors.Is(err, storage.ErrExists) {
+		return Confirmed{}, ErrAlreadyUsed
+	}
+	if err != nil {
+		return Confirmed{}, err
+	}
+
+	_, err = database.DB.Exec(`
+		UPDATE uploads
+		SET multipart_upload_id = NULL
+		WHERE object_key = ? AND multipart_upload_id = ?
+	`, key, uploadID)
+	if err != nil {
+		return Confirmed{}, fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return Confirm(ctx, userID, key)
+}
+
+// AbortMultipart discards a pending multipart upload and its parts. The upload is
+// rejected; the client has to initiate it again to retry.
+func AbortMultipart(ctx context.Context, userID int64, key, uploadID string) error {
+	if err := checkMultipart(userID, key, uploadID); err != nil {
+		return err
+	}
+
+	err := storage.DefaultStore.AbortMultipartUpload(ctx, key, uploadID)
+	if err != nil && !errors.Is(err, storage.ErrNoSuchUpload) {
+		return err
+	}
+
+	_, err = database.DB.Exec(`
+		UPDATE uploads
+		SET status = 'rejected', multipart_upload_id = NULL
+		WHERE object_key = ? AND multipart_upload_id = ?
+	`, key, uploadID)
+	if err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return nil
+}
+
+// checkMultipart makes sure uploadID is the pending multipart upload of key, and
+// that it is userID's.
+func checkMultipart(userID int64, key, uploadID string) error {
+	var found int
+	err := database.DB.QueryRow(`
+		SELECT 1
+		FROM uploads
+		WHERE object_key = ?
+			AND user_id = ?
+			AND multipart_upload_id = ?
+			AND status = 'pending'
+	`, key, userID, uploadID).Scan(&found)
+	if err == sql.ErrNoRows {
+		return ErrNotMultipart
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get multipart upload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,574 @@
+// Package uploads enforces what users may upload and makes sure nothing is stored
+// against a post or profile until it has really been uploaded. A presigned URL is
+// only handed out for an allowed content type and size, and is recorded as a
+// pending upload. Once the client has PUT the file it confirms the upload, and the
+// object is checked: it has to exist, be within the size limit and sniff as the
+// type that was declared. Audio and video are probed instead of sniffed, which
+// also yields their duration and codec. Only confirmed uploads can then be
+// referenced by post_media or user_images, and URLs that do not point into our
+// storage at all are refused. Files too large for a single PUT are uploaded in
+// parts; see InitiateMultipart. Voice notes are also held to a maximum duration,
+// and get a waveform for clients to draw.
+package uploads
+
+import (
+	"VoizyServer/internal/database"
+	"VoizyServer/internal/mediaprobe"
+	"VoizyServer/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// Purpose is what an upload is for. It decides which types and sizes are allowed.
+type Purpose string
+
+const (
+	PostMedia Purpose = "post_media"
+	UserImage Purpose = "user_image"
+	VoiceNote Purpose = "voice_note"
+)
+
+// presignExpiry is how long a presigned upload URL stays valid.
+const presignExpiry = 5 * time.Minute
+
+// sniffBytes is how much of an object is read to sniff its type, which is all
+// http.DetectContentType looks at.
+const sniffBytes = 512
+
+const (
+	maxImageBytes     = 10 << 20
+	maxAudioBytes     = 100 << 20
+	maxVideoBytes     = 500 << 20
+	maxVoiceNoteBytes = 10 << 20
+)
+
+// MaxVoiceNoteDuration is the longest voice note that can be sent.
+const MaxVoiceNoteDuration = 5 * time.Minute
+
+// WaveformBuckets is how many levels a voice note's waveform has.
+const WaveformBuckets = 64
+
+// policies lists, per purpose, the content types that may be uploaded and the
+// largest allowed size of each.
+var policies = map[Purpose]map[string]int64{
+	PostMedia: {
+		"image/jpeg":      maxImageBytes,
+		"image/png":       maxImageBytes,
+		"image/gif":       maxImageBytes,
+		"image/webp":      maxImageBytes,
+		"video/mp4":       maxVideoBytes,
+		"video/quicktime": maxVideoBytes,
+		"video/webm":      maxVideoBytes,
+		"audio/mpeg":      maxAudioBytes,
+		"audio/mp4":       maxAudioBytes,
+		"audio/ogg":       maxAudioBytes,
+		"audio/webm":      maxAudioBytes,
+		"audio/wav":       maxAudioBytes,
+	},
+	UserImage: {
+		"image/jpeg": maxImageBytes,
+		"image/png":  maxImageBytes,
+		"image/gif":  maxImageBytes,
+		"image/webp": maxImageBytes,
+	},
+	// WebM is left out: browsers do not record its duration, so the maximum could
+	// not be enforced.
+	VoiceNote: {
+		"audio/ogg":  maxVoiceNoteBytes,
+		"audio/mp4":  maxVoiceNoteBytes,
+		"audio/mpeg": maxVoiceNoteBytes,
+		"audio/wav":  maxVoiceNoteBytes,
+	},
+}
+
+// maxDurations caps how long the audio and video of a purpose may play. Uploads
+// of such a purpose have to record their duration.
+var maxDurations = map[Purpose]time.Duration{
+	VoiceNote: MaxVoiceNoteDuration,
+}
+
+// containers maps the audio and video types in the policies to the container
+// mediaprobe has to find for an upload to be what it claims.
+var containers = map[string]string{
+	"video/mp4":       "mp4",
+	"video/quicktime": "mp4",
+	"video/webm":      "webm",
+	"audio/mpeg":      "mp3",
+	"audio/mp4":       "mp4",
+	"audio/ogg":       "ogg",
+	"audio/webm":      "webm",
+	"audio/wav":       "wav",
+}
+
+// contentTypeAliases maps the nonstandard names browsers and recorders use for
+// audio to the ones the policies know.
+var contentTypeAliases = map[string]string{
+	"audio/x-wav":    "audio/wav",
+	"audio/wave":     "audio/wav",
+	"audio/vnd.wave": "audio/wav",
+	"audio/x-m4a":    "audio/mp4",
+	"audio/m4a":      "audio/mp4",
+	"audio/mp3":      "audio/mpeg",
+}
+
+// extensionTypes covers media extensions the system MIME table may not know.
+var extensionTypes = map[string]string{
+	".mp4":  "video/mp4",
+	".mov":  "video/quicktime",
+	".webm": "video/webm",
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".opus": "audio/ogg",
+	".wav":  "audio/wav",
+}
+
+var (
+	ErrInvalidFileName = errors.New("file name must be a plain name without a path")
+	ErrUnsupportedType = errors.New("content type is not allowed for this upload")
+	ErrTooLarge        = errors.New("file is larger than allowed for this upload")
+	ErrSizeMismatch    = errors.New("uploaded file is not the size that was declared")
+	ErrAlreadyUsed     = errors.New("a confirmed upload already exists under this file name")
+	ErrUploadNotFound  = errors.New("no pending upload with this file name")
+	ErrNotUploaded     = errors.New("file has not been uploaded yet")
+	ErrTypeMismatch    = errors.New("uploaded file does not match its declared content type")
+	ErrForeignURL      = errors.New("media URL does not point to an upload")
+	ErrNotConfirmed    = errors.New("media URL is not a confirmed upload")
+	ErrNotMultipart    = errors.New("no pending multipart upload with this file name and upload ID")
+	ErrInvalidPart     = errors.New("invalid part number or completed part list")
+	ErrTooLong         = errors.New("recording is longer than allowed for this upload")
+	ErrNoDuration      = errors.New("recording does not say how long it plays")
+	ErrChanged         = errors.New("file changed while it was being checked")
+)
+
+// HTTPStatus maps the errors of this package to a response status.
+func HTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrUploadNotFound), errors.Is(err, ErrNotMultipart):
+		return http.StatusNotFound
+	case errors.Is(err, ErrAlreadyUsed), errors.Is(err, ErrChanged):
+		return http.StatusConflict
+	case errors.Is(err, ErrTooLarge), errors.Is(err, ErrTooLong):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedType), errors.Is(err, ErrTypeMismatch):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrInvalidFileName), errors.Is(err, ErrNotUploaded),
+		errors.Is(err, ErrForeignURL), errors.Is(err, ErrNotConfirmed), errors.Is(err, ErrInvalidPart),
+		errors.Is(err, ErrNoDuration), errors.Is(err, ErrSizeMismatch):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// Presigned is an upload the client may now PUT to URL. It has to be sent with
+// Headers, and with the declared size when there was one. The URL only creates the
+// file, so it cannot be used to replace it once it has been confirmed.
+type Presigned struct {
+	Key          string
+	PresignedURL string
+	FinalURL     string
+	ContentType  string
+	MaxSize      int64
+	Headers      map[string]string
+}
+
+// Confirmed is an upload that has been checked and can be referenced. DurationMS
+// and Codec are only known for audio and video, and DurationMS not even for all of
+// those. Waveform is only drawn for voice notes in a format it can be read from.
+type Confirmed struct {
+	Key         string
+	FinalURL    string
+	ContentType string
+	Size        int64
+	DurationMS  int64
+	Codec       string
+	Waveform    []byte
+}
+
+// Kind is "image", "video" or "audio" for uploads of contentType, which is what
+// post_media records as the media type. It is empty for any other type.
+func (c Confirmed) Kind() string {
+	return Kind(c.ContentType)
+}
+
+// ContentType returns the declared type of a file, falling back to the type its
+// extension implies for clients that only send file names.
+func ContentType(fileName, declared string) string {
+	if declared == "" {
+		ext := strings.ToLower(path.Ext(fileName))
+		declared = mime.TypeByExtension(ext)
+		if declared == "" {
+			declared = extensionTypes[ext]
+		}
+	}
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return ""
+	}
+	if alias, ok := contentTypeAliases[mediaType]; ok {
+		return alias
+	}
+	return mediaType
+}
+
+// Kind is "image", "video" or "audio" depending on the top-level type of
+// contentType, and empty for anything else.
+func Kind(contentType string) string {
+	kind, _, _ := strings.Cut(contentType, "/")
+	switch kind {
+	case "image", "video", "audio":
+		return kind
+	}
+	return ""
+}
+
+// Presign checks a file against the policy for purpose, records it as a pending
+// upload under dir/fileName and returns where to upload it. size may be zero when
+// the client does not know it up front; it is then only checked on confirmation.
+func Presign(ctx context.Context, userID int64, purpose Purpose, dir, fileName, contentType string, size int64) (Presigned, error) {
+	maxSize, err := checkPolicy(purpose, fileName, contentType, size)
+	if err != nil {
+		return Presigned{}, err
+	}
+	key := dir + "/" + fileName
+	if err := recordPending(ctx, userID, purpose, key, contentType, size); err != nil {
+		return Presigned{}, err
+	}
+
+	presignedURL, err := storage.DefaultStore.PresignPut(ctx, key, storage.PutOptions{
+		Expires:       presignExpiry,
+		ContentType:   contentType,
+		ContentLength: size,
+	})
+	if err != nil {
+		return Presigned{}, err
+	}
+
+	return Presigned{
+		Key:          key,
+		PresignedURL: presignedURL,
+		FinalURL:     storage.DefaultStore.URL(key),
+		ContentType:  contentType,
+		MaxSize:      maxSize,
+		Headers: map[string]string{
+			"Content-Type":           contentType,
+			storage.CreateOnlyHeader: storage.CreateOnlyValue,
+		},
+	}, nil
+}
+
+// checkPolicy returns the largest size allowed for a file, or why it may not be
+// uploaded at all.
+func checkPolicy(purpose Purpose, fileName, contentType string, size int64) (int64, error) {
+	if fileName == "" || path.Base(fileName) != fileName || strings.HasPrefix(fileName, ".") {
+		return 0, ErrInvalidFileName
+	}
+	maxSize, ok := policies[purpose][contentType]
+	if !ok {
+		return 0, ErrUnsupportedType
+	}
+	if size > maxSize {
+		return 0, ErrTooLarge
+	}
+	return maxSize, nil
+}
+
+// recordPending records key as a pending upload, starting over if an earlier
+// attempt was never confirmed.
+func recordPending(ctx context.Context, userID int64, purpose Purpose, key, contentType string, size int64) error {
+	// A key that is already confirmed may be referenced by a post or profile, so it
+	// cannot be uploaded over again without going through the checks.
+	result, err := database.DB.Exec(`
+		INSERT INTO uploads (user_id, object_key, purpose, content_type, declared_size)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			purpose = IF(status = 'confirmed', purpose, VALUES(purpose)),
+			content_type = IF(status = 'confirmed', content_type, VALUES(content_type)),
+			declared_size = IF(status = 'confirmed', declared_size, VALUES(declared_size)),
+			created_at = IF(status = 'confirmed', created_at, NOW()),
+			multipart_upload_id = IF(status = 'confirmed', multipart_upload_id, NULL),
+			status = IF(status = 'confirmed', status, 'pending')
+	`, userID, key, purpose, contentType, nullIfZero(size))
+	if err != nil {
+		return fmt.Errorf("failed to record upload: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		var status string
+		err := database.DB.QueryRow(`SELECT status FROM uploads WHERE object_key = ?`, key).Scan(&status)
+		if err != nil {
+			return fmt.Errorf("failed to check upload: %w", err)
+		}
+		if status == "confirmed" {
+			return ErrAlreadyUsed
+		}
+	}
+	// Uploads only create objects, so whatever an unconfirmed attempt left behind
+	// has to go for the new one to succeed.
+	return storage.DefaultStore.Delete(ctx, key)
+}
+
+func nullIfZero(n int64) interface{} {
+	if n == 0 {
+		return nil
+	}
+	return n
+}
+
+// Confirm checks the object the user uploaded under key and, if it is what was
+// declared, marks the upload confirmed. An object that breaks the policy is deleted
+// and the upload rejected; the client has to presign it again.
+func Confirm(ctx context.Context, userID int64, key string) (Confirmed, error) {
+	var (
+		purpose      Purpose
+		contentType  string
+		declaredSize sql.NullInt64
+		status       string
+		durationMS   sql.NullInt64
+		codec        sql.NullString
+		waveform     []byte
+	)
+	err := database.DB.QueryRow(`
+		SELECT purpose, content_type, declared_size, status, duration_ms, codec, waveform
+		FROM uploads
+		WHERE object_key = ? AND user_id = ?
+	`, key, userID).Scan(&purpose, &contentType, &declaredSize, &status, &durationMS, &codec, &waveform)
+	if err == sql.ErrNoRows {
+		return Confirmed{}, ErrUploadNotFound
+	}
+	if err != nil {
+		return Confirmed{}, fmt.Errorf("failed to get upload: %w", err)
+	}
+
+	store := storage.DefaultStore
+	info, err := store.Head(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return Confirmed{}, ErrNotUploaded
+	}
+	if err != nil {
+		return Confirmed{}, err
+	}
+	confirmed := Confirmed{
+		Key:         key,
+		FinalURL:    store.URL(key),
+		ContentType: contentType,
+		Size:        info.Size,
+		DurationMS:  durationMS.Int64,
+		Codec:       codec.String,
+		Waveform:    waveform,
+	}
+	if status == "confirmed" {
+		return confirmed, nil
+	}
+	if status == "rejected" {
+		return Confirmed{}, ErrUploadNotFound
+	}
+
+	var policyErr error
+	if maxSize, ok := policies[purpose][contentType]; !ok {
+		policyErr = ErrUnsupportedType
+	} else if info.Size > maxSize {
+		policyErr = ErrTooLarge
+	} else if declaredSize.Valid && info.Size != declaredSize.Int64 {
+		policyErr = ErrSizeMismatch
+	} else if container, ok := containers[contentType]; ok {
+		media, err := mediaprobe.Probe(storage.ReaderAt(ctx, store, key), info.Size)
+		switch {
+		case errors.Is(err, mediaprobe.ErrUnknownFormat), errors.Is(err, mediaprobe.ErrMalformed):
+			policyErr = ErrTypeMismatch
+		case err != nil:
+			return Confirmed{}, err
+		case !matchesKind(media, container, Kind(contentType)):
+			policyErr = ErrTypeMismatch
+		default:
+			policyErr = checkDuration(purpose, media.Duration)
+			confirmed.DurationMS = media.Duration.Milliseconds()
+			confirmed.Codec = media.Codec()
+		}
+		if policyErr == nil && purpose == VoiceNote {
+			confirmed.Waveform, err = mediaprobe.Waveform(storage.ReaderAt(ctx, store, key), info.Size, WaveformBuckets)
+			// Formats a waveform cannot be read from are still fine to send.
+			if err != nil && !errors.Is(err, mediaprobe.ErrUnknownFormat) && !errors.Is(err, mediaprobe.ErrMalformed) {
+				return Confirmed{}, err
+			}
+		}
+	} else {
+		sniffed, err := sniff(ctx, key)
+		if err != nil {
+			return Confirmed{}, err
+		}
+		if sniffed != contentType {
+			policyErr = ErrTypeMismatch
+		}
+	}
+	if policyErr == nil {
+		// The checks read the object in several requests; make sure they all saw
+		// the one whose size was checked.
+		after, err := store.Head(ctx, key)
+		if errors.Is(err, storage.ErrNotFound) {
+			return Confirmed{}, ErrChanged
+		}
+		if err != nil {
+			return Confirmed{}, err
+		}
+		if after.Size != info.Size || !after.LastModified.Equal(info.LastModified) {
+			return Confirmed{}, ErrChanged
+		}
+	}
+	if policyErr != nil {
+		if err := store.Delete(ctx, key); err != nil {
+			return Confirmed{}, err
+		}
+		if _, err := database.DB.Exec(`UPDATE uploads SET status = 'rejected' WHERE object_key = ?`, key); err != nil {
+			return Confirmed{}, fmt.Errorf("failed to reject upload: %w", err)
+		}
+		return Confirmed{}, policyErr
+	}
+
+	_, err = database.DB.Exec(`
+		UPDATE uploads
+		SET status = 'confirmed', size = ?, duration_ms = ?, codec = ?, waveform = ?, confirmed_at = NOW()
+		WHERE object_key = ? AND status = 'pending'
+	`, info.Size, nullIfZero(confirmed.DurationMS), nullIfEmpty(confirmed.Codec), confirmed.Waveform, key)
+	if err != nil {
+		return Confirmed{}, fmt.Errorf("failed to confirm upload: %w", err)
+	}
+	return confirmed, nil
+}
+
+// checkDuration holds audio and video to the maximum duration of purpose, if it
+// has one.
+func checkDuration(purpose Purpose, duration time.Duration) error {
+	limit, ok := maxDurations[purpose]
+	switch {
+	case !ok:
+		return nil
+	case duration <= 0:
+		return ErrNoDuration
+	case duration > limit:
+		return ErrTooLong
+	}
+	return nil
+}
+
+// matchesKind reports whether a probed file is in the expected container and has
+// the tracks its kind needs: audio must not carry a video track, video must.
+func matchesKind(media mediaprobe.Info, container, kind string) bool {
+	if media.Container != container {
+		return false
+	}
+	if kind == "audio" {
+		return media.HasAudio && !media.HasVideo
+	}
+	return media.HasVideo
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// sniff returns the content type the start of the object looks like.
+func sniff(ctx context.Context, key string) (string, error) {
+	body, err := storage.DefaultStore.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", ErrNotUploaded
+	}
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	head := make([]byte, sniffBytes)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("failed to read upload: %w", err)
+	}
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	return mediaType, nil
+}
+
+// Verify checks that every URL in urls is a confirmed upload of ownerID for
+// purpose, so it can be stored against a post or profile.
+func Verify(ownerID int64, purpose Purpose, urls []string) error {
+	_, err := Lookup(ownerID, purpose, urls)
+	return err
+}
+
+// Lookup is Verify for callers that also need to know what was uploaded. It
+// returns the confirmed upload behind each URL, keyed by the URL.
+func Lookup(ownerID int64, purpose Purpose, urls []string) (map[string]Confirmed, error) {
+	found := make(map[string]Confirmed, len(urls))
+	keys := make([]string, 0, len(urls))
+	urlsByKey := make(map[string][]string, len(urls))
+	for _, u := range urls {
+		if u == "" {
+			continue
+		}
+		key, ok := storage.DefaultStore.KeyFromURL(u)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrForeignURL, u)
+		}
+		if _, seen := urlsByKey[key]; !seen {
+			keys = append(keys, key)
+		}
+		urlsByKey[key] = append(urlsByKey[key], u)
+	}
+	if len(keys) == 0 {
+		return found, nil
+	}
+
+	placeholders := make([]string, len(keys))
+	args := make([]interface{}, 0, len(keys)+2)
+	args = append(args, ownerID, purpose)
+	for i, key := range keys {
+		placeholders[i] = "?"
+		args = append(args, key)
+	}
+	rows, err := database.DB.Query(`
+		SELECT object_key, content_type, COALESCE(size, 0), COALESCE(duration_ms, 0), COALESCE(codec, ''), waveform
+		FROM uploads
+		WHERE user_id = ?
+			AND purpose = ?
+			AND status = 'confirmed'
+			AND object_key IN (`+strings.Join(placeholders, ",")+`)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check uploads: %w", err)
+	}
+	defer rows.Close()
+
+	confirmed := make(map[string]Confirmed, len(keys))
+	for rows.Next() {
+		var upload Confirmed
+		if err := rows.Scan(&upload.Key, &upload.ContentType, &upload.Size, &upload.DurationMS, &upload.Codec, &upload.Waveform); err != nil {
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+		upload.FinalURL = storage.DefaultStore.URL(upload.Key)
+		confirmed[upload.Key] = upload
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over uploads: %w", err)
+	}
+
+	for _, key := range keys {
+		upload, ok := confirmed[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotConfirmed, storage.DefaultStore.URL(key))
+		}
+		for _, u := range urlsByKey[key] {
+			found[u] = upload
+		}
+	}
+	return found, nil
+}
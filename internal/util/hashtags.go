@@ -0,0 +1,52 @@
+package util
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+const maxHashtagLength = 100
+
+var hashtagFolder = cases.Fold()
+
+// NormalizeHashtag returns the canonical form a tag is stored and looked up by:
+// without leading '#', Unicode NFKC, case-folded, and with everything but letters,
+// marks, digits and '_' removed, so "#Go", "go", "#go!" and the full-width "ＧＯ"
+// are the same tag. It returns "" when nothing usable is left.
+func NormalizeHashtag(tag string) string {
+	tag = strings.TrimLeft(strings.TrimSpace(tag), "#")
+	tag = hashtagFolder.String(norm.NFKC.String(tag))
+
+	var b strings.Builder
+	length := 0
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsDigit(r) && r != '_' {
+			continue
+		}
+		if length == maxHashtagLength {
+			break
+		}
+		b.WriteRune(r)
+		length++
+	}
+
+	return norm.NFC.String(b.String())
+}
+
+// NormalizeHashtags normalizes each tag and drops empty results and duplicates,
+// keeping the first occurrence's position.
+func NormalizeHashtags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, t := range tags {
+		n := NormalizeHashtag(t)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		normalized = append(normalized, n)
+	}
+	return normalized
+}
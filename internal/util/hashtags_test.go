@@ -0,0 +1,49 @@
+package util
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeHashtag(t *testing.T) {
+	tests := []struct {
+		tag, want string
+	}{
+		{"#Go", "go"},
+		{"  ##go!  ", "go"},
+		{"GO_lang-2", "go_lang2"},
+		{"\u0130stanbul", "i\u0307stanbul"},
+		{"ISTANBUL", "istanbul"},
+		{"caf\u00e9", "caf\u00e9"},
+		{"cafe\u0301", "caf\u00e9"},
+		{"CAF\u00c9", "caf\u00e9"},
+		{"ＧＯ", "go"},
+		{"＃ｇｏ", "go"},
+		{"Straße", "strasse"},
+		{strings.Repeat("a", maxHashtagLength+50), strings.Repeat("a", maxHashtagLength)},
+		{strings.Repeat("!a", maxHashtagLength+50), strings.Repeat("a", maxHashtagLength)},
+		{"#!?", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeHashtag(tt.tag); got != tt.want {
+			t.Errorf("NormalizeHashtag(%.40q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeHashtags(t *testing.T) {
+	tests := []struct {
+		tags, want []string
+	}{
+		{nil, []string{}},
+		{[]string{"#Go", "go", "GO!", "ＧＯ"}, []string{"go"}},
+		{[]string{"rust", "", "#", "caf\u00e9", "cafe\u0301", "Go", "RUST"}, []string{"rust", "caf\u00e9", "go"}},
+	}
+	for _, tt := range tests {
+		if got := NormalizeHashtags(tt.tags); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("NormalizeHashtags(%q) = %q, want %q", tt.tags, got, tt.want)
+		}
+	}
+}